@@ -0,0 +1,107 @@
+//nolint:forbidigo // it's okay to use fmt in this file
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/ya-breeze/diary.be/pkg/database"
+	"github.com/ya-breeze/diary.be/pkg/server/assets"
+)
+
+func CmdAssets() *cobra.Command {
+	res := &cobra.Command{
+		Use:   "assets",
+		Short: "Maintain asset files",
+		Run: func(_ *cobra.Command, _ []string) {
+		},
+	}
+
+	res.AddCommand(NewAssetsGC(), NewAssetsRestore())
+
+	return res
+}
+
+func NewAssetsGC() *cobra.Command {
+	var dryRun bool
+	var graceDays int
+
+	res := &cobra.Command{
+		Use:   "gc",
+		Short: "Move assets that no item references to the trash",
+		Long: "Move assets that no item references and that are older than the grace period to the trash " +
+			"and remove leftovers of interrupted uploads",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			collector, err := newGarbageCollector(cmd)
+			if err != nil {
+				return err
+			}
+
+			report, err := collector.Collect(cmd.Context(), time.Now().AddDate(0, 0, -graceDays), dryRun)
+			if err != nil {
+				return err
+			}
+
+			for _, f := range report.Orphans {
+				fmt.Printf("orphan\t%s\t%s\t%d\t%s\n", f.UserID, f.Name, f.Size, f.ModTime.Format(time.RFC3339))
+			}
+			for _, f := range report.TempFiles {
+				fmt.Printf("temp\t%s\t%s\t%d\t%s\n", f.UserID, f.Name, f.Size, f.ModTime.Format(time.RFC3339))
+			}
+			action := "moved to trash"
+			if dryRun {
+				action = "would be moved to trash"
+			}
+			fmt.Printf("%d orphaned assets (%d bytes) %s, %d temporary files\n",
+				len(report.Orphans), report.OrphansSize(), action, len(report.TempFiles))
+
+			return nil
+		},
+	}
+	res.Flags().BoolVar(&dryRun, "dry-run", false, "only report the files that would be collected")
+	res.Flags().IntVar(&graceDays, "grace-days", 7, "keep unreferenced assets modified within this many days")
+
+	return res
+}
+
+func NewAssetsRestore() *cobra.Command {
+	res := &cobra.Command{
+		Use:   "restore <userID> [name...]",
+		Short: "Restore trashed assets of a user, all of them if no names are given",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			collector, err := newGarbageCollector(cmd)
+			if err != nil {
+				return err
+			}
+
+			restored, err := collector.Restore(cmd.Context(), args[0], args[1:])
+			for _, name := range restored {
+				fmt.Printf("restored\t%s\n", name)
+			}
+			return err
+		},
+	}
+
+	return res
+}
+
+func newGarbageCollector(cmd *cobra.Command) (*assets.GarbageCollector, error) {
+	cfg, logger, err := createConfigAndLogger(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	storage := database.NewStorage(logger, cfg)
+	if err := storage.Open(); err != nil {
+		return nil, fmt.Errorf("failed to open storage: %w", err)
+	}
+
+	blobs, err := assets.NewBlobStore(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create asset storage: %w", err)
+	}
+
+	return assets.NewGarbageCollector(logger, cfg, storage, assets.WithEncryption(blobs, storage.Keyring(), storage)), nil
+}
@@ -0,0 +1,126 @@
+//nolint:forbidigo // it's okay to use fmt in this file
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	"github.com/ya-breeze/diary.be/pkg/config"
+	"github.com/ya-breeze/diary.be/pkg/database"
+	"github.com/ya-breeze/diary.be/pkg/encryption"
+	"github.com/ya-breeze/diary.be/pkg/server/assets"
+)
+
+func CmdEncryption() *cobra.Command {
+	res := &cobra.Command{
+		Use:   "encryption",
+		Short: "Manage encryption at rest",
+		Run: func(_ *cobra.Command, _ []string) {
+		},
+	}
+
+	res.AddCommand(NewEncryptionGenerateKey(), NewEncryptionMigrate(), NewEncryptionRotateKey())
+
+	return res
+}
+
+func NewEncryptionGenerateKey() *cobra.Command {
+	res := &cobra.Command{
+		Use:   "generate-key",
+		Short: "Print a new random master key",
+		Run: func(_ *cobra.Command, _ []string) {
+			fmt.Println(encryption.GenerateKey())
+		},
+	}
+
+	return res
+}
+
+func NewEncryptionMigrate() *cobra.Command {
+	res := &cobra.Command{
+		Use:   "migrate",
+		Short: "Encrypt the entries and assets stored before encryption was enabled",
+		Long: "Encrypt the entries and assets stored before encryption was enabled. Entries are also encrypted " +
+			"when the server starts, assets are only encrypted by this command. Stop the server before running it.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			storage, cfg, logger, err := openEncryptedStorage(cmd)
+			if err != nil {
+				return err
+			}
+
+			blobs, err := assets.NewBlobStore(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to create asset storage: %w", err)
+			}
+			// Assets missing in the registry are registered first, the registry records which are encrypted
+			store := assets.NewEncryptedStore(blobs, storage.Keyring(), storage)
+			if err := assets.BackfillAssets(cmd.Context(), logger, storage, store); err != nil {
+				return fmt.Errorf("failed to backfill assets: %w", err)
+			}
+			encrypted, err := store.EncryptBlobs(cmd.Context())
+			for _, key := range encrypted {
+				fmt.Printf("encrypted\t%s\n", key)
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%d assets encrypted\n", len(encrypted))
+
+			return nil
+		},
+	}
+
+	return res
+}
+
+func NewEncryptionRotateKey() *cobra.Command {
+	var newKey string
+
+	res := &cobra.Command{
+		Use:   "rotate-key",
+		Short: "Wrap the data keys of all users with a new master key",
+		Long: "Wrap the data keys of all users with a new master key. The encrypted data isn't rewritten. " +
+			"Stop the server before running it and start it with the new key afterwards.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			key, err := encryption.ParseKey(newKey)
+			if err != nil {
+				return err
+			}
+			storage, _, _, err := openEncryptedStorage(cmd)
+			if err != nil {
+				return err
+			}
+
+			rotated, err := storage.RotateEncryptionKey(key)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%d data keys wrapped with the new master key, set GB_ENCRYPTIONKEY to it\n", rotated)
+
+			return nil
+		},
+	}
+	res.Flags().StringVar(&newKey, "new-key", "", "the new base64 encoded master key, see generate-key")
+	_ = res.MarkFlagRequired("new-key")
+
+	return res
+}
+
+// openEncryptedStorage opens the storage with the configured master key
+func openEncryptedStorage(cmd *cobra.Command) (database.Storage, *config.Config, *slog.Logger, error) {
+	cfg, logger, err := createConfigAndLogger(cmd)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if cfg.EncryptionKey == "" {
+		return nil, nil, nil, errors.New("no master key is configured, set GB_ENCRYPTIONKEY")
+	}
+
+	storage := database.NewStorage(logger, cfg)
+	if err := storage.Open(); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to open storage: %w", err)
+	}
+	return storage, cfg, logger, nil
+}
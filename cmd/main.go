@@ -37,6 +37,8 @@ func newRootCmd(cfgFile *string, logger *slog.Logger) *cobra.Command {
 	rootCmd.AddCommand(
 		commands.CmdUser(logger),
 		commands.CmdServer(),
+		commands.CmdAssets(),
+		commands.CmdEncryption(),
 	)
 
 	return rootCmd
@@ -25,6 +25,37 @@ type Config struct {
 	MaxPerFileSizeMB    int `mapstructure:"maxperfilesizemb" default:"200"`
 	MaxBatchFiles       int `mapstructure:"maxbatchfiles" default:"100"`
 	MaxBatchTotalSizeMB int `mapstructure:"maxbatchtotalsizemb" default:"1000"`
+
+	// Per-user storage quotas, 0 is unlimited
+	UserQuotaMB     int `mapstructure:"userquotamb" default:"0"`
+	UserQuotaFiles  int `mapstructure:"userquotafiles" default:"0"`
+	UserQuotaItems  int `mapstructure:"userquotaitems" default:"0"`
+	UserQuotaBodyMB int `mapstructure:"userquotabodymb" default:"0"`
+
+	// Sync changes older than this are compacted, 0 disables compaction
+	SyncRetentionDays int `mapstructure:"syncretentiondays" default:"30"`
+
+	// Unreferenced assets older than this are moved to the trash daily, 0 disables the collection
+	AssetGCGraceDays int `mapstructure:"assetgcgracedays" default:"0"`
+
+	// Encoding of image thumbnails and medium variants, "webp" or empty to keep JPEG/PNG
+	AssetVariantFormat string `mapstructure:"assetvariantformat" default:""`
+
+	// Where assets are stored, "local" keeps them in AssetPath and "s3" in the configured S3 bucket
+	AssetStorage string `mapstructure:"assetstorage" default:"local"`
+	S3Endpoint   string `mapstructure:"s3endpoint" default:""`
+	S3Bucket     string `mapstructure:"s3bucket" default:""`
+	S3Prefix     string `mapstructure:"s3prefix" default:""`
+	S3Region     string `mapstructure:"s3region" default:""`
+	S3AccessKey  string `mapstructure:"s3accesskey" default:""`
+	S3SecretKey  string `mapstructure:"s3secretkey" default:""`
+	S3UseSSL     bool   `mapstructure:"s3usessl" default:"true"`
+
+	// Base64 encoded 32 byte master key, entries and assets are encrypted at rest if it's set
+	EncryptionKey string `mapstructure:"encryptionkey" default:""`
+
+	// Remove GPS data from uploaded photos
+	StripPhotoLocation bool `mapstructure:"stripphotolocation" default:"false"`
 }
 
 func InitiateConfig(cfgFile string) (*Config, error) {
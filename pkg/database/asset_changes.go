@@ -0,0 +1,35 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ya-breeze/diary.be/pkg/database/models"
+	"gorm.io/gorm"
+)
+
+// #region Asset changes
+
+// createAssetChangeRecordsInTx records that assets of the user were created or deleted within an
+// existing transaction, so synchronized clients can mirror the asset directory of the user
+func (s *storage) createAssetChangeRecordsInTx(
+	tx *gorm.DB, userID string, operationType models.OperationType, assets []*models.AssetInfo,
+) error {
+	for _, asset := range assets {
+		change := &models.ItemChange{
+			UserID:        userID,
+			EntityType:    models.EntityTypeAsset,
+			OperationType: operationType,
+			Timestamp:     time.Now(),
+			AssetSnapshot: asset,
+		}
+		if err := tx.Create(change).Error; err != nil {
+			return fmt.Errorf(StorageError, err)
+		}
+		s.broker.stage(tx, userID)
+	}
+
+	return nil
+}
+
+// #endregion Asset changes
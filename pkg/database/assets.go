@@ -0,0 +1,332 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ya-breeze/diary.be/pkg/database/models"
+	"github.com/ya-breeze/diary.be/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// #region Assets
+
+// AssetFilter selects assets returned by GetAssets, all non-empty conditions must hold
+type AssetFilter struct {
+	// ContentType matches assets whose MIME type starts with the value, e.g. "image/" or "video/mp4"
+	ContentType string
+	// Date matches assets referenced by the item of the date
+	Date string
+	// Unreferenced matches assets that aren't referenced by any item
+	Unreferenced bool
+	// Limit is the maximum number of assets to return, 0 means no limit
+	Limit int
+	// Offset is the number of matching assets to skip
+	Offset int
+}
+
+// CreateAssets registers uploaded assets of the user and records their creation in the change log.
+// Assets are named after their content, so uploading a registered asset again changes nothing.
+// A trashed asset uploaded again is restored. ErrQuotaExceeded is returned and nothing is registered
+// if the new assets exceed the quota of the user.
+func (s *storage) CreateAssets(userID string, assets []*models.Asset) error {
+	return s.createAssets(userID, assets, UserQuota(s.cfg))
+}
+
+// createAssets registers the assets in their own transaction, see createAssetsInTx
+func (s *storage) createAssets(userID string, assets []*models.Asset, quota Quota) error {
+	if len(assets) == 0 {
+		return nil
+	}
+
+	// Start a transaction to ensure atomicity
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return fmt.Errorf(StorageError, tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			s.rollbackTx(tx)
+		}
+	}()
+
+	if err := s.createAssetsInTx(tx, userID, assets, quota); err != nil {
+		s.rollbackTx(tx)
+		return err
+	}
+
+	// Commit the transaction
+	if err := s.commitTx(tx); err != nil {
+		return fmt.Errorf(StorageError, err)
+	}
+
+	return nil
+}
+
+// createAssetsInTx registers the assets within an existing transaction, new assets are checked
+// against the quota
+func (s *storage) createAssetsInTx(tx *gorm.DB, userID string, assets []*models.Asset, quota Quota) error {
+	created := false
+	infos := make([]*models.AssetInfo, 0, len(assets))
+	for _, asset := range assets {
+		asset.UserID = userID
+
+		var existing models.Asset
+		err := tx.Where("user_id = ? AND name = ?", userID, asset.Name).First(&existing).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if err := tx.Create(asset).Error; err != nil {
+				return fmt.Errorf(StorageError, err)
+			}
+			created = true
+			infos = append(infos, asset.ToAssetInfo())
+		case err != nil:
+			return fmt.Errorf(StorageError, err)
+		default:
+			if existing.TrashedAt != nil {
+				if err := tx.Model(&existing).Update("trashed_at", nil).Error; err != nil {
+					return fmt.Errorf(StorageError, err)
+				}
+				infos = append(infos, existing.ToAssetInfo())
+			}
+			*asset = existing
+			asset.TrashedAt = nil
+		}
+	}
+
+	if created {
+		if err := checkAssetQuotaInTx(tx, userID, quota); err != nil {
+			return err
+		}
+	}
+
+	return s.createAssetChangeRecordsInTx(tx, userID, models.OperationTypeCreated, infos)
+}
+
+// TrashAssets marks assets of the user as moved to the trash and records their deletion in the
+// change log. Unknown and already trashed names are ignored.
+func (s *storage) TrashAssets(userID string, names []string) error {
+	return s.setAssetsTrashed(userID, names, true)
+}
+
+// RestoreAssets reverts TrashAssets and records the creation of the restored assets in the change log
+func (s *storage) RestoreAssets(userID string, names []string) error {
+	return s.setAssetsTrashed(userID, names, false)
+}
+
+func (s *storage) setAssetsTrashed(userID string, names []string, trashed bool) error {
+	// Start a transaction to ensure atomicity
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return fmt.Errorf(StorageError, tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			s.rollbackTx(tx)
+		}
+	}()
+
+	query := tx.Where("user_id = ? AND name IN ?", userID, names)
+	operationType := models.OperationTypeCreated
+	var trashedAt *time.Time
+	if trashed {
+		query = query.Where("trashed_at IS NULL")
+		operationType = models.OperationTypeDeleted
+		now := time.Now()
+		trashedAt = &now
+	} else {
+		query = query.Where("trashed_at IS NOT NULL")
+	}
+
+	var assets []*models.Asset
+	if err := query.Order("name ASC").Find(&assets).Error; err != nil {
+		s.rollbackTx(tx)
+		return fmt.Errorf(StorageError, err)
+	}
+	if len(assets) == 0 {
+		s.rollbackTx(tx)
+		return nil
+	}
+
+	changed := make([]string, 0, len(assets))
+	infos := make([]*models.AssetInfo, 0, len(assets))
+	for _, asset := range assets {
+		changed = append(changed, asset.Name)
+		infos = append(infos, asset.ToAssetInfo())
+	}
+	if err := tx.Model(&models.Asset{}).Where("user_id = ? AND name IN ?", userID, changed).
+		Update("trashed_at", trashedAt).Error; err != nil {
+		s.rollbackTx(tx)
+		return fmt.Errorf(StorageError, err)
+	}
+	if err := s.createAssetChangeRecordsInTx(tx, userID, operationType, infos); err != nil {
+		s.rollbackTx(tx)
+		return err
+	}
+
+	// Commit the transaction
+	if err := s.commitTx(tx); err != nil {
+		return fmt.Errorf(StorageError, err)
+	}
+
+	return nil
+}
+
+// GetAssets returns a page of the assets of the user that are not trashed matching the filter, newest first, with the
+// dates of the items referencing them, and the total number of matching assets
+func (s *storage) GetAssets(userID string, filter AssetFilter) ([]*models.Asset, int, error) {
+	const isReferenced = "EXISTS (SELECT 1 FROM item_assets " +
+		"WHERE item_assets.user_id = assets.user_id AND item_assets.asset_name = assets.name"
+
+	query := s.db.Model(&models.Asset{}).Where("assets.user_id = ? AND assets.trashed_at IS NULL", userID)
+	if filter.ContentType != "" {
+		query = query.Where("substr(assets.content_type, 1, ?) = ?", len(filter.ContentType), filter.ContentType)
+	}
+	if filter.Date != "" {
+		query = query.Where(isReferenced+" AND item_assets.date = ?)", filter.Date)
+	}
+	if filter.Unreferenced {
+		query = query.Where("NOT " + isReferenced + ")")
+	}
+
+	// Get total count for pagination
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, fmt.Errorf(StorageError, err)
+	}
+
+	// Apply pagination
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var assets []*models.Asset
+	if err := query.Order("assets.uploaded_at DESC, assets.name ASC").Find(&assets).Error; err != nil {
+		return nil, 0, fmt.Errorf(StorageError, err)
+	}
+	if len(assets) == 0 {
+		return assets, int(totalCount), nil
+	}
+
+	// Add the dates of the items referencing the assets of the page
+	names := make([]string, 0, len(assets))
+	byName := make(map[string]*models.Asset, len(assets))
+	for _, asset := range assets {
+		names = append(names, asset.Name)
+		byName[asset.Name] = asset
+	}
+	var links []*models.ItemAsset
+	if err := s.db.Where("user_id = ? AND asset_name IN ?", userID, names).
+		Order("date ASC").Find(&links).Error; err != nil {
+		return nil, 0, fmt.Errorf(StorageError, err)
+	}
+	for _, link := range links {
+		asset := byName[link.AssetName]
+		asset.Dates = append(asset.Dates, link.Date)
+	}
+
+	return assets, int(totalCount), nil
+}
+
+// linkAssetsInTx replaces the links of the item to the assets referenced in its body within an
+// existing transaction
+func linkAssetsInTx(tx *gorm.DB, userID, date, body string) error {
+	if err := unlinkAssetsInTx(tx, userID, date); err != nil {
+		return err
+	}
+
+	names := utils.AssetNamesFromMarkdown(body)
+	if len(names) == 0 {
+		return nil
+	}
+	links := make([]*models.ItemAsset, 0, len(names))
+	for _, name := range names {
+		links = append(links, &models.ItemAsset{UserID: userID, Date: date, AssetName: name})
+	}
+	if err := tx.Create(links).Error; err != nil {
+		return fmt.Errorf(StorageError, err)
+	}
+
+	return nil
+}
+
+// unlinkAssetsInTx removes the links of the item to assets within an existing transaction
+func unlinkAssetsInTx(tx *gorm.DB, userID, date string) error {
+	if err := tx.Where("user_id = ? AND date = ?", userID, date).Delete(&models.ItemAsset{}).Error; err != nil {
+		return fmt.Errorf(StorageError, err)
+	}
+
+	return nil
+}
+
+// HasAssets reports if any asset is registered
+func (s *storage) HasAssets() (bool, error) {
+	var count int64
+	if err := s.db.Model(&models.Asset{}).Count(&count).Error; err != nil {
+		return false, fmt.Errorf(StorageError, err)
+	}
+
+	return count > 0, nil
+}
+
+// ImportAssets registers assets found in the asset storage like CreateAssets. Files stored before
+// the quota was introduced are registered whatever it is.
+func (s *storage) ImportAssets(userID string, assets []*models.Asset) error {
+	return s.createAssets(userID, assets, Quota{})
+}
+
+// IsAssetEncrypted reports whether the asset file is stored encrypted. Unregistered assets are reported
+// as encrypted, they are stored by the encrypted store before they are registered.
+func (s *storage) IsAssetEncrypted(userID, name string) (bool, error) {
+	var asset models.Asset
+	err := s.db.Select("encrypted").Where("user_id = ? AND name = ?", userID, name).First(&asset).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf(StorageError, err)
+	}
+
+	return asset.Encrypted, nil
+}
+
+// SetAssetsEncrypted records that the asset files of the user were encrypted
+func (s *storage) SetAssetsEncrypted(userID string, names []string) error {
+	err := s.db.Model(&models.Asset{}).Where("user_id = ? AND name IN ?", userID, names).Update("encrypted", true).Error
+	if err != nil {
+		return fmt.Errorf(StorageError, err)
+	}
+
+	return nil
+}
+
+// backfillAssetLinks links all items to their assets if no links exist yet
+func (s *storage) backfillAssetLinks() error {
+	var count int64
+	if err := s.db.Model(&models.ItemAsset{}).Count(&count).Error; err != nil {
+		return fmt.Errorf(StorageError, err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	var items []*models.Item
+	if err := s.db.Select("user_id", "date", "body").Find(&items).Error; err != nil {
+		return fmt.Errorf(StorageError, err)
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		for _, item := range items {
+			if err := linkAssetsInTx(tx, item.UserID, item.Date, item.Body); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// #endregion Assets
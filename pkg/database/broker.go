@@ -0,0 +1,109 @@
+package database
+
+import (
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// changeBroker notifies in-process subscribers about new change records of a user. Change records
+// created in a transaction are held back until the transaction is committed, so subscribers never
+// see changes that were rolled back. A notification carries no data, subscribers read the new
+// changes from the change log.
+type changeBroker struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan struct{}]struct{}
+	// pending holds the users with change records in uncommitted transactions
+	pending map[*gorm.DB][]string
+}
+
+func newChangeBroker() *changeBroker {
+	return &changeBroker{
+		subscribers: make(map[string]map[chan struct{}]struct{}),
+		pending:     make(map[*gorm.DB][]string),
+	}
+}
+
+// subscribe returns a channel that receives a value when new changes of the user are committed
+// and a function to cancel the subscription. Notifications are coalesced: a subscriber that
+// doesn't keep up receives a single notification for several changes.
+func (b *changeBroker) subscribe(userID string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subscribers[userID] == nil {
+		b.subscribers[userID] = make(map[chan struct{}]struct{})
+	}
+	b.subscribers[userID][ch] = struct{}{}
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subscribers[userID], ch)
+			if len(b.subscribers[userID]) == 0 {
+				delete(b.subscribers, userID)
+			}
+		})
+	}
+}
+
+// publish notifies subscribers of the user
+func (b *changeBroker) publish(userID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers[userID] {
+		select {
+		case ch <- struct{}{}:
+		default:
+			// a notification is already pending
+		}
+	}
+}
+
+// stage remembers that the transaction created a change record of the user
+func (b *changeBroker) stage(tx *gorm.DB, userID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pending[tx] = append(b.pending[tx], userID)
+}
+
+// take removes and returns the users staged by the transaction
+func (b *changeBroker) take(tx *gorm.DB) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	users := b.pending[tx]
+	delete(b.pending, tx)
+	return users
+}
+
+// SubscribeChanges returns a channel that receives a value when new changes of the user are
+// committed and a function to cancel the subscription
+func (s *storage) SubscribeChanges(userID string) (<-chan struct{}, func()) {
+	return s.broker.subscribe(userID)
+}
+
+// commitTx commits the transaction and notifies subscribers about the change records it created
+func (s *storage) commitTx(tx *gorm.DB) error {
+	users := s.broker.take(tx)
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	notified := make(map[string]bool, len(users))
+	for _, userID := range users {
+		if !notified[userID] {
+			notified[userID] = true
+			s.broker.publish(userID)
+		}
+	}
+	return nil
+}
+
+// rollbackTx rolls the transaction back and drops the change records it staged for subscribers
+func (s *storage) rollbackTx(tx *gorm.DB) {
+	s.broker.take(tx)
+	tx.Rollback()
+}
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ya-breeze/diary.be/pkg/database/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// compactableChanges selects changes older than the cutoff that are superseded by a newer change
+// of the same item or asset, and deletions that are the last change of their item or asset
+const compactableChanges = "timestamp < ? AND (operation_type = ? OR id < (" +
+	"SELECT MAX(c.id) FROM item_changes c WHERE c.user_id = item_changes.user_id AND c.date = item_changes.date" +
+	" AND c.entity_type = item_changes.entity_type AND IFNULL(c.asset_name, '') = IFNULL(item_changes.asset_name, '')))"
+
+// lastDeletions selects deletions older than the cutoff that are the last change of their item or asset.
+// They are the only compacted changes clients can miss: a removed change that was superseded is replaced
+// by the newer change, which clients still pull.
+const lastDeletions = "timestamp < ? AND operation_type = ? AND id = (" +
+	"SELECT MAX(c.id) FROM item_changes c WHERE c.user_id = item_changes.user_id AND c.date = item_changes.date" +
+	" AND c.entity_type = item_changes.entity_type AND IFNULL(c.asset_name, '') = IFNULL(item_changes.asset_name, ''))"
+
+// #region Compaction
+
+// CompactChanges removes changes older than before that don't affect the current state of the items
+// and assets and returns the number of removed changes. The latest change of every existing item
+// and asset is kept, so clients can still pull the whole state from the change log. Removing old
+// deletions means that clients which pulled only up to a removed change must resync, see GetSyncHorizon.
+func (s *storage) CompactChanges(before time.Time) (int64, error) {
+	// Start a transaction to ensure atomicity
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return 0, fmt.Errorf(StorageError, tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var horizons []models.SyncHorizon
+	if err := tx.Model(&models.ItemChange{}).
+		Select("user_id, MAX(id) AS change_id").
+		Where(lastDeletions, before, models.OperationTypeDeleted).
+		Group("user_id").
+		Scan(&horizons).Error; err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf(StorageError, err)
+	}
+
+	for _, horizon := range horizons {
+		// The horizon never moves back, older changes may become superseded only later
+		if err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"change_id": gorm.Expr("MAX(sync_horizons.change_id, excluded.change_id)"),
+			}),
+		}).Create(&horizon).Error; err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf(StorageError, err)
+		}
+	}
+
+	res := tx.Where(compactableChanges, before, models.OperationTypeDeleted).Delete(&models.ItemChange{})
+	if res.Error != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf(StorageError, res.Error)
+	}
+
+	// Commit the transaction
+	if err := tx.Commit().Error; err != nil {
+		return 0, fmt.Errorf(StorageError, err)
+	}
+
+	return res.RowsAffected, nil
+}
+
+// GetSyncHorizon returns the highest ID of a deletion of the user removed by compaction, 0 if none
+func (s *storage) GetSyncHorizon(userID string) (uint, error) {
+	var horizon models.SyncHorizon
+	if err := s.db.Where("user_id = ?", userID).First(&horizon).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf(StorageError, err)
+	}
+
+	return horizon.ChangeID, nil
+}
+
+// #endregion Compaction
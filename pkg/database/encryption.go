@@ -0,0 +1,373 @@
+package database
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/ya-breeze/diary.be/pkg/database/models"
+	"github.com/ya-breeze/diary.be/pkg/encryption"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// ErrEncryptionDisabled is returned by operations on the encryption keys if no master key is configured
+var ErrEncryptionDisabled = errors.New("encryption at rest is not enabled")
+
+// encryptedSerializer is the gorm serializer of the columns encrypted at rest, see fieldEncryptor
+const encryptedSerializer = "encrypted"
+
+type keyringContextKey struct{}
+
+// fieldEncryptor encrypts column values with the data key of the user owning the row. The keyring is
+// taken from the context of the statement, without it values are stored as they are. Values stored
+// before encryption was enabled are read as plaintext.
+type fieldEncryptor struct{}
+
+func keyringFromContext(ctx context.Context) *encryption.Keyring {
+	keys, _ := ctx.Value(keyringContextKey{}).(*encryption.Keyring)
+	return keys
+}
+
+func (fieldEncryptor) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue any) (any, error) {
+	value := fieldValue
+	if valuer, ok := fieldValue.(driver.Valuer); ok {
+		var err error
+		if value, err = valuer.Value(); err != nil {
+			return nil, err
+		}
+	}
+	keys := keyringFromContext(ctx)
+	if keys == nil {
+		return value, nil
+	}
+
+	var plaintext string
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		plaintext = v
+	case []byte:
+		plaintext = string(v)
+	default:
+		return nil, fmt.Errorf("can't encrypt %T value of %s", value, field.Name)
+	}
+	if plaintext == "" {
+		return plaintext, nil
+	}
+
+	// Embedded fields belong to the schema of the row, so this is the owner of change records too
+	owner := field.Schema.LookUpField("user_id")
+	if owner == nil {
+		return nil, fmt.Errorf("can't encrypt %s, the row has no user", field.Name)
+	}
+	ownerValue, _ := owner.ValueOf(ctx, dst)
+	userID, _ := ownerValue.(string)
+	if userID == "" {
+		return nil, fmt.Errorf("can't encrypt %s, the row has no user", field.Name)
+	}
+	return keys.EncryptString(userID, plaintext)
+}
+
+func (fieldEncryptor) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue any) error {
+	var value string
+	switch v := dbValue.(type) {
+	case nil:
+		return nil
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("can't decrypt %T value of %s", dbValue, field.Name)
+	}
+	if encryption.IsEncrypted(value) {
+		keys := keyringFromContext(ctx)
+		if keys == nil {
+			return fmt.Errorf("%s is encrypted, but no encryption key is configured", field.Name)
+		}
+		var err error
+		if value, err = keys.DecryptString(value); err != nil {
+			return err
+		}
+		dbValue = value
+	}
+
+	target := field.ReflectValueOf(ctx, dst)
+	if scanner, ok := target.Addr().Interface().(sql.Scanner); ok {
+		return scanner.Scan(dbValue)
+	}
+	target.SetString(value)
+	return nil
+}
+
+// #region Encryption
+
+// Keyring returns the keys encrypting the data of the users, nil if encryption at rest is disabled
+func (s *storage) Keyring() *encryption.Keyring {
+	return s.keys
+}
+
+// setupEncryption loads the data keys of the users, creating them for users without one, and
+// encrypts the entries stored before encryption was enabled
+func (s *storage) setupEncryption() error {
+	masterKey, err := encryption.ParseKey(s.cfg.EncryptionKey)
+	if err != nil {
+		return err
+	}
+	s.keys, err = encryption.NewKeyring(masterKey)
+	if err != nil {
+		return err
+	}
+	// Statements of the storage pass the keyring to fieldEncryptor in their context
+	s.db = s.db.WithContext(context.WithValue(context.Background(), keyringContextKey{}, s.keys))
+
+	var users []*models.User
+	if err := s.db.Find(&users).Error; err != nil {
+		return fmt.Errorf(StorageError, err)
+	}
+	for _, user := range users {
+		userID := user.ID.String()
+		if len(user.DataKey) == 0 {
+			user.DataKey = s.keys.NewDataKey(userID)
+			if err := s.db.Model(user).Update("data_key", user.DataKey).Error; err != nil {
+				return fmt.Errorf(StorageError, err)
+			}
+			s.log.Info("Data key created", "userID", userID)
+		}
+		if err := s.keys.AddDataKey(userID, user.DataKey); err != nil {
+			return err
+		}
+	}
+
+	return encryptExistingData(s.log, s.db)
+}
+
+// encryptExistingData encrypts the items, revisions and change records stored before encryption
+// was enabled by saving them again
+func encryptExistingData(log *slog.Logger, db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		items, err := encryptRowsInTx[models.Item](tx, "title", "body", "tags")
+		if err != nil {
+			return err
+		}
+		revisions, err := encryptRowsInTx[models.ItemRevision](tx, "title", "body", "tags")
+		if err != nil {
+			return err
+		}
+		changes, err := encryptRowsInTx[models.ItemChange](tx, "item_title", "item_body", "item_tags")
+		if err != nil {
+			return err
+		}
+		if items+revisions+changes > 0 {
+			log.Info("Existing entries encrypted", "items", items, "revisions", revisions, "changes", changes)
+		}
+		return nil
+	})
+}
+
+// encryptRowsInTx saves the rows having a plaintext value in one of the columns again, so the
+// values are encrypted, and returns the number of saved rows
+func encryptRowsInTx[T any](tx *gorm.DB, columns ...string) (int, error) {
+	conditions := make([]string, 0, len(columns))
+	for _, column := range columns {
+		conditions = append(conditions, fmt.Sprintf("(%[1]s IS NOT NULL AND %[1]s <> '' AND substr(CAST(%[1]s AS TEXT), 1, %[2]d) <> '%[3]s')",
+			column, len(encryption.FieldPrefix), encryption.FieldPrefix))
+	}
+
+	var rows []*T
+	if err := tx.Where(strings.Join(conditions, " OR ")).Find(&rows).Error; err != nil {
+		return 0, fmt.Errorf(StorageError, err)
+	}
+	for _, row := range rows {
+		if err := tx.Save(row).Error; err != nil {
+			return 0, fmt.Errorf(StorageError, err)
+		}
+	}
+
+	return len(rows), nil
+}
+
+// RotateEncryptionKey wraps the data keys of all users with the new master key and returns the
+// number of rewrapped keys. The encrypted data doesn't change. The storage must be reopened with the
+// new key, keys created by other processes before that are still wrapped with the old one.
+func (s *storage) RotateEncryptionKey(newKey []byte) (int, error) {
+	if s.keys == nil {
+		return 0, ErrEncryptionDisabled
+	}
+	target, err := encryption.NewKeyring(newKey)
+	if err != nil {
+		return 0, err
+	}
+
+	var rotated int
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var users []*models.User
+		if err := tx.Where("data_key IS NOT NULL").Find(&users).Error; err != nil {
+			return fmt.Errorf(StorageError, err)
+		}
+		for _, user := range users {
+			wrapped, err := s.keys.Rewrap(user.ID.String(), user.DataKey, target)
+			if err != nil {
+				return err
+			}
+			if err := tx.Model(user).Update("data_key", wrapped).Error; err != nil {
+				return fmt.Errorf(StorageError, err)
+			}
+		}
+		rotated = len(users)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return rotated, nil
+}
+
+// decryptedPageSize is the number of items of a page loaded by getItemsDecrypted with one query
+const decryptedPageSize = 500
+
+// getItemsDecrypted returns the page of items selected by the query that match the text and the tags
+// of the search. The database can't search encrypted columns, so items are filtered after they are
+// decrypted. The text is matched by the words of the entries like by the full-text search, see
+// BuildFTSQuery, and the results are sorted by date.
+func getItemsDecrypted(query *gorm.DB, params SearchParams, order string) ([]*models.Item, int, error) {
+	query = query.Session(&gorm.Session{})
+	var tokens []string
+	if params.SearchText != "" {
+		if tokens = searchTokens(params.SearchText); len(tokens) == 0 {
+			return []*models.Item{}, 0, nil
+		}
+	}
+
+	// Every item is decrypted to be filtered, for the tags only the tags are loaded and the page is completed afterwards
+	var items []*models.Item
+	var err error
+	switch {
+	case len(tokens) > 0:
+		err = query.Order(order).Find(&items).Error
+	case !params.Tags.IsEmpty():
+		err = query.Select("items.date", "items.tags").Order(order).Find(&items).Error
+	default:
+		return getItemsPage(query, params, order)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf(StorageError, err)
+	}
+
+	matching := make([]*models.Item, 0, len(items))
+	for _, item := range items {
+		if len(tokens) > 0 && !matchesSearch(tokens, item.Title, item.Body) {
+			continue
+		}
+		if !matchesTagFilter(item.Tags, params.Tags) {
+			continue
+		}
+		matching = append(matching, item)
+	}
+
+	total := len(matching)
+	start := min(max(params.Offset, 0), total)
+	end := total
+	if params.Limit > 0 {
+		end = min(start+params.Limit, total)
+	}
+	page := matching[start:end]
+	if len(tokens) > 0 {
+		return page, total, nil
+	}
+
+	// Load the complete items of the page, the dates are in order so the chunks are as well
+	dates := make([]string, 0, len(page))
+	for _, item := range page {
+		dates = append(dates, item.Date)
+	}
+	items = make([]*models.Item, 0, len(page))
+	for chunk := range slices.Chunk(dates, decryptedPageSize) {
+		var loaded []*models.Item
+		if err := query.Where("items.date IN ?", chunk).Order(order).Find(&loaded).Error; err != nil {
+			return nil, 0, fmt.Errorf(StorageError, err)
+		}
+		items = append(items, loaded...)
+	}
+
+	return items, total, nil
+}
+
+// getItemsPage returns the page of items selected by the query, which has no filters on encrypted
+// columns, so only the items of the page are decrypted
+func getItemsPage(query *gorm.DB, params SearchParams, order string) ([]*models.Item, int, error) {
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, fmt.Errorf(StorageError, err)
+	}
+
+	if params.Limit > 0 {
+		query = query.Limit(params.Limit)
+	}
+	if params.Offset > 0 {
+		query = query.Offset(params.Offset)
+	}
+	var items []*models.Item
+	if err := query.Order(order).Find(&items).Error; err != nil {
+		return nil, 0, fmt.Errorf(StorageError, err)
+	}
+
+	return items, int(totalCount), nil
+}
+
+// matchesTagFilter reports whether the tags satisfy the filter, see applyTagFilter
+func matchesTagFilter(tags models.StringList, filter TagFilter) bool {
+	has := func(tag string) bool { return slices.Contains(tags, tag) }
+	if len(filter.AnyOf) > 0 && !slices.ContainsFunc(filter.AnyOf, has) {
+		return false
+	}
+	for _, tag := range filter.AllOf {
+		if !has(tag) {
+			return false
+		}
+	}
+	return !slices.ContainsFunc(filter.NoneOf, has)
+}
+
+// getTagsDecrypted computes the tag usage of GetTags from the decrypted items
+func (s *storage) getTagsDecrypted(userID string) ([]TagUsage, error) {
+	var items []*models.Item
+	if err := s.db.Select("date", "tags").Where("user_id = ?", userID).Order("date ASC").Find(&items).Error; err != nil {
+		return nil, fmt.Errorf(StorageError, err)
+	}
+
+	usages := map[string]*TagUsage{}
+	for _, item := range items {
+		for _, tag := range item.Tags {
+			usage, ok := usages[tag]
+			if !ok {
+				usage = &TagUsage{Tag: tag, FirstUsed: item.Date}
+				usages[tag] = usage
+			}
+			usage.Count++
+			usage.LastUsed = item.Date
+		}
+	}
+
+	tags := make([]TagUsage, 0, len(usages))
+	for _, usage := range usages {
+		tags = append(tags, *usage)
+	}
+	slices.SortFunc(tags, func(a, b TagUsage) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), strings.Compare(a.Tag, b.Tag))
+	})
+
+	return tags, nil
+}
+
+// #endregion Encryption
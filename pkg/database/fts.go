@@ -0,0 +1,447 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidSearchQuery is returned when the full-text search expression can't be parsed
+var ErrInvalidSearchQuery = errors.New("invalid search query")
+
+const ftsTable = "items_fts"
+
+// ftsRankOrder ranks matches with bm25. Weights follow the column order of items_fts:
+// user_id and date are not searchable, a hit in the title counts ten times more than in the body.
+const ftsRankOrder = "bm25(" + ftsTable + ", 0.0, 0.0, 10.0, 1.0)"
+
+// FullTextSearchSupported reports whether the linked SQLite library provides the FTS5 module.
+// The mattn/go-sqlite3 driver only includes it when built with the "sqlite_fts5" tag.
+func FullTextSearchSupported() bool {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		return false
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	return db.Exec("CREATE VIRTUAL TABLE fts_probe USING fts5(content)").Error == nil
+}
+
+// setupFullTextSearch creates the FTS5 index for items and backfills it if it is out of date.
+// It returns false if FTS5 is not available, in which case search falls back to LIKE matching.
+func setupFullTextSearch(log *slog.Logger, db *gorm.DB) bool {
+	err := db.Exec("CREATE VIRTUAL TABLE IF NOT EXISTS " + ftsTable +
+		" USING fts5(user_id UNINDEXED, date UNINDEXED, title, body, tokenize = 'unicode61 remove_diacritics 2')").Error
+	if err != nil {
+		log.Warn("Full-text search is not available, falling back to LIKE search", "error", err)
+		return false
+	}
+
+	indexed, total, stale, err := countStaleIndexRows(db)
+	if err != nil {
+		log.Error("failed to compare full-text index with items", "error", err)
+		return false
+	}
+	if indexed == total && stale == 0 {
+		return true
+	}
+
+	log.Info("Rebuilding full-text search index", "indexed", indexed, "items", total, "stale", stale)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM " + ftsTable).Error; err != nil {
+			return err
+		}
+		return tx.Exec("INSERT INTO " + ftsTable + " (user_id, date, title, body) " +
+			"SELECT user_id, date, title, body FROM items").Error
+	})
+	if err != nil {
+		log.Error("failed to rebuild full-text search index", "error", err)
+		return false
+	}
+
+	return true
+}
+
+// countStaleIndexRows counts the index rows, the items and the items whose content differs from
+// their index row, e.g. because they were edited by a binary built without FTS5. The index is up to
+// date if all counts match and no item is stale.
+func countStaleIndexRows(db *gorm.DB) (int64, int64, int64, error) {
+	var indexed, total, stale int64
+	if err := db.Table(ftsTable).Count(&indexed).Error; err != nil {
+		return 0, 0, 0, err
+	}
+	if err := db.Table("items").Count(&total).Error; err != nil {
+		return 0, 0, 0, err
+	}
+	err := db.Raw("SELECT COUNT(*) FROM (SELECT user_id, date, title, body FROM items " +
+		"EXCEPT SELECT user_id, date, title, body FROM " + ftsTable + ")").Scan(&stale).Error
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	return indexed, total, stale, nil
+}
+
+// dropFullTextSearch removes the FTS5 index, which would keep a plaintext copy of encrypted items
+func dropFullTextSearch(log *slog.Logger, db *gorm.DB) {
+	if err := db.Exec("DROP TABLE IF EXISTS " + ftsTable).Error; err != nil {
+		log.Error("failed to drop full-text search index", "error", err)
+	}
+}
+
+// indexItemInTx replaces the search index entry of the item within an existing transaction
+func (s *storage) indexItemInTx(tx *gorm.DB, userID, date string) error {
+	if !s.ftsEnabled {
+		return nil
+	}
+	if err := s.unindexItemInTx(tx, userID, date); err != nil {
+		return err
+	}
+
+	err := tx.Exec("INSERT INTO "+ftsTable+" (user_id, date, title, body) "+
+		"SELECT user_id, date, title, body FROM items WHERE user_id = ? AND date = ?", userID, date).Error
+	if err != nil {
+		return fmt.Errorf(StorageError, err)
+	}
+
+	return nil
+}
+
+// unindexItemInTx removes the item from the search index within an existing transaction
+func (s *storage) unindexItemInTx(tx *gorm.DB, userID, date string) error {
+	if !s.ftsEnabled {
+		return nil
+	}
+
+	if err := tx.Exec("DELETE FROM "+ftsTable+" WHERE user_id = ? AND date = ?", userID, date).Error; err != nil {
+		return fmt.Errorf(StorageError, err)
+	}
+
+	return nil
+}
+
+// isFTSQueryError reports whether err was caused by a malformed MATCH expression
+func isFTSQueryError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "fts5:")
+}
+
+// BuildFTSQuery converts user input into a safe FTS5 MATCH expression.
+//
+// Supported syntax:
+//   - words are matched as whole tokens: `beach vacation` finds entries containing both
+//   - "quoted phrases" match the exact sequence of words
+//   - a trailing asterisk matches by prefix: `vacat*`
+//   - AND, OR and NOT (upper case) combine terms, parentheses group them
+//
+// Every term is quoted, so punctuation in the input can't break the expression.
+// An empty string is returned if the input contains nothing searchable.
+func BuildFTSQuery(input string) string {
+	return strings.Join(searchTokens(input), " ")
+}
+
+// searchTokens returns the terms, operators and parentheses of the FTS5 expression of the input
+func searchTokens(input string) []string {
+	tokens := tokenizeSearchInput(input)
+	tokens = dropDanglingOperators(tokens)
+	if !parenthesesBalanced(tokens) {
+		tokens = dropDanglingOperators(removeParentheses(tokens))
+	}
+
+	return tokens
+}
+
+func isFTSOperator(token string) bool {
+	return token == "AND" || token == "OR" || token == "NOT"
+}
+
+func hasSearchableRune(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}) >= 0
+}
+
+func quoteFTSTerm(term string) string {
+	return `"` + strings.ReplaceAll(term, `"`, "") + `"`
+}
+
+// tokenizeSearchInput splits the input into quoted terms, operators and parentheses
+func tokenizeSearchInput(input string) []string {
+	var tokens []string
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			tokens = append(tokens, string(r))
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			phrase := string(runes[i+1 : min(end, len(runes))])
+			i = end + 1
+			prefix := i < len(runes) && runes[i] == '*'
+			if prefix {
+				i++
+			}
+			if hasSearchableRune(phrase) {
+				token := quoteFTSTerm(phrase)
+				if prefix {
+					token += "*"
+				}
+				tokens = append(tokens, token)
+			}
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune(`()"`, runes[end]) {
+				end++
+			}
+			word := string(runes[i:end])
+			i = end
+			if isFTSOperator(word) {
+				tokens = append(tokens, word)
+				continue
+			}
+			prefix := strings.HasSuffix(word, "*")
+			word = strings.TrimRight(word, "*")
+			if !hasSearchableRune(word) {
+				continue
+			}
+			token := quoteFTSTerm(word)
+			if prefix {
+				token += "*"
+			}
+			tokens = append(tokens, token)
+		}
+	}
+
+	return tokens
+}
+
+// dropDanglingOperators removes operators that don't have a term on both sides
+// and parentheses that don't enclose anything
+func dropDanglingOperators(tokens []string) []string {
+	res := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		prev := ""
+		if len(res) > 0 {
+			prev = res[len(res)-1]
+		}
+		switch {
+		case isFTSOperator(token):
+			if prev == "" || prev == "(" || isFTSOperator(prev) {
+				continue
+			}
+		case token == ")":
+			if isFTSOperator(prev) {
+				res = res[:len(res)-1]
+				prev = ""
+				if len(res) > 0 {
+					prev = res[len(res)-1]
+				}
+			}
+			if prev == "(" {
+				res = res[:len(res)-1]
+				continue
+			}
+		}
+		res = append(res, token)
+	}
+	for len(res) > 0 && isFTSOperator(res[len(res)-1]) {
+		res = res[:len(res)-1]
+	}
+
+	return res
+}
+
+func parenthesesBalanced(tokens []string) bool {
+	depth := 0
+	for _, token := range tokens {
+		switch token {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
+
+func removeParentheses(tokens []string) []string {
+	res := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if token != "(" && token != ")" {
+			res = append(res, token)
+		}
+	}
+	return res
+}
+
+// SearchTerm is a single positive term of a search query, used to highlight matches
+type SearchTerm struct {
+	// Words of the term, more than one for phrases
+	Words []string
+	// Prefix is true if the last word should match by prefix
+	Prefix bool
+}
+
+// ParseSearchTerms extracts the terms of the search input that a matching entry should contain.
+// Terms excluded with NOT are skipped. The words of the terms are normalized, see NormalizeSearchWord.
+func ParseSearchTerms(input string) []SearchTerm {
+	var terms []SearchTerm
+	negated := false
+	for _, token := range tokenizeSearchInput(input) {
+		switch {
+		case token == "NOT":
+			negated = true
+			continue
+		case isFTSOperator(token) || token == "(" || token == ")":
+			continue
+		}
+		if negated {
+			negated = false
+			continue
+		}
+
+		if term, ok := parseSearchTerm(token); ok {
+			terms = append(terms, term)
+		}
+	}
+
+	return terms
+}
+
+// parseSearchTerm converts a quoted term of the FTS5 expression into its normalized words
+func parseSearchTerm(token string) (SearchTerm, bool) {
+	words := SplitSearchWords(strings.Trim(token, `"*`))
+	return SearchTerm{Words: words, Prefix: strings.HasSuffix(token, "*")}, len(words) > 0
+}
+
+// matches reports whether the words contain the words of the term in sequence
+func (t SearchTerm) matches(words []string) bool {
+	last := len(t.Words) - 1
+	for start := 0; start+last < len(words); start++ {
+		matched := true
+		for i, word := range t.Words {
+			if words[start+i] != word && (i < last || !t.Prefix || !strings.HasPrefix(words[start+i], word)) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// searchMatcher evaluates the tokens of an FTS5 expression against the words of the columns of an
+// entry outside the index. Like in FTS5, NOT binds tighter than AND, which binds tighter than OR,
+// adjacent terms are combined with AND and a phrase has to be found within one column.
+type searchMatcher struct {
+	tokens  []string
+	pos     int
+	columns [][]string
+}
+
+// matchesSearch reports whether the texts match the tokens of the expression, see searchTokens
+func matchesSearch(tokens []string, texts ...string) bool {
+	m := &searchMatcher{tokens: tokens}
+	for _, text := range texts {
+		m.columns = append(m.columns, SplitSearchWords(text))
+	}
+	return m.or()
+}
+
+func (m *searchMatcher) or() bool {
+	matched := m.and()
+	for m.next("OR") {
+		right := m.and()
+		matched = matched || right
+	}
+	return matched
+}
+
+func (m *searchMatcher) and() bool {
+	matched := m.not()
+	for m.pos < len(m.tokens) && m.tokens[m.pos] != "OR" && m.tokens[m.pos] != ")" {
+		m.next("AND")
+		right := m.not()
+		matched = matched && right
+	}
+	return matched
+}
+
+func (m *searchMatcher) not() bool {
+	matched := m.primary()
+	for m.next("NOT") {
+		right := m.primary()
+		matched = matched && !right
+	}
+	return matched
+}
+
+func (m *searchMatcher) primary() bool {
+	if m.pos >= len(m.tokens) {
+		return false
+	}
+	token := m.tokens[m.pos]
+	m.pos++
+	if token == "(" {
+		matched := m.or()
+		m.next(")")
+		return matched
+	}
+	term, ok := parseSearchTerm(token)
+	return ok && slices.ContainsFunc(m.columns, term.matches)
+}
+
+// next skips the token if it's the current one
+func (m *searchMatcher) next(token string) bool {
+	if m.pos < len(m.tokens) && m.tokens[m.pos] == token {
+		m.pos++
+		return true
+	}
+	return false
+}
+
+// diacriticsRemover decomposes letters and drops their combining marks, e.g. "é" becomes "e"
+var diacriticsRemover = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+// NormalizeSearchWord folds the case and removes the diacritics of a word the way the FTS5
+// tokenizer does, so matches can be found outside the index the same way as inside it
+func NormalizeSearchWord(word string) string {
+	normalized, _, err := transform.String(diacriticsRemover, strings.ToLower(word))
+	if err != nil {
+		return strings.ToLower(word)
+	}
+	return normalized
+}
+
+// SplitSearchWords splits text into its normalized words, the runs of letters and digits
+func SplitSearchWords(text string) []string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = NormalizeSearchWord(word)
+	}
+	return words
+}
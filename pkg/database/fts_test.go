@@ -0,0 +1,148 @@
+package database_test
+
+import (
+	"log/slog"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/ya-breeze/diary.be/pkg/config"
+	"github.com/ya-breeze/diary.be/pkg/database"
+	"github.com/ya-breeze/diary.be/pkg/database/models"
+	"github.com/ya-breeze/diary.be/pkg/encryption"
+)
+
+var _ = Describe("BuildFTSQuery", func() {
+	DescribeTable("should convert user input into a safe FTS5 expression",
+		func(input, expected string) {
+			Expect(database.BuildFTSQuery(input)).To(Equal(expected))
+		},
+		Entry("plain words", "beach vacation", `"beach" "vacation"`),
+		Entry("phrase", `"summer vacation" beach`, `"summer vacation" "beach"`),
+		Entry("prefix", "vacat*", `"vacat"*`),
+		Entry("prefix phrase", `"summer vac"*`, `"summer vac"*`),
+		Entry("operators", "beach OR sea NOT work", `"beach" OR "sea" NOT "work"`),
+		Entry("lower case operators are words", "beach or sea", `"beach" "or" "sea"`),
+		Entry("grouping", "work AND (meeting OR call)", `"work" AND ( "meeting" OR "call" )`),
+		Entry("punctuation", "e-mail 2024-01-15", `"e-mail" "2024-01-15"`),
+		Entry("dangling operators", "AND beach OR", `"beach"`),
+		Entry("leading NOT", "NOT work", `"work"`),
+		Entry("unbalanced parentheses", "(beach OR sea", `"beach" OR "sea"`),
+		Entry("empty group", "beach ()", `"beach"`),
+		Entry("unterminated phrase", `"summer vac`, `"summer vac"`),
+		Entry("nothing searchable", "-- ** ::", ""),
+	)
+})
+
+var _ = Describe("Full-text search index", func() {
+	var cfg *config.Config
+	var logger *slog.Logger
+
+	BeforeEach(func() {
+		if !database.FullTextSearchSupported() {
+			Skip("built without the sqlite_fts5 tag")
+		}
+		logger = slog.New(slog.NewTextHandler(GinkgoWriter, nil))
+		cfg = &config.Config{DBPath: filepath.Join(GinkgoT().TempDir(), "diary.db")}
+	})
+
+	search := func(storage database.Storage, text string) []string {
+		items, _, err := storage.GetItems("user", database.SearchParams{SearchText: text})
+		Expect(err).ToNot(HaveOccurred())
+		dates := make([]string, 0, len(items))
+		for _, item := range items {
+			dates = append(dates, item.Date)
+		}
+		return dates
+	}
+
+	It("should rebuild an index with stale content on startup", func() {
+		storage := database.NewStorage(logger, cfg)
+		Expect(storage.Open()).To(Succeed())
+		Expect(storage.PutItem("user", &models.Item{Date: "2024-01-15", Title: "Beach", Body: "A day at the beach"})).To(Succeed())
+		Expect(search(storage, "beach")).To(Equal([]string{"2024-01-15"}))
+
+		// An older binary edits the item without updating the index, so the row counts still match
+		db, err := gorm.Open(sqlite.Open(cfg.DBPath), &gorm.Config{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(db.Exec("UPDATE items SET body = 'Hiking in the mountains' WHERE date = '2024-01-15'").Error).To(Succeed())
+		sqlDB, err := db.DB()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sqlDB.Close()).To(Succeed())
+
+		storage = database.NewStorage(logger, cfg)
+		Expect(storage.Open()).To(Succeed())
+		Expect(search(storage, "mountains")).To(Equal([]string{"2024-01-15"}))
+		Expect(search(storage, "beach")).To(Equal([]string{"2024-01-15"}))
+		Expect(search(storage, "day")).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Search of encrypted items", func() {
+	var plain, encrypted database.Storage
+	var plainUser, encryptedUser string
+
+	open := func(cfg *config.Config) (database.Storage, string) {
+		storage := database.NewStorage(slog.New(slog.NewTextHandler(GinkgoWriter, nil)), cfg)
+		Expect(storage.Open()).To(Succeed())
+		DeferCleanup(storage.Close)
+		user, err := storage.CreateUser("search@test.com", "password")
+		Expect(err).ToNot(HaveOccurred())
+		userID := user.ID.String()
+
+		for _, item := range []*models.Item{
+			{Date: "2024-01-01", Title: "Beach day", Body: "Swimming in the sea", Tags: []string{"trip"}},
+			{Date: "2024-01-02", Title: "Mountains", Body: "Hiking near the beach café", Tags: []string{"trip", "work"}},
+			{Date: "2024-01-03", Title: "Office", Body: "Meeting about the day at the beach", Tags: []string{"work"}},
+		} {
+			Expect(storage.PutItem(userID, item)).To(Succeed())
+		}
+		return storage, userID
+	}
+
+	dates := func(storage database.Storage, userID string, params database.SearchParams) ([]string, int) {
+		items, total, err := storage.GetItems(userID, params)
+		Expect(err).ToNot(HaveOccurred())
+		dates := make([]string, 0, len(items))
+		for _, item := range items {
+			Expect(item.Title).ToNot(BeEmpty())
+			dates = append(dates, item.Date)
+		}
+		return dates, total
+	}
+
+	BeforeEach(func() {
+		if !database.FullTextSearchSupported() {
+			Skip("built without the sqlite_fts5 tag")
+		}
+		plain, plainUser = open(&config.Config{DBPath: ":memory:"})
+		encrypted, encryptedUser = open(&config.Config{DBPath: ":memory:", EncryptionKey: encryption.GenerateKey()})
+	})
+
+	DescribeTable("should match like the full-text search",
+		func(params database.SearchParams, expected []string) {
+			params.Sort = database.SortDateAsc
+			found, total := dates(encrypted, encryptedUser, params)
+			Expect(found).To(Equal(expected))
+			plainFound, plainTotal := dates(plain, plainUser, params)
+			Expect(plainFound).To(Equal(expected))
+			Expect(total).To(Equal(plainTotal))
+		},
+		Entry("all terms", database.SearchParams{SearchText: "beach day"}, []string{"2024-01-01", "2024-01-03"}),
+		Entry("phrase", database.SearchParams{SearchText: `"beach day"`}, []string{"2024-01-01"}),
+		Entry("exclusion", database.SearchParams{SearchText: "beach NOT hiking"}, []string{"2024-01-01", "2024-01-03"}),
+		Entry("alternatives", database.SearchParams{SearchText: "sea OR office"}, []string{"2024-01-01", "2024-01-03"}),
+		Entry("groups", database.SearchParams{SearchText: "(hiking OR sea) AND beach"}, []string{"2024-01-01", "2024-01-02"}),
+		Entry("prefix", database.SearchParams{SearchText: "swim*"}, []string{"2024-01-01"}),
+		Entry("whole words", database.SearchParams{SearchText: "swim"}, []string{}),
+		Entry("diacritics", database.SearchParams{SearchText: "cafe"}, []string{"2024-01-02"}),
+		Entry("nothing searchable", database.SearchParams{SearchText: "!!"}, []string{}),
+		Entry("tags", database.SearchParams{Tags: database.TagFilter{AllOf: []string{"work"}}}, []string{"2024-01-02", "2024-01-03"}),
+		Entry("page of tags", database.SearchParams{Tags: database.TagFilter{AnyOf: []string{"trip", "work"}}, Offset: 1, Limit: 1},
+			[]string{"2024-01-02"}),
+		Entry("page", database.SearchParams{Offset: 2, Limit: 5}, []string{"2024-01-03"}),
+	)
+})
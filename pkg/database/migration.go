@@ -11,5 +11,9 @@ func autoMigrateModels(db *gorm.DB) error {
 		&models.User{},
 		&models.Item{},
 		&models.ItemChange{},
+		&models.ItemRevision{},
+		&models.SyncHorizon{},
+		&models.Asset{},
+		&models.ItemAsset{},
 	)
 }
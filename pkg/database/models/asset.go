@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+
+	"github.com/ya-breeze/diary.be/pkg/generated/goserver"
+)
+
+// Asset is an asset file of a user registered in the database
+type Asset struct {
+	UserID string `gorm:"primaryKey"`
+	// Name is the file name in the asset directory of the user
+	Name string `gorm:"primaryKey"`
+
+	// OriginalName is the name of the uploaded file
+	OriginalName string
+	Size         int64
+	ContentType  string
+
+	// Hash is the hex-encoded SHA-256 of the file content
+	Hash       string
+	UploadedAt time.Time `gorm:"index"`
+	// CapturedAt is when the photo was taken according to its EXIF data
+	CapturedAt *time.Time
+	// TrashedAt is set while the asset file is moved to the trash, trashed assets aren't listed
+	TrashedAt *time.Time `gorm:"index"`
+	// Encrypted is set if the file is stored encrypted, files stored before encryption at rest was
+	// enabled are read as they are until they are encrypted
+	Encrypted bool
+
+	// Dates of the items referencing the asset, filled by Storage.GetAssets
+	Dates []string `gorm:"-"`
+}
+
+// ItemAsset links an item to an asset referenced in its body
+type ItemAsset struct {
+	UserID    string `gorm:"primaryKey"`
+	Date      string `gorm:"primaryKey"`
+	AssetName string `gorm:"primaryKey;index"`
+}
+
+// ToAssetInfo returns the asset metadata recorded in the change log
+func (a Asset) ToAssetInfo() *AssetInfo {
+	return &AssetInfo{
+		Name:        a.Name,
+		Size:        a.Size,
+		ContentType: a.ContentType,
+		Hash:        a.Hash,
+	}
+}
+
+// ToResponse converts Asset to the API response format
+func (a Asset) ToResponse() goserver.AssetResponse {
+	dates := a.Dates
+	if dates == nil {
+		dates = []string{}
+	}
+	return goserver.AssetResponse{
+		Name:         a.Name,
+		OriginalName: a.OriginalName,
+		Size:         a.Size,
+		ContentType:  a.ContentType,
+		Hash:         a.Hash,
+		UploadedAt:   a.UploadedAt,
+		CapturedAt:   a.CapturedAt,
+		RefCount:     int64(len(dates)),
+		Dates:        dates,
+	}
+}
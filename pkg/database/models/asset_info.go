@@ -0,0 +1,27 @@
+package models
+
+import (
+	"github.com/ya-breeze/diary.be/pkg/generated/goserver"
+)
+
+// AssetInfo describes an asset file of a user
+type AssetInfo struct {
+	// Name is the file name in the asset directory of the user
+	Name string
+	Size int64
+
+	ContentType string
+
+	// Hash is the hex-encoded SHA-256 of the file content
+	Hash string
+}
+
+// ToSyncResponse converts AssetInfo to the API response format
+func (a AssetInfo) ToSyncResponse() goserver.SyncAssetSnapshot {
+	return goserver.SyncAssetSnapshot{
+		Name:        a.Name,
+		Size:        a.Size,
+		ContentType: a.ContentType,
+		Hash:        a.Hash,
+	}
+}
@@ -4,9 +4,13 @@ type Item struct {
 	UserID string `gorm:"primaryKey"`
 	Date   string `gorm:"primaryKey"`
 
-	Title string
-	Body  string
-	Tags  StringList `gorm:"type:json"`
+	// Title, Body and Tags are encrypted in the database when encryption at rest is enabled
+	Title string     `gorm:"serializer:encrypted"`
+	Body  string     `gorm:"serializer:encrypted"`
+	Tags  StringList `gorm:"type:json;serializer:encrypted"`
+
+	// Version is incremented on every save and is used to detect conflicting edits
+	Version int64 `gorm:"not null;default:1"`
 	// AssetIDs StringList `gorm:"type:json"`
 }
 
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/ya-breeze/diary.be/pkg/generated/goserver"
+	"gorm.io/gorm"
 )
 
 // OperationType represents the type of operation performed on an item
@@ -15,7 +16,15 @@ const (
 	OperationTypeDeleted OperationType = "deleted"
 )
 
-// ItemChange tracks changes to diary items for synchronization purposes
+// EntityType represents the kind of entity a change was made to
+type EntityType string
+
+const (
+	EntityTypeItem  EntityType = "item"
+	EntityTypeAsset EntityType = "asset"
+)
+
+// ItemChange tracks changes to diary items and assets for synchronization purposes
 type ItemChange struct {
 	// ID is the auto-incrementing primary key for change tracking
 	ID uint `gorm:"primaryKey;autoIncrement" json:"id"`
@@ -23,9 +32,12 @@ type ItemChange struct {
 	// UserID identifies which user's data was changed
 	UserID string `gorm:"index;not null" json:"userId"`
 
-	// Date is the date identifier of the item that was modified
+	// Date is the date identifier of the item that was modified, empty for asset changes
 	Date string `gorm:"index;not null" json:"date"`
 
+	// EntityType is the kind of the changed entity
+	EntityType EntityType `gorm:"type:varchar(10);not null;default:item" json:"entityType"`
+
 	// OperationType indicates what kind of operation was performed
 	OperationType OperationType `gorm:"type:varchar(10);not null" json:"operationType"`
 
@@ -36,33 +48,51 @@ type ItemChange struct {
 	// For deleted items, this contains the last known state before deletion
 	ItemSnapshot *Item `gorm:"embedded;embeddedPrefix:item_" json:"itemSnapshot,omitempty"`
 
+	// AssetSnapshot describes the asset for asset changes
+	AssetSnapshot *AssetInfo `gorm:"embedded;embeddedPrefix:asset_" json:"assetSnapshot,omitempty"`
+
 	// Metadata stores additional information about the change
 	Metadata StringList `gorm:"type:json" json:"metadata,omitempty"`
 }
 
-// ToSyncResponse converts ItemChange to the API response format
-func (ic ItemChange) ToSyncResponse() goserver.SyncChangeResponse {
-	var id int32
-	if ic.ID <= uint(^uint32(0)>>1) { // Check if it fits in int32 (max positive value)
-		id = int32(ic.ID) // #nosec G115 - checked above
+// AfterFind drops the item snapshot of changes stored without one. The version column of the
+// snapshot has a database default, so GORM allocates the snapshot even if the change has none.
+func (ic *ItemChange) AfterFind(_ *gorm.DB) error {
+	if ic.ItemSnapshot != nil && ic.ItemSnapshot.Date == "" {
+		ic.ItemSnapshot = nil
 	}
+	return nil
+}
 
+// ToSyncResponse converts ItemChange to the API response format
+func (ic ItemChange) ToSyncResponse() goserver.SyncChangeResponse {
 	response := goserver.SyncChangeResponse{
-		Id:            id,
+		Id:            int64(ic.ID), //nolint:gosec // change IDs fit into int64
 		UserId:        ic.UserID,
 		Date:          ic.Date,
 		OperationType: string(ic.OperationType),
 		Timestamp:     ic.Timestamp,
+		EntityType:    string(EntityTypeItem),
 		Metadata:      []string(ic.Metadata),
 	}
 
+	if ic.EntityType == EntityTypeAsset {
+		response.EntityType = string(EntityTypeAsset)
+		if ic.AssetSnapshot != nil {
+			asset := ic.AssetSnapshot.ToSyncResponse()
+			response.AssetSnapshot = &asset
+		}
+		return response
+	}
+
 	// Include item data for all operations (including deleted items to show what was deleted)
 	if ic.ItemSnapshot != nil {
 		response.ItemSnapshot = &goserver.ItemsResponse{
-			Date:  ic.ItemSnapshot.Date,
-			Title: ic.ItemSnapshot.Title,
-			Body:  ic.ItemSnapshot.Body,
-			Tags:  []string(ic.ItemSnapshot.Tags),
+			Date:    ic.ItemSnapshot.Date,
+			Title:   ic.ItemSnapshot.Title,
+			Body:    ic.ItemSnapshot.Body,
+			Tags:    []string(ic.ItemSnapshot.Tags),
+			Version: ic.ItemSnapshot.Version,
 		}
 	}
 
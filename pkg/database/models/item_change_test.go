@@ -101,7 +101,7 @@ var _ = Describe("ItemChange", func() {
 				itemChange.OperationType = models.OperationTypeDeleted
 			})
 
-			It("should convert to sync response without item snapshot", func() {
+			It("should convert to sync response with the last known state of the item", func() {
 				response := itemChange.ToSyncResponse()
 
 				Expect(response.Id).To(BeNumerically("==", 123))
@@ -111,7 +111,8 @@ var _ = Describe("ItemChange", func() {
 				Expect(response.Timestamp).To(Equal(testTime))
 				Expect(response.Metadata).To(ConsistOf("mobile-app", "v1.0.0"))
 
-				Expect(response.ItemSnapshot).To(BeNil())
+				Expect(response.ItemSnapshot).NotTo(BeNil())
+				Expect(response.ItemSnapshot.Title).To(Equal("Test Entry"))
 			})
 		})
 
@@ -127,6 +128,18 @@ var _ = Describe("ItemChange", func() {
 				Expect(response.ItemSnapshot).To(BeNil())
 			})
 		})
+
+		Context("with an ID beyond int32", func() {
+			BeforeEach(func() {
+				itemChange.ID = 1 << 40
+			})
+
+			It("should keep the full ID", func() {
+				response := itemChange.ToSyncResponse()
+
+				Expect(response.Id).To(Equal(int64(1 << 40)))
+			})
+		})
 	})
 
 	Describe("Metadata handling", func() {
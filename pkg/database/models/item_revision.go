@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/ya-breeze/diary.be/pkg/generated/goserver"
+)
+
+// ItemRevision is a saved version of a diary item. A revision is stored every time the item
+// is written, so the history survives overwrites and deletion of the item.
+type ItemRevision struct {
+	// ID is the auto-incrementing primary key, newer revisions have greater IDs
+	ID uint `gorm:"primaryKey;autoIncrement"`
+
+	UserID string `gorm:"index:idx_item_revisions_item;not null"`
+	Date   string `gorm:"index:idx_item_revisions_item;not null"`
+
+	// Timestamp records when the version was saved
+	Timestamp time.Time `gorm:"not null"`
+
+	Title string     `gorm:"serializer:encrypted"`
+	Body  string     `gorm:"serializer:encrypted"`
+	Tags  StringList `gorm:"type:json;serializer:encrypted"`
+}
+
+// ToItem returns the diary item as it was in this revision
+func (r ItemRevision) ToItem() *Item {
+	return &Item{
+		UserID: r.UserID,
+		Date:   r.Date,
+		Title:  r.Title,
+		Body:   r.Body,
+		Tags:   r.Tags,
+	}
+}
+
+// ToRevisionResponse converts ItemRevision to the API response format
+func (r ItemRevision) ToRevisionResponse() goserver.ItemRevisionResponse {
+	return goserver.ItemRevisionResponse{
+		Id:        int64(r.ID), //nolint:gosec // revision IDs fit into int64
+		Date:      r.Date,
+		Timestamp: r.Timestamp,
+		Title:     r.Title,
+		Body:      r.Body,
+		Tags:      []string(r.Tags),
+	}
+}
@@ -0,0 +1,10 @@
+package models
+
+// SyncHorizon records how far the change log of a user was compacted. Clients that pulled
+// changes only up to an older change ID may have missed removed deletions and must resync.
+type SyncHorizon struct {
+	UserID string `gorm:"primaryKey"`
+
+	// ChangeID is the highest ID of a deletion removed by compaction
+	ChangeID uint `gorm:"not null"`
+}
@@ -12,6 +12,10 @@ type User struct {
 	StartDate      time.Time
 	Login          string `gorm:"unique"`
 	HashedPassword string
+
+	// DataKey is the key encrypting the data of the user wrapped with the master key, it's empty
+	// until encryption at rest is enabled
+	DataKey []byte
 }
 
 func (u User) FromDB() goserver.User {
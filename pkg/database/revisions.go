@@ -0,0 +1,84 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ya-breeze/diary.be/pkg/database/models"
+	"gorm.io/gorm"
+)
+
+// #region Revisions
+
+// createRevisionInTx stores the current state of the item as a new revision within an existing transaction
+func (s *storage) createRevisionInTx(tx *gorm.DB, item *models.Item) error {
+	revision := &models.ItemRevision{
+		UserID:    item.UserID,
+		Date:      item.Date,
+		Timestamp: time.Now(),
+		Title:     item.Title,
+		Body:      item.Body,
+		Tags:      item.Tags,
+	}
+
+	if err := tx.Create(revision).Error; err != nil {
+		return fmt.Errorf(StorageError, err)
+	}
+
+	return nil
+}
+
+// GetItemRevisions returns all saved versions of the item, newest first
+func (s *storage) GetItemRevisions(userID, date string) ([]*models.ItemRevision, error) {
+	var revisions []*models.ItemRevision
+	if err := s.db.Where("user_id = ? AND date = ?", userID, date).
+		Order("id DESC").
+		Find(&revisions).Error; err != nil {
+		return nil, fmt.Errorf(StorageError, err)
+	}
+
+	return revisions, nil
+}
+
+// GetItemRevision returns a single saved version of the item
+func (s *storage) GetItemRevision(userID, date string, revisionID uint) (*models.ItemRevision, error) {
+	var revision models.ItemRevision
+	if err := s.db.Where("id = ? AND user_id = ? AND date = ?", revisionID, userID, date).
+		First(&revision).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, fmt.Errorf(StorageError, err)
+	}
+
+	return &revision, nil
+}
+
+// backfillRevisions seeds an empty revisions table from the item snapshots of the change log,
+// so the history of items saved before revisions were introduced is not lost
+func backfillRevisions(log *slog.Logger, db *gorm.DB) error {
+	var count int64
+	if err := db.Model(&models.ItemRevision{}).Count(&count).Error; err != nil {
+		return fmt.Errorf(StorageError, err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	res := db.Exec("INSERT INTO item_revisions (user_id, date, timestamp, title, body, tags) "+
+		"SELECT user_id, date, timestamp, item_title, item_body, item_tags FROM item_changes "+
+		"WHERE operation_type <> ? AND item_date IS NOT NULL ORDER BY id", models.OperationTypeDeleted)
+	if res.Error != nil {
+		return fmt.Errorf(StorageError, res.Error)
+	}
+	if res.RowsAffected > 0 {
+		log.Info("Revisions restored from change log", "count", res.RowsAffected)
+	}
+
+	return nil
+}
+
+// #endregion Revisions
@@ -8,6 +8,7 @@ import (
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
 )
 
 type SlogGormLogger struct {
@@ -54,6 +55,9 @@ func (l *SlogGormLogger) Trace(ctx context.Context, begin time.Time, fc func() (
 }
 
 func openSqlite(l *slog.Logger, dbPath string, verbose bool) (*gorm.DB, error) {
+	// The serializer must be known before the models are parsed
+	schema.RegisterSerializer(encryptedSerializer, fieldEncryptor{})
+
 	return gorm.Open(sqlite.Open(dbPath), &gorm.Config{
 		Logger: (&SlogGormLogger{logger: l, verbose: verbose}).LogMode(logger.Warn),
 	})
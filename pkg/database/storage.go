@@ -4,12 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"strings"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/ya-breeze/diary.be/pkg/config"
 	"github.com/ya-breeze/diary.be/pkg/database/models"
+	"github.com/ya-breeze/diary.be/pkg/encryption"
 	"gorm.io/gorm"
 )
 
@@ -19,14 +20,55 @@ const StorageError = "storage error: %w"
 
 var ErrNotFound = errors.New("not found")
 
+// ErrVersionConflict is returned when an item was changed or deleted since the version the update is based on
+var ErrVersionConflict = errors.New("version conflict")
+
+// SortOrder defines the order of items returned by GetItems
+type SortOrder string
+
+const (
+	// SortRelevance orders search results by relevance, newest first for equal relevance.
+	// Without full-text search it is the same as SortDateDesc.
+	SortRelevance SortOrder = "relevance"
+	SortDateDesc  SortOrder = "date_desc"
+	SortDateAsc   SortOrder = "date_asc"
+)
+
+// TagFilter selects items by exact tag membership, all non-empty conditions must hold
+type TagFilter struct {
+	// AnyOf matches items that have at least one of the tags
+	AnyOf []string
+	// AllOf matches items that have every one of the tags
+	AllOf []string
+	// NoneOf excludes items that have any of the tags
+	NoneOf []string
+}
+
+// IsEmpty reports whether the filter has no conditions
+func (f TagFilter) IsEmpty() bool {
+	return len(f.AnyOf) == 0 && len(f.AllOf) == 0 && len(f.NoneOf) == 0
+}
+
 // SearchParams defines parameters for searching diary items
 type SearchParams struct {
-	// SearchText filters items by title and body content (case-insensitive)
+	// SearchText filters items by title and body content (case-insensitive).
+	// With full-text search enabled it supports phrases, prefixes and boolean operators
+	// (see BuildFTSQuery) and results are ranked by relevance.
 	SearchText string
-	// Tags filters items that contain any of the specified tags
-	Tags []string
+	// Tags filters items by their tags
+	Tags TagFilter
 	// Date filters items by specific date (optional, for backward compatibility)
 	Date string
+	// DateFrom filters items dated on or after this date (YYYY-MM-DD, optional)
+	DateFrom string
+	// DateTo filters items dated on or before this date (YYYY-MM-DD, optional)
+	DateTo string
+	// Sort defines the order of items, SortRelevance if empty
+	Sort SortOrder
+	// Limit is the maximum number of items to return, 0 means no limit
+	Limit int
+	// Offset is the number of matching items to skip
+	Offset int
 }
 
 //nolint:interfacebloat // keep a single storage interface for simplicity
@@ -42,26 +84,61 @@ type Storage interface {
 	GetItem(userID, itemID string) (*models.Item, error)
 	GetItems(userID string, searchParams SearchParams) ([]*models.Item, int, error)
 	PutItem(userID string, item *models.Item) error
+	PutItemIfVersion(userID string, item *models.Item, version int64) error
 	DeleteItem(userID, itemID string) error
 
 	GetPreviousDate(userID, date string) (string, error)
 	GetNextDate(userID, date string) (string, error)
+	GetNavigationDates(userID string, dates []string) (map[string]NavigationDates, error)
+
+	GetTags(userID string) ([]TagUsage, error)
+	RenameTag(userID, from, to string) (int, error)
+	MergeTags(userID string, sources []string, target string) (int, error)
+
+	GetItemRevisions(userID, date string) ([]*models.ItemRevision, error)
+	GetItemRevision(userID, date string, revisionID uint) (*models.ItemRevision, error)
+
+	CreateAssets(userID string, assets []*models.Asset) error
+	TrashAssets(userID string, names []string) error
+	RestoreAssets(userID string, names []string) error
+	GetAssets(userID string, filter AssetFilter) ([]*models.Asset, int, error)
+	GetUsage(userID string) (*Usage, error)
+	HasAssets() (bool, error)
+	ImportAssets(userID string, assets []*models.Asset) error
+	IsAssetEncrypted(userID, name string) (bool, error)
+	SetAssetsEncrypted(userID string, names []string) error
 
 	// Change tracking methods for synchronization
 	CreateChangeRecord(userID, date string, operationType models.OperationType,
 		itemSnapshot *models.Item, metadata []string) error
 	GetChangesSince(userID string, sinceID uint, limit int) ([]*models.ItemChange, error)
 	GetLatestChangeID(userID string) (uint, error)
+	PushChanges(userID string, baseChangeID uint, changes []PushChange) ([]PushResult, error)
+	GetSnapshot(userID string) ([]*models.Item, []*models.AssetInfo, uint, error)
+	CompactChanges(before time.Time) (int64, error)
+	GetSyncHorizon(userID string) (uint, error)
+	SubscribeChanges(userID string) (<-chan struct{}, func())
+
+	// Encryption at rest
+	Keyring() *encryption.Keyring
+	RotateEncryptionKey(newKey []byte) (int, error)
 }
 
 type storage struct {
 	log *slog.Logger
 	cfg *config.Config
 	db  *gorm.DB
+
+	// ftsEnabled is true when the FTS5 index of items is available
+	ftsEnabled bool
+	// broker notifies subscribers about committed changes
+	broker *changeBroker
+	// keys encrypt the data of the users, nil if encryption at rest is disabled
+	keys *encryption.Keyring
 }
 
 func NewStorage(logger *slog.Logger, cfg *config.Config) Storage {
-	return &storage{log: logger, db: nil, cfg: cfg}
+	return &storage{log: logger, db: nil, cfg: cfg, broker: newChangeBroker()}
 }
 
 func (s *storage) Open() error {
@@ -76,6 +153,25 @@ func (s *storage) Open() error {
 		s.log.Error("failed to migrate database", "error", err)
 		panic("failed to migrate database")
 	}
+	if s.cfg.EncryptionKey != "" {
+		if err := s.setupEncryption(); err != nil {
+			return fmt.Errorf("failed to set up encryption: %w", err)
+		}
+	}
+	if err := backfillRevisions(s.log, s.db); err != nil {
+		s.log.Error("failed to backfill revisions", "error", err)
+		panic("failed to migrate database")
+	}
+	if err := s.backfillAssetLinks(); err != nil {
+		s.log.Error("failed to backfill asset links", "error", err)
+		panic("failed to migrate database")
+	}
+	if s.keys != nil {
+		// The index would keep a plaintext copy of the entries
+		dropFullTextSearch(s.log, s.db)
+	} else {
+		s.ftsEnabled = setupFullTextSearch(s.log, s.db)
+	}
 
 	return nil
 }
@@ -98,9 +194,17 @@ func (s *storage) CreateUser(username, hashedPassword string) (*models.User, err
 		HashedPassword: hashedPassword,
 		StartDate:      time.Now(),
 	}
+	if s.keys != nil {
+		user.DataKey = s.keys.NewDataKey(user.ID.String())
+	}
 	if err := s.db.Create(&user).Error; err != nil {
 		return nil, fmt.Errorf(StorageError, err)
 	}
+	if s.keys != nil {
+		if err := s.keys.AddDataKey(user.ID.String(), user.DataKey); err != nil {
+			return nil, err
+		}
+	}
 
 	return &user, nil
 }
@@ -167,49 +271,118 @@ func (s *storage) GetItem(userID, date string) (*models.Item, error) {
 
 func (s *storage) GetItems(userID string, searchParams SearchParams) ([]*models.Item, int, error) {
 	var items []*models.Item
-	query := s.db.Where("user_id = ?", userID)
+	query := s.db.Model(&models.Item{}).Where("items.user_id = ?", userID)
+	order := "items.date DESC"
+	if searchParams.Sort == SortDateAsc {
+		order = "items.date ASC"
+	}
 
 	// Apply date filter if specified (for backward compatibility)
 	if searchParams.Date != "" {
-		query = query.Where("date = ?", searchParams.Date)
+		query = query.Where("items.date = ?", searchParams.Date)
+	}
+
+	// Apply date range filter, dates are stored as YYYY-MM-DD so they compare as strings
+	if searchParams.DateFrom != "" {
+		query = query.Where("items.date >= ?", searchParams.DateFrom)
+	}
+	if searchParams.DateTo != "" {
+		query = query.Where("items.date <= ?", searchParams.DateTo)
+	}
+
+	// Encrypted items are searched after they are decrypted
+	if s.keys != nil {
+		return getItemsDecrypted(query, searchParams, order)
 	}
 
 	// Apply text search filter if specified
 	if searchParams.SearchText != "" {
-		searchPattern := "%" + searchParams.SearchText + "%"
-		query = query.Where("title LIKE ? OR body LIKE ?", searchPattern, searchPattern)
+		if s.ftsEnabled {
+			ftsQuery := BuildFTSQuery(searchParams.SearchText)
+			if ftsQuery == "" {
+				return []*models.Item{}, 0, nil
+			}
+			query = query.
+				Joins("JOIN "+ftsTable+" ON "+ftsTable+".user_id = items.user_id AND "+ftsTable+".date = items.date").
+				Where(ftsTable+" MATCH ?", ftsQuery)
+			if searchParams.Sort == "" || searchParams.Sort == SortRelevance {
+				order = ftsRankOrder + ", " + order
+			}
+		} else {
+			searchPattern := "%" + searchParams.SearchText + "%"
+			query = query.Where("items.title LIKE ? OR items.body LIKE ?", searchPattern, searchPattern)
+		}
 	}
 
 	// Apply tag filters if specified
-	if len(searchParams.Tags) > 0 {
-		// For JSON tag filtering, we need to check if any of the specified tags exist in the JSON array
-		tagConditions := make([]string, len(searchParams.Tags))
-		tagArgs := make([]any, len(searchParams.Tags))
-		for i, tag := range searchParams.Tags {
-			tagConditions[i] = "JSON_EXTRACT(tags, '$') LIKE ?"
-			tagArgs[i] = "%\"" + tag + "\"%"
-		}
-		tagQuery := strings.Join(tagConditions, " OR ")
-		query = query.Where(tagQuery, tagArgs...)
-	}
+	query = applyTagFilter(query, searchParams.Tags)
 
 	// Get total count for pagination
 	var totalCount int64
-	if err := query.Model(&models.Item{}).Count(&totalCount).Error; err != nil {
+	if err := query.Count(&totalCount).Error; err != nil {
+		if isFTSQueryError(err) {
+			return nil, 0, fmt.Errorf("%w: %w", ErrInvalidSearchQuery, err)
+		}
 		return nil, 0, fmt.Errorf(StorageError, err)
 	}
 
-	// Execute the query to get items, ordered by date descending
-	if err := query.Order("date DESC").Find(&items).Error; err != nil {
+	// Apply pagination
+	if searchParams.Limit > 0 {
+		query = query.Limit(searchParams.Limit)
+	}
+	if searchParams.Offset > 0 {
+		query = query.Offset(searchParams.Offset)
+	}
+
+	// Execute the query to get the requested page of items
+	if err := query.Select("items.*").Order(order).Find(&items).Error; err != nil {
 		return nil, 0, fmt.Errorf(StorageError, err)
 	}
 
 	return items, int(totalCount), nil
 }
 
+// applyTagFilter adds tag conditions to the items query. Tags are matched as exact elements
+// of the JSON array, so wildcard characters in tags have no special meaning.
+func applyTagFilter(query *gorm.DB, filter TagFilter) *gorm.DB {
+	const matchingTags = "FROM json_each(items.tags) WHERE json_each.value IN ?"
+
+	if len(filter.AnyOf) > 0 {
+		query = query.Where("EXISTS (SELECT 1 "+matchingTags+")", filter.AnyOf)
+	}
+	if allOf := uniqueStrings(filter.AllOf); len(allOf) > 0 {
+		query = query.Where("(SELECT COUNT(DISTINCT json_each.value) "+matchingTags+") = ?", allOf, len(allOf))
+	}
+	if len(filter.NoneOf) > 0 {
+		query = query.Where("NOT EXISTS (SELECT 1 "+matchingTags+")", filter.NoneOf)
+	}
+
+	return query
+}
+
+func uniqueStrings(values []string) []string {
+	res := make([]string, 0, len(values))
+	for _, v := range values {
+		if !slices.Contains(res, v) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// PutItem saves the item regardless of its current version
 func (s *storage) PutItem(userID string, item *models.Item) error {
-	item.UserID = userID
+	return s.putItem(userID, item, nil)
+}
+
+// PutItemIfVersion saves the item only if the stored item still has the given version,
+// version 0 means that the item must not exist yet. ErrVersionConflict is returned otherwise.
+func (s *storage) PutItemIfVersion(userID string, item *models.Item, version int64) error {
+	return s.putItem(userID, item, &version)
+}
 
+// putItem saves the item in its own transaction, see putItemInTx
+func (s *storage) putItem(userID string, item *models.Item, expectedVersion *int64) error {
 	// Start a transaction to ensure atomicity
 	tx := s.db.Begin()
 	if tx.Error != nil {
@@ -217,20 +390,73 @@ func (s *storage) PutItem(userID string, item *models.Item) error {
 	}
 	defer func() {
 		if r := recover(); r != nil {
-			tx.Rollback()
+			s.rollbackTx(tx)
 		}
 	}()
 
+	if err := s.putItemInTx(tx, userID, item, expectedVersion); err != nil {
+		s.rollbackTx(tx)
+		return err
+	}
+
+	// Commit the transaction
+	if err := s.commitTx(tx); err != nil {
+		return fmt.Errorf(StorageError, err)
+	}
+
+	return nil
+}
+
+// putItemInTx saves the item and sets its new version within an existing transaction.
+// The current version is checked against expectedVersion unless it's nil.
+func (s *storage) putItemInTx(tx *gorm.DB, userID string, item *models.Item, expectedVersion *int64) error {
+	item.UserID = userID
+
 	// Check if item exists to determine operation type
 	var existingItem models.Item
 	isUpdate := tx.Where("user_id = ? AND date = ?", userID, item.Date).First(&existingItem).Error == nil
+	if expectedVersion != nil && existingItem.Version != *expectedVersion {
+		return ErrVersionConflict
+	}
+	item.Version = existingItem.Version + 1
 
-	// Save the item
-	if err := tx.Save(item).Error; err != nil {
-		tx.Rollback()
+	// Save the item, an existing item is only updated if it still has the version read above
+	if isUpdate {
+		res := tx.Model(&models.Item{}).
+			Where("user_id = ? AND date = ? AND version = ?", userID, item.Date, existingItem.Version).
+			Select("title", "body", "tags", "version").
+			Updates(item)
+		if res.Error != nil {
+			return fmt.Errorf(StorageError, res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+	} else if err := tx.Save(item).Error; err != nil {
 		return fmt.Errorf(StorageError, err)
 	}
 
+	// The quota is checked on the stored items, so it includes the encryption overhead of the bodies
+	grown := len(item.Body) > len(existingItem.Body)
+	if err := checkItemQuotaInTx(tx, userID, UserQuota(s.cfg), !isUpdate, grown); err != nil {
+		return err
+	}
+
+	// Keep the full-text index in sync with the saved item
+	if err := s.indexItemInTx(tx, userID, item.Date); err != nil {
+		return fmt.Errorf("failed to update search index: %w", err)
+	}
+
+	// Keep the saved version in the item history
+	if err := s.createRevisionInTx(tx, item); err != nil {
+		return fmt.Errorf("failed to create revision: %w", err)
+	}
+
+	// Link the item to the assets referenced in its body
+	if err := linkAssetsInTx(tx, userID, item.Date, item.Body); err != nil {
+		return fmt.Errorf("failed to link assets: %w", err)
+	}
+
 	// Create change record
 	operationType := models.OperationTypeCreated
 	if isUpdate {
@@ -238,15 +464,9 @@ func (s *storage) PutItem(userID string, item *models.Item) error {
 	}
 
 	if err := s.createChangeRecordInTx(tx, userID, item.Date, operationType, item, nil); err != nil {
-		tx.Rollback()
 		return fmt.Errorf("failed to create change record: %w", err)
 	}
 
-	// Commit the transaction
-	if err := tx.Commit().Error; err != nil {
-		return fmt.Errorf(StorageError, err)
-	}
-
 	return nil
 }
 
@@ -258,14 +478,28 @@ func (s *storage) DeleteItem(userID, itemID string) error {
 	}
 	defer func() {
 		if r := recover(); r != nil {
-			tx.Rollback()
+			s.rollbackTx(tx)
 		}
 	}()
 
+	if err := s.deleteItemInTx(tx, userID, itemID); err != nil {
+		s.rollbackTx(tx)
+		return err
+	}
+
+	// Commit the transaction
+	if err := s.commitTx(tx); err != nil {
+		return fmt.Errorf(StorageError, err)
+	}
+
+	return nil
+}
+
+// deleteItemInTx deletes the item within an existing transaction, ErrNotFound is returned if it doesn't exist
+func (s *storage) deleteItemInTx(tx *gorm.DB, userID, itemID string) error {
 	// Get the item before deletion for the change record
 	var item models.Item
 	if err := tx.Where("user_id = ? AND date = ?", userID, itemID).First(&item).Error; err != nil {
-		tx.Rollback()
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return ErrNotFound
 		}
@@ -274,21 +508,24 @@ func (s *storage) DeleteItem(userID, itemID string) error {
 
 	// Delete the item
 	if err := tx.Where("user_id = ? AND date = ?", userID, itemID).Delete(&models.Item{}).Error; err != nil {
-		tx.Rollback()
 		return fmt.Errorf(StorageError, err)
 	}
 
+	// Remove the item from the full-text index
+	if err := s.unindexItemInTx(tx, userID, itemID); err != nil {
+		return fmt.Errorf("failed to update search index: %w", err)
+	}
+
+	// The deleted item no longer references assets
+	if err := unlinkAssetsInTx(tx, userID, itemID); err != nil {
+		return fmt.Errorf("failed to unlink assets: %w", err)
+	}
+
 	// Create change record for deletion
 	if err := s.createChangeRecordInTx(tx, userID, itemID, models.OperationTypeDeleted, &item, nil); err != nil {
-		tx.Rollback()
 		return fmt.Errorf("failed to create change record: %w", err)
 	}
 
-	// Commit the transaction
-	if err := tx.Commit().Error; err != nil {
-		return fmt.Errorf(StorageError, err)
-	}
-
 	return nil
 }
 
@@ -322,6 +559,53 @@ func (s *storage) GetNextDate(userID, date string) (string, error) {
 	return item.Date, nil
 }
 
+// NavigationDates are the dates of the items before and after an item, empty if there is none
+type NavigationDates struct {
+	Previous string
+	Next     string
+}
+
+// GetNavigationDates returns the navigation dates of all given dates with three queries: the dates
+// of the items between the first and the last given date, and the dates around this range
+func (s *storage) GetNavigationDates(userID string, dates []string) (map[string]NavigationDates, error) {
+	res := make(map[string]NavigationDates, len(dates))
+	if len(dates) == 0 {
+		return res, nil
+	}
+
+	first, last := slices.Min(dates), slices.Max(dates)
+	var known []string
+	if err := s.db.Model(&models.Item{}).Where("user_id = ? AND date >= ? AND date <= ?", userID, first, last).
+		Order("date asc").Pluck("date", &known).Error; err != nil {
+		return nil, fmt.Errorf(StorageError, err)
+	}
+	before, err := s.GetPreviousDate(userID, first)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	after, err := s.GetNextDate(userID, last)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	for _, date := range dates {
+		i, found := slices.BinarySearch(known, date)
+		nav := NavigationDates{Previous: before, Next: after}
+		if i > 0 {
+			nav.Previous = known[i-1]
+		}
+		if found {
+			i++
+		}
+		if i < len(known) {
+			nav.Next = known[i]
+		}
+		res[date] = nav
+	}
+
+	return res, nil
+}
+
 // #endregion Dates
 
 // #region Change Tracking
@@ -342,6 +626,8 @@ func (s *storage) createChangeRecordInTx(tx *gorm.DB, userID, date string,
 	if err := tx.Create(change).Error; err != nil {
 		return fmt.Errorf(StorageError, err)
 	}
+	// Subscribers are notified once the transaction is committed
+	s.broker.stage(tx, userID)
 
 	return nil
 }
@@ -362,6 +648,7 @@ func (s *storage) CreateChangeRecord(userID, date string, operationType models.O
 	if err := s.db.Create(change).Error; err != nil {
 		return fmt.Errorf(StorageError, err)
 	}
+	s.broker.publish(userID)
 
 	return nil
 }
@@ -398,4 +685,36 @@ func (s *storage) GetLatestChangeID(userID string) (uint, error) {
 	return change.ID, nil
 }
 
+// GetSnapshot returns all items and assets of the user and the latest change ID, read in one
+// transaction so they are exactly the state after that change
+func (s *storage) GetSnapshot(userID string) ([]*models.Item, []*models.AssetInfo, uint, error) {
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, nil, 0, fmt.Errorf(StorageError, tx.Error)
+	}
+	defer tx.Rollback()
+
+	var items []*models.Item
+	if err := tx.Where("user_id = ?", userID).Order("date ASC").Find(&items).Error; err != nil {
+		return nil, nil, 0, fmt.Errorf(StorageError, err)
+	}
+
+	var registered []*models.Asset
+	if err := tx.Where("user_id = ? AND trashed_at IS NULL", userID).Order("name ASC").Find(&registered).Error; err != nil {
+		return nil, nil, 0, fmt.Errorf(StorageError, err)
+	}
+	assets := make([]*models.AssetInfo, 0, len(registered))
+	for _, asset := range registered {
+		assets = append(assets, asset.ToAssetInfo())
+	}
+
+	var changeID uint
+	if err := tx.Model(&models.ItemChange{}).Where("user_id = ?", userID).
+		Select("COALESCE(MAX(id), 0)").Scan(&changeID).Error; err != nil {
+		return nil, nil, 0, fmt.Errorf(StorageError, err)
+	}
+
+	return items, assets, changeID, nil
+}
+
 // #endregion Change Tracking
@@ -0,0 +1,173 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ya-breeze/diary.be/pkg/database/models"
+	"gorm.io/gorm"
+)
+
+// PushOperation is the kind of change pushed by a client
+type PushOperation string
+
+const (
+	PushOperationUpsert PushOperation = "upsert"
+	PushOperationDelete PushOperation = "delete"
+)
+
+// PushStatus is the outcome of a single pushed change
+type PushStatus string
+
+const (
+	PushStatusApplied  PushStatus = "applied"
+	PushStatusConflict PushStatus = "conflict"
+	PushStatusRejected PushStatus = "rejected"
+)
+
+// PushChange is a change made by a client while it was offline
+type PushChange struct {
+	Operation PushOperation
+	// Item is the new state of the item for upserts, only the date is used for deletes
+	Item *models.Item
+	// BaseVersion is the version of the item the change is based on. If it's nil, the change
+	// conflicts with any server change of the item after the base change ID of the push.
+	BaseVersion *int64
+}
+
+// PushResult is the outcome of a pushed change
+type PushResult struct {
+	Status PushStatus
+	// Reason explains why the change was rejected
+	Reason string
+	// Item is the saved item for applied upserts and the server copy for conflicts,
+	// nil if the item doesn't exist
+	Item *models.Item
+}
+
+// pushSavePoint marks the state before a pushed upsert, so an upsert exceeding the quota can be undone
+const pushSavePoint = "push_change"
+
+// #region Sync push
+
+// PushChanges applies changes of a client in one transaction. Changes are applied in order,
+// conflicting changes are skipped and reported with the server copy of the item. Conflicts are
+// detected by BaseVersion or, if it's not set, by server changes after baseChangeID.
+// An error is returned and nothing is applied if the storage fails.
+func (s *storage) PushChanges(userID string, baseChangeID uint, changes []PushChange) ([]PushResult, error) {
+	// Start a transaction to ensure atomicity
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf(StorageError, tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			s.rollbackTx(tx)
+		}
+	}()
+
+	// Changes made by this push must not be treated as conflicts of later changes of the same item
+	var latestChangeID uint
+	if err := tx.Model(&models.ItemChange{}).Where("user_id = ?", userID).
+		Select("COALESCE(MAX(id), 0)").Scan(&latestChangeID).Error; err != nil {
+		s.rollbackTx(tx)
+		return nil, fmt.Errorf(StorageError, err)
+	}
+
+	results := make([]PushResult, len(changes))
+	for i, change := range changes {
+		result, err := s.pushChangeInTx(tx, userID, baseChangeID, latestChangeID, change)
+		if err != nil {
+			s.rollbackTx(tx)
+			return nil, err
+		}
+		results[i] = result
+	}
+
+	// Commit the transaction
+	if err := s.commitTx(tx); err != nil {
+		return nil, fmt.Errorf(StorageError, err)
+	}
+
+	return results, nil
+}
+
+func (s *storage) pushChangeInTx(
+	tx *gorm.DB, userID string, baseChangeID, latestChangeID uint, change PushChange,
+) (PushResult, error) {
+	date := change.Item.Date
+
+	var current *models.Item
+	var existingItem models.Item
+	err := tx.Where("user_id = ? AND date = ?", userID, date).First(&existingItem).Error
+	switch {
+	case err == nil:
+		current = &existingItem
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return PushResult{}, fmt.Errorf(StorageError, err)
+	}
+
+	conflict, err := s.isPushConflictInTx(tx, userID, baseChangeID, latestChangeID, change, current)
+	if err != nil {
+		return PushResult{}, err
+	}
+	if conflict {
+		return PushResult{Status: PushStatusConflict, Item: current}, nil
+	}
+
+	switch change.Operation {
+	case PushOperationUpsert:
+		item := *change.Item
+		// The quota is checked after the item is saved, a rejected item is rolled back to the savepoint
+		if err := tx.SavePoint(pushSavePoint).Error; err != nil {
+			return PushResult{}, fmt.Errorf(StorageError, err)
+		}
+		err = s.putItemInTx(tx, userID, &item, change.BaseVersion)
+		if errors.Is(err, ErrVersionConflict) {
+			return PushResult{Status: PushStatusConflict, Item: current}, nil
+		}
+		if errors.Is(err, ErrQuotaExceeded) {
+			if err := tx.RollbackTo(pushSavePoint).Error; err != nil {
+				return PushResult{}, fmt.Errorf(StorageError, err)
+			}
+			return PushResult{Status: PushStatusRejected, Reason: err.Error()}, nil
+		}
+		if err != nil {
+			return PushResult{}, err
+		}
+		return PushResult{Status: PushStatusApplied, Item: &item}, nil
+	case PushOperationDelete:
+		if current == nil {
+			return PushResult{Status: PushStatusRejected, Reason: "item not found"}, nil
+		}
+		if err := s.deleteItemInTx(tx, userID, date); err != nil {
+			return PushResult{}, err
+		}
+		return PushResult{Status: PushStatusApplied}, nil
+	default:
+		return PushResult{Status: PushStatusRejected, Reason: fmt.Sprintf("unknown operation %q", change.Operation)}, nil
+	}
+}
+
+// isPushConflictInTx reports whether the item was changed on the server since the client saw it
+func (s *storage) isPushConflictInTx(
+	tx *gorm.DB, userID string, baseChangeID, latestChangeID uint, change PushChange, current *models.Item,
+) (bool, error) {
+	if change.BaseVersion != nil {
+		var currentVersion int64
+		if current != nil {
+			currentVersion = current.Version
+		}
+		return currentVersion != *change.BaseVersion, nil
+	}
+
+	var count int64
+	if err := tx.Model(&models.ItemChange{}).
+		Where("user_id = ? AND date = ? AND id > ? AND id <= ?", userID, change.Item.Date, baseChangeID, latestChangeID).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf(StorageError, err)
+	}
+	return count > 0, nil
+}
+
+// #endregion Sync push
@@ -0,0 +1,125 @@
+package database
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ya-breeze/diary.be/pkg/database/models"
+)
+
+// TagUsage describes how a tag is used across the items of a user
+type TagUsage struct {
+	Tag string
+	// Count is the number of items having the tag
+	Count int
+	// FirstUsed is the date of the oldest item having the tag
+	FirstUsed string
+	// LastUsed is the date of the newest item having the tag
+	LastUsed string
+}
+
+// #region Tags
+
+// GetTags returns all tags of the user with usage statistics, most used tags first
+func (s *storage) GetTags(userID string) ([]TagUsage, error) {
+	if s.keys != nil {
+		return s.getTagsDecrypted(userID)
+	}
+
+	var tags []TagUsage
+	err := s.db.Raw("SELECT json_each.value AS tag, COUNT(*) AS count, "+
+		"MIN(items.date) AS first_used, MAX(items.date) AS last_used "+
+		"FROM items, json_each(items.tags) WHERE items.user_id = ? "+
+		"GROUP BY json_each.value ORDER BY count DESC, tag ASC", userID).
+		Scan(&tags).Error
+	if err != nil {
+		return nil, fmt.Errorf(StorageError, err)
+	}
+
+	return tags, nil
+}
+
+// RenameTag replaces the tag in all items of the user and returns the number of updated items
+func (s *storage) RenameTag(userID, from, to string) (int, error) {
+	return s.MergeTags(userID, []string{from}, to)
+}
+
+// MergeTags replaces all source tags with the target tag in all items of the user and returns
+// the number of updated items. Items are updated in one transaction and a change record is
+// created for each of them, so synchronized clients pick up the new tags.
+func (s *storage) MergeTags(userID string, sources []string, target string) (int, error) {
+	sources = uniqueStrings(sources)
+	if len(sources) == 0 {
+		return 0, nil
+	}
+
+	// Start a transaction to ensure atomicity
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return 0, fmt.Errorf(StorageError, tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			s.rollbackTx(tx)
+		}
+	}()
+
+	var items []*models.Item
+	query := tx.Model(&models.Item{}).Where("items.user_id = ?", userID)
+	// Encrypted tags can't be filtered in the database, items without the sources are skipped below
+	if s.keys == nil {
+		query = applyTagFilter(query, TagFilter{AnyOf: sources})
+	}
+	if err := query.Order("items.date ASC").Find(&items).Error; err != nil {
+		s.rollbackTx(tx)
+		return 0, fmt.Errorf(StorageError, err)
+	}
+
+	updated := 0
+	for _, item := range items {
+		tags := replaceTags(item.Tags, sources, target)
+		if slices.Equal(tags, item.Tags) {
+			continue
+		}
+		item.Tags = tags
+		item.Version++
+
+		if err := tx.Save(item).Error; err != nil {
+			s.rollbackTx(tx)
+			return 0, fmt.Errorf(StorageError, err)
+		}
+		if err := s.createRevisionInTx(tx, item); err != nil {
+			s.rollbackTx(tx)
+			return 0, fmt.Errorf("failed to create revision: %w", err)
+		}
+		if err := s.createChangeRecordInTx(tx, userID, item.Date, models.OperationTypeUpdated, item, nil); err != nil {
+			s.rollbackTx(tx)
+			return 0, fmt.Errorf("failed to create change record: %w", err)
+		}
+		updated++
+	}
+
+	// Commit the transaction
+	if err := s.commitTx(tx); err != nil {
+		return 0, fmt.Errorf(StorageError, err)
+	}
+
+	return updated, nil
+}
+
+// replaceTags replaces the sources with the target, keeping the position of the first
+// replaced tag and dropping duplicates
+func replaceTags(tags models.StringList, sources []string, target string) models.StringList {
+	res := make(models.StringList, 0, len(tags))
+	for _, tag := range tags {
+		if slices.Contains(sources, tag) {
+			tag = target
+		}
+		if !slices.Contains(res, tag) {
+			res = append(res, tag)
+		}
+	}
+	return res
+}
+
+// #endregion Tags
@@ -0,0 +1,141 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ya-breeze/diary.be/pkg/config"
+	"gorm.io/gorm"
+)
+
+// ErrQuotaExceeded is returned for changes that would exceed the storage quota of the user
+var ErrQuotaExceeded = errors.New("storage quota exceeded")
+
+// Usage describes the storage used by a user
+type Usage struct {
+	// AssetBytes is the size of the asset files, trashed assets are included as they occupy the disk
+	// until the trash is emptied
+	AssetBytes int64
+	// AssetFiles is the number of asset files including trashed ones
+	AssetFiles int
+	// TrashedBytes is the size of the trashed asset files
+	TrashedBytes int64
+	// Items is the number of diary items
+	Items int
+	// BodyBytes is the total size of the stored item bodies, encrypted bodies take more space than their text
+	BodyBytes int64
+}
+
+// Quota limits the storage of every user, zero values are unlimited
+type Quota struct {
+	// Bytes and Files limit the assets
+	Bytes int64
+	Files int
+	// Items limits the number of diary items and BodyBytes the total size of their stored bodies
+	Items     int
+	BodyBytes int64
+}
+
+// UserQuota converts the MB config to the byte quota
+func UserQuota(cfg *config.Config) Quota {
+	return Quota{
+		Bytes:     int64(cfg.UserQuotaMB) * 1024 * 1024,
+		Files:     cfg.UserQuotaFiles,
+		Items:     cfg.UserQuotaItems,
+		BodyBytes: int64(cfg.UserQuotaBodyMB) * 1024 * 1024,
+	}
+}
+
+// Check returns ErrQuotaExceeded if adding asset files of the given total size to the usage exceeds the quota.
+// Deduplicated uploads are counted as well, as they are only detected while saving.
+func (q Quota) Check(usage *Usage, files int, bytes int64) error {
+	if q.Bytes > 0 && usage.AssetBytes+bytes > q.Bytes {
+		return fmt.Errorf("%w: %d of %d bytes used, %d bytes uploaded", ErrQuotaExceeded, usage.AssetBytes, q.Bytes, bytes)
+	}
+	if q.Files > 0 && usage.AssetFiles+files > q.Files {
+		return fmt.Errorf("%w: %d of %d files used, %d files uploaded", ErrQuotaExceeded, usage.AssetFiles, q.Files, files)
+	}
+	return nil
+}
+
+// #region Usage
+
+// GetUsage returns the storage used by the user
+func (s *storage) GetUsage(userID string) (*Usage, error) {
+	usage, err := getAssetUsage(s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := getItemUsage(s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	usage.Items = items.Items
+	usage.BodyBytes = items.BodyBytes
+
+	return usage, nil
+}
+
+// getAssetUsage returns the asset usage of the user, db may be a transaction
+func getAssetUsage(db *gorm.DB, userID string) (*Usage, error) {
+	var usage Usage
+	err := db.Raw("SELECT COALESCE(SUM(size), 0) AS asset_bytes, COUNT(*) AS asset_files, "+
+		"COALESCE(SUM(CASE WHEN trashed_at IS NOT NULL THEN size ELSE 0 END), 0) AS trashed_bytes "+
+		"FROM assets WHERE user_id = ?", userID).
+		Scan(&usage).Error
+	if err != nil {
+		return nil, fmt.Errorf(StorageError, err)
+	}
+
+	return &usage, nil
+}
+
+// getItemUsage returns the item usage of the user, db may be a transaction
+func getItemUsage(db *gorm.DB, userID string) (*Usage, error) {
+	var usage Usage
+	err := db.Raw("SELECT COUNT(*) AS items, COALESCE(SUM(LENGTH(CAST(body AS BLOB))), 0) AS body_bytes "+
+		"FROM items WHERE user_id = ?", userID).
+		Scan(&usage).Error
+	if err != nil {
+		return nil, fmt.Errorf(StorageError, err)
+	}
+
+	return &usage, nil
+}
+
+// checkAssetQuotaInTx returns ErrQuotaExceeded if the assets of the user exceed the quota within an
+// existing transaction. Uploads are checked before they are saved as well, but parallel uploads all
+// pass against the same usage, so the quota is checked again when they are registered.
+func checkAssetQuotaInTx(tx *gorm.DB, userID string, quota Quota) error {
+	if quota.Bytes == 0 && quota.Files == 0 {
+		return nil
+	}
+	usage, err := getAssetUsage(tx, userID)
+	if err != nil {
+		return err
+	}
+	return quota.Check(usage, 0, 0)
+}
+
+// checkItemQuotaInTx returns ErrQuotaExceeded if a saved item made the items of the user exceed the
+// quota within an existing transaction. Only a new item is checked against the item count and only a
+// grown body against the body size, so items can still be edited down after the quota was lowered.
+func checkItemQuotaInTx(tx *gorm.DB, userID string, quota Quota, added, grown bool) error {
+	if (quota.Items == 0 || !added) && (quota.BodyBytes == 0 || !grown) {
+		return nil
+	}
+	usage, err := getItemUsage(tx, userID)
+	if err != nil {
+		return err
+	}
+	if added && quota.Items > 0 && usage.Items > quota.Items {
+		return fmt.Errorf("%w: %d items allowed", ErrQuotaExceeded, quota.Items)
+	}
+	if grown && quota.BodyBytes > 0 && usage.BodyBytes > quota.BodyBytes {
+		return fmt.Errorf("%w: %d of %d body bytes used", ErrQuotaExceeded, usage.BodyBytes, quota.BodyBytes)
+	}
+	return nil
+}
+
+// #endregion Usage
@@ -0,0 +1,91 @@
+package database_test
+
+import (
+	"log/slog"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ya-breeze/diary.be/pkg/config"
+	"github.com/ya-breeze/diary.be/pkg/database"
+	"github.com/ya-breeze/diary.be/pkg/database/models"
+)
+
+var _ = Describe("Storage quotas", func() {
+	var (
+		storage database.Storage
+		cfg     *config.Config
+	)
+	const userID = "quota-user"
+
+	BeforeEach(func() {
+		cfg = &config.Config{DBPath: ":memory:"}
+		storage = database.NewStorage(slog.New(slog.NewTextHandler(GinkgoWriter, nil)), cfg)
+		Expect(storage.Open()).To(Succeed())
+		DeferCleanup(storage.Close)
+	})
+
+	It("should limit the number of items", func() {
+		cfg.UserQuotaItems = 1
+		Expect(storage.PutItem(userID, &models.Item{Date: "2024-01-01", Title: "First"})).To(Succeed())
+
+		err := storage.PutItem(userID, &models.Item{Date: "2024-01-02", Title: "Second"})
+		Expect(err).To(MatchError(database.ErrQuotaExceeded))
+		_, err = storage.GetItem(userID, "2024-01-02")
+		Expect(err).To(HaveOccurred())
+
+		// Existing items can still be changed
+		Expect(storage.PutItem(userID, &models.Item{Date: "2024-01-01", Title: "Edited"})).To(Succeed())
+	})
+
+	It("should limit the size of the bodies", func() {
+		cfg.UserQuotaBodyMB = 1
+		half := strings.Repeat("a", 512*1024)
+		Expect(storage.PutItem(userID, &models.Item{Date: "2024-01-01", Body: half})).To(Succeed())
+
+		err := storage.PutItem(userID, &models.Item{Date: "2024-01-02", Body: half + "a"})
+		Expect(err).To(MatchError(database.ErrQuotaExceeded))
+
+		// A body can be shortened after the quota was lowered
+		cfg.UserQuotaBodyMB = 0
+		Expect(storage.PutItem(userID, &models.Item{Date: "2024-01-02", Body: half})).To(Succeed())
+		cfg.UserQuotaBodyMB = 1
+		Expect(storage.PutItem(userID, &models.Item{Date: "2024-01-02", Body: "short"})).To(Succeed())
+	})
+
+	It("should reject pushed items exceeding the quota and apply the others", func() {
+		cfg.UserQuotaItems = 1
+		results, err := storage.PushChanges(userID, 0, []database.PushChange{
+			{Operation: database.PushOperationUpsert, Item: &models.Item{Date: "2024-01-01", Title: "First"}},
+			{Operation: database.PushOperationUpsert, Item: &models.Item{Date: "2024-01-02", Title: "Second"}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results[0].Status).To(Equal(database.PushStatusApplied))
+		Expect(results[1].Status).To(Equal(database.PushStatusRejected))
+		Expect(results[1].Reason).To(ContainSubstring(database.ErrQuotaExceeded.Error()))
+
+		usage, err := storage.GetUsage(userID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(usage.Items).To(Equal(1))
+		changes, err := storage.GetChangesSince(userID, 0, 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes).To(HaveLen(1))
+	})
+
+	It("should check the asset quota when assets are registered", func() {
+		cfg.UserQuotaFiles = 1
+		first := &models.Asset{Name: "first.jpg", Size: 1, ContentType: "image/jpeg", Hash: "a"}
+		second := &models.Asset{Name: "second.jpg", Size: 1, ContentType: "image/jpeg", Hash: "b"}
+		Expect(storage.CreateAssets(userID, []*models.Asset{first})).To(Succeed())
+
+		// Uploads checked in parallel against the same usage are rejected when they are registered
+		Expect(storage.CreateAssets(userID, []*models.Asset{second})).To(MatchError(database.ErrQuotaExceeded))
+		usage, err := storage.GetUsage(userID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(usage.AssetFiles).To(Equal(1))
+
+		// Registering stored content again doesn't need room
+		Expect(storage.CreateAssets(userID, []*models.Asset{first})).To(Succeed())
+	})
+})
@@ -0,0 +1,147 @@
+package encryption_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ya-breeze/diary.be/pkg/encryption"
+)
+
+func newKeyring(userIDs ...string) *encryption.Keyring {
+	key, err := encryption.ParseKey(encryption.GenerateKey())
+	Expect(err).ToNot(HaveOccurred())
+	keys, err := encryption.NewKeyring(key)
+	Expect(err).ToNot(HaveOccurred())
+	for _, userID := range userIDs {
+		Expect(keys.AddDataKey(userID, keys.NewDataKey(userID))).To(Succeed())
+	}
+	return keys
+}
+
+var _ = Describe("Keys", func() {
+	It("should reject invalid master keys", func() {
+		_, err := encryption.ParseKey("not base64!")
+		Expect(err).To(MatchError(encryption.ErrInvalidKey))
+		_, err = encryption.ParseKey("c2hvcnQ=")
+		Expect(err).To(MatchError(encryption.ErrInvalidKey))
+	})
+
+	It("should rewrap data keys with a new master key", func() {
+		oldKeys, newKeys := newKeyring(), newKeyring()
+		wrapped := oldKeys.NewDataKey("user")
+		Expect(oldKeys.AddDataKey("user", wrapped)).To(Succeed())
+		value, err := oldKeys.EncryptString("user", "secret")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(newKeys.AddDataKey("user", wrapped)).To(MatchError(encryption.ErrDecrypt))
+		rewrapped, err := oldKeys.Rewrap("user", wrapped, newKeys)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(newKeys.AddDataKey("user", rewrapped)).To(Succeed())
+		Expect(newKeys.DecryptString(value)).To(Equal("secret"))
+	})
+
+	It("should not unwrap data keys of other users", func() {
+		keys := newKeyring()
+		Expect(keys.AddDataKey("other", keys.NewDataKey("user"))).To(MatchError(encryption.ErrDecrypt))
+	})
+})
+
+var _ = Describe("Fields", func() {
+	It("should encrypt and decrypt values", func() {
+		keys := newKeyring("user")
+		value, err := keys.EncryptString("user", "Dear diary")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(encryption.IsEncrypted(value)).To(BeTrue())
+		Expect(value).ToNot(ContainSubstring("diary"))
+		Expect(keys.DecryptString(value)).To(Equal("Dear diary"))
+	})
+
+	It("should pass plaintext through", func() {
+		Expect(newKeyring().DecryptString("written before encryption")).To(Equal("written before encryption"))
+	})
+
+	It("should reject tampered values and unknown users", func() {
+		keys := newKeyring("user")
+		value, err := keys.EncryptString("user", "Dear diary")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = keys.DecryptString(value[:len(value)-2] + "AA")
+		Expect(err).To(MatchError(encryption.ErrDecrypt))
+		_, err = keys.EncryptString("other", "Dear diary")
+		Expect(err).To(MatchError(encryption.ErrNoDataKey))
+	})
+})
+
+var _ = Describe("Streams", func() {
+	var dataKey *encryption.Cipher
+
+	BeforeEach(func() {
+		var err error
+		dataKey, err = newKeyring("user").DataKey("user")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	encrypt := func(content []byte) []byte {
+		encrypted, err := io.ReadAll(dataKey.EncryptReader(bytes.NewReader(content)))
+		Expect(err).ToNot(HaveOccurred())
+		return encrypted
+	}
+
+	decrypt := func(encrypted []byte, offset, length int64) []byte {
+		size, err := encryption.PlainSize(int64(len(encrypted)))
+		Expect(err).ToNot(HaveOccurred())
+		first, last, streamOffset, _ := encryption.ChunkRange(offset, length)
+		r, err := dataKey.DecryptReader(encrypted[:encryption.StreamHeaderSize], bytes.NewReader(encrypted[streamOffset:]),
+			first, last, encryption.LastChunk(size))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = io.CopyN(io.Discard, r, offset-first*encryption.ChunkSize)
+		Expect(err).ToNot(HaveOccurred())
+		plaintext, err := io.ReadAll(io.LimitReader(r, length))
+		Expect(err).ToNot(HaveOccurred())
+		return plaintext
+	}
+
+	DescribeTable("should encrypt streams of any size",
+		func(size int) {
+			content := []byte(strings.Repeat("0123456789", size/10+1)[:size])
+			encrypted := encrypt(content)
+			Expect(encryption.IsEncryptedStream(encrypted)).To(BeTrue())
+			Expect(int64(len(encrypted))).To(Equal(encryption.EncryptedSize(int64(size))))
+			Expect(encryption.PlainSize(int64(len(encrypted)))).To(Equal(int64(size)))
+			Expect(decrypt(encrypted, 0, int64(size))).To(Equal(content))
+		},
+		Entry("empty", 0),
+		Entry("short", 10),
+		Entry("one chunk", encryption.ChunkSize),
+		Entry("several chunks", 3*encryption.ChunkSize+5),
+	)
+
+	It("should decrypt ranges across chunks", func() {
+		content := []byte(strings.Repeat("0123456789", encryption.ChunkSize/4))
+		encrypted := encrypt(content)
+		Expect(decrypt(encrypted, 5, 10)).To(Equal(content[5:15]))
+		Expect(decrypt(encrypted, encryption.ChunkSize-3, 6)).To(Equal(content[encryption.ChunkSize-3 : encryption.ChunkSize+3]))
+		Expect(decrypt(encrypted, int64(len(content)-4), 4)).To(Equal(content[len(content)-4:]))
+	})
+
+	It("should detect truncated and tampered streams", func() {
+		encrypted := encrypt([]byte(strings.Repeat("x", 2*encryption.ChunkSize+100)))
+
+		// Cutting off the last chunk leaves a stream whose last chunk isn't marked as the last one
+		truncated := encrypted[:encryption.EncryptedSize(2*encryption.ChunkSize)]
+		r, err := dataKey.DecryptReader(truncated, bytes.NewReader(truncated[encryption.StreamHeaderSize:]), 0, 1, 1)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = io.ReadAll(r)
+		Expect(err).To(MatchError(encryption.ErrDecrypt))
+
+		encrypted[len(encrypted)-1] ^= 1
+		r, err = dataKey.DecryptReader(encrypted, bytes.NewReader(encrypted[encryption.StreamHeaderSize:]), 0, 2, 2)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = io.ReadAll(r)
+		Expect(err).To(MatchError(encryption.ErrDecrypt))
+	})
+})
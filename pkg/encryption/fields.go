@@ -0,0 +1,52 @@
+package encryption
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// FieldPrefix starts encrypted field values, they are FieldPrefix<userID>:<base64 sealed value>.
+// The user ID selects the data key, so values can be decrypted without the rest of their row.
+const FieldPrefix = "enc1:"
+
+// IsEncrypted reports whether the field value was encrypted by EncryptString
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, FieldPrefix)
+}
+
+// EncryptString encrypts the field value with the data key of the user. The value is bound to
+// the user, it can't be decrypted as a value of another user.
+func (k *Keyring) EncryptString(userID, plaintext string) (string, error) {
+	dataKey, err := k.DataKey(userID)
+	if err != nil {
+		return "", err
+	}
+	sealed := dataKey.Seal([]byte(plaintext), []byte(userID))
+	return FieldPrefix + userID + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptString decrypts a value encrypted by EncryptString, other values are returned as they are
+// so data stored before encryption was enabled stays readable
+func (k *Keyring) DecryptString(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	userID, encoded, ok := strings.Cut(strings.TrimPrefix(value, FieldPrefix), ":")
+	if !ok {
+		return "", fmt.Errorf("%w: malformed value", ErrDecrypt)
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrDecrypt, err)
+	}
+	dataKey, err := k.DataKey(userID)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := dataKey.Open(sealed, []byte(userID))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,150 @@
+// Package encryption implements the envelope encryption of diary data at rest. Every user has a
+// random data key that encrypts their entries and assets. Data keys are stored wrapped, encrypted
+// with the master key of the server, so rotating the master key only rewraps the data keys.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// KeySize is the size of master and data keys, they are AES-256 keys
+const KeySize = 32
+
+var (
+	// ErrInvalidKey is returned for master keys that aren't base64 encoded 32 byte keys
+	ErrInvalidKey = errors.New("invalid encryption key")
+	// ErrNoDataKey is returned when the data key of the user isn't loaded into the keyring
+	ErrNoDataKey = errors.New("no data key")
+	// ErrDecrypt is returned for data that was changed or encrypted with another key
+	ErrDecrypt = errors.New("failed to decrypt")
+)
+
+// ParseKey decodes a base64 encoded master key
+func ParseKey(s string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("%w: key must be %d bytes, got %d", ErrInvalidKey, KeySize, len(key))
+	}
+	return key, nil
+}
+
+// GenerateKey returns a new random master key encoded as base64
+func GenerateKey() string {
+	return base64.StdEncoding.EncodeToString(randomBytes(KeySize))
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	// crypto/rand never returns an error, it crashes the program if the OS can't provide randomness
+	_, _ = rand.Read(b)
+	return b
+}
+
+// Cipher encrypts and authenticates data with a single AES-256-GCM key
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+func NewCipher(key []byte) (*Cipher, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("%w: key must be %d bytes, got %d", ErrInvalidKey, KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Cipher{aead: aead}, nil
+}
+
+// Seal encrypts the plaintext with a random nonce, which is prepended to the result. The
+// additional data is authenticated but not stored, Open must be called with the same data.
+func (c *Cipher) Seal(plaintext, additionalData []byte) []byte {
+	nonce := randomBytes(c.aead.NonceSize())
+	return c.aead.Seal(nonce, nonce, plaintext, additionalData)
+}
+
+// Open decrypts data sealed by Seal
+func (c *Cipher) Open(data, additionalData []byte) ([]byte, error) {
+	if len(data) < c.aead.NonceSize()+c.aead.Overhead() {
+		return nil, fmt.Errorf("%w: data is too short", ErrDecrypt)
+	}
+	nonce, ciphertext := data[:c.aead.NonceSize()], data[c.aead.NonceSize():]
+	plaintext, err := c.aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecrypt, err)
+	}
+	return plaintext, nil
+}
+
+// Keyring holds the master key and the unwrapped data keys of the users
+type Keyring struct {
+	master *Cipher
+
+	mu       sync.RWMutex
+	dataKeys map[string]*Cipher
+}
+
+func NewKeyring(masterKey []byte) (*Keyring, error) {
+	master, err := NewCipher(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Keyring{master: master, dataKeys: map[string]*Cipher{}}, nil
+}
+
+// NewDataKey generates a data key for the user and returns it wrapped with the master key, it
+// still has to be loaded with AddDataKey
+func (k *Keyring) NewDataKey(userID string) []byte {
+	return k.master.Seal(randomBytes(KeySize), []byte(userID))
+}
+
+// AddDataKey unwraps the data key of the user and keeps it in the keyring
+func (k *Keyring) AddDataKey(userID string, wrapped []byte) error {
+	key, err := k.master.Open(wrapped, []byte(userID))
+	if err != nil {
+		return fmt.Errorf("failed to unwrap data key of user %q: %w", userID, err)
+	}
+	dataKey, err := NewCipher(key)
+	if err != nil {
+		return err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.dataKeys[userID] = dataKey
+	return nil
+}
+
+// DataKey returns the data key of the user
+func (k *Keyring) DataKey(userID string) (*Cipher, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	dataKey, ok := k.dataKeys[userID]
+	if !ok {
+		return nil, fmt.Errorf("%w for user %q", ErrNoDataKey, userID)
+	}
+	return dataKey, nil
+}
+
+// Rewrap unwraps the data key of the user with the master key of this keyring and wraps it with
+// the master key of the target keyring
+func (k *Keyring) Rewrap(userID string, wrapped []byte, target *Keyring) ([]byte, error) {
+	key, err := k.master.Open(wrapped, []byte(userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key of user %q: %w", userID, err)
+	}
+	return target.master.Seal(key, []byte(userID)), nil
+}
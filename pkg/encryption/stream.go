@@ -0,0 +1,214 @@
+package encryption
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Encrypted streams start with streamMagic and a random nonce prefix, followed by the content
+// split into chunks of ChunkSize bytes, each sealed on its own. The nonce of a chunk is the prefix
+// and the index of the chunk, and the last chunk is marked in its additional data, so chunks can't
+// be reordered or cut off. Ranges of a stream are decrypted by reading only the chunks they span.
+const (
+	// ChunkSize is the size of the plaintext of all but the last chunk of a stream
+	ChunkSize = 64 << 10
+
+	streamMagic     = "DEN1"
+	noncePrefixSize = 8
+	// StreamHeaderSize is the size of the header that starts every encrypted stream
+	StreamHeaderSize = len(streamMagic) + noncePrefixSize
+	// chunkOverhead is the size of the authentication tag of a chunk
+	chunkOverhead   = 16
+	sealedChunkSize = ChunkSize + chunkOverhead
+)
+
+// IsEncryptedStream reports whether the stream starting with the header was encrypted by EncryptReader
+func IsEncryptedStream(header []byte) bool {
+	return len(header) >= StreamHeaderSize && bytes.HasPrefix(header, []byte(streamMagic))
+}
+
+// chunkCount returns the number of chunks of a stream with size bytes of plaintext, even empty
+// streams have a chunk
+func chunkCount(size int64) int64 {
+	return max(1, (size+ChunkSize-1)/ChunkSize)
+}
+
+// EncryptedSize returns the size of the encrypted stream of size bytes of plaintext
+func EncryptedSize(size int64) int64 {
+	return int64(StreamHeaderSize) + size + chunkCount(size)*chunkOverhead
+}
+
+// PlainSize returns the size of the plaintext of an encrypted stream of size bytes
+func PlainSize(size int64) (int64, error) {
+	body := size - int64(StreamHeaderSize)
+	if body < chunkOverhead {
+		return 0, fmt.Errorf("%w: stream is too short", ErrDecrypt)
+	}
+	full, rest := body/sealedChunkSize, body%sealedChunkSize
+	switch {
+	case rest == 0:
+		return full * ChunkSize, nil
+	case rest < chunkOverhead:
+		return 0, fmt.Errorf("%w: stream is truncated", ErrDecrypt)
+	default:
+		return full*ChunkSize + rest - chunkOverhead, nil
+	}
+}
+
+// ChunkRange returns the indices of the first and the last chunk holding the length bytes of
+// plaintext at the offset and the range of the encrypted stream these chunks occupy
+func ChunkRange(offset, length int64) (first, last, streamOffset, streamLength int64) {
+	first = offset / ChunkSize
+	last = first
+	if length > 0 {
+		last = (offset + length - 1) / ChunkSize
+	}
+	return first, last, int64(StreamHeaderSize) + first*sealedChunkSize, (last - first + 1) * sealedChunkSize
+}
+
+// LastChunk returns the index of the last chunk of a stream with size bytes of plaintext
+func LastChunk(size int64) int64 {
+	return chunkCount(size) - 1
+}
+
+// EncryptReader returns a reader of the encrypted stream of the content of src
+func (c *Cipher) EncryptReader(src io.Reader) io.Reader {
+	prefix := randomBytes(noncePrefixSize)
+	return &encryptReader{
+		cipher: c,
+		src:    bufio.NewReaderSize(src, ChunkSize),
+		prefix: prefix,
+		chunk:  make([]byte, ChunkSize),
+		out:    append([]byte(streamMagic), prefix...),
+	}
+}
+
+type encryptReader struct {
+	cipher *Cipher
+	src    *bufio.Reader
+	prefix []byte
+	index  int64
+	chunk  []byte
+	// out is the encrypted data not read yet
+	out  []byte
+	done bool
+}
+
+func (r *encryptReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.sealChunk(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+func (r *encryptReader) sealChunk() error {
+	n, err := io.ReadFull(r.src, r.chunk)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return err
+	}
+	last := n < len(r.chunk)
+	if !last {
+		// A full chunk is the last one if nothing follows it
+		if _, err := r.src.Peek(1); err != nil {
+			if !errors.Is(err, io.EOF) {
+				return err
+			}
+			last = true
+		}
+	}
+
+	r.out = r.cipher.aead.Seal(r.out[:0], chunkNonce(r.prefix, r.index), r.chunk[:n], chunkAdditionalData(last))
+	r.index++
+	r.done = last
+	return nil
+}
+
+// DecryptReader returns a reader of the plaintext of the chunks first to last of an encrypted
+// stream. The header is the start of the stream, src must be positioned at the first chunk.
+// lastChunk is the index of the last chunk of the whole stream.
+func (c *Cipher) DecryptReader(header []byte, src io.Reader, first, last, lastChunk int64) (io.Reader, error) {
+	if !IsEncryptedStream(header) {
+		return nil, fmt.Errorf("%w: not an encrypted stream", ErrDecrypt)
+	}
+	return &decryptReader{
+		cipher:    c,
+		src:       src,
+		prefix:    bytes.Clone(header[len(streamMagic):StreamHeaderSize]),
+		index:     first,
+		last:      last,
+		lastChunk: lastChunk,
+		sealed:    make([]byte, sealedChunkSize),
+	}, nil
+}
+
+type decryptReader struct {
+	cipher    *Cipher
+	src       io.Reader
+	prefix    []byte
+	index     int64
+	last      int64
+	lastChunk int64
+	sealed    []byte
+	// out is the decrypted data not read yet
+	out []byte
+}
+
+func (r *decryptReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.index > r.last {
+			return 0, io.EOF
+		}
+		if err := r.openChunk(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+func (r *decryptReader) openChunk() error {
+	n, err := io.ReadFull(r.src, r.sealed)
+	isLast := r.index == r.lastChunk
+	switch {
+	case err == nil:
+	case errors.Is(err, io.ErrUnexpectedEOF) && isLast:
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return fmt.Errorf("%w: stream is truncated", ErrDecrypt)
+	default:
+		return err
+	}
+
+	plaintext, err := r.cipher.aead.Open(r.sealed[:0], chunkNonce(r.prefix, r.index), r.sealed[:n], chunkAdditionalData(isLast))
+	if err != nil {
+		return fmt.Errorf("%w: chunk %d: %w", ErrDecrypt, r.index, err)
+	}
+	r.out = plaintext
+	r.index++
+	return nil
+}
+
+func chunkNonce(prefix []byte, index int64) []byte {
+	nonce := make([]byte, noncePrefixSize+4)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[noncePrefixSize:], uint32(index)) //nolint:gosec // streams have less than 2^32 chunks
+	return nonce
+}
+
+func chunkAdditionalData(last bool) []byte {
+	if last {
+		return []byte{1}
+	}
+	return []byte{0}
+}
@@ -27,6 +27,7 @@ type ApiGetAssetRequest struct {
 	ctx        context.Context
 	ApiService *AssetsAPIService
 	path       *string
+	size       *string
 }
 
 // relative path to asset file
@@ -35,6 +36,12 @@ func (r ApiGetAssetRequest) Path(path string) ApiGetAssetRequest {
 	return r
 }
 
+// image variant to return, thumb or medium; the original is returned if omitted
+func (r ApiGetAssetRequest) Size(size string) ApiGetAssetRequest {
+	r.size = &size
+	return r
+}
+
 func (r ApiGetAssetRequest) Execute() (*os.File, *http.Response, error) {
 	return r.ApiService.GetAssetExecute(r)
 }
@@ -78,6 +85,9 @@ func (a *AssetsAPIService) GetAssetExecute(r ApiGetAssetRequest) (*os.File, *htt
 	}
 
 	parameterAddToHeaderOrQuery(localVarQueryParams, "path", r.path, "")
+	if r.size != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "size", r.size, "")
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -132,6 +142,157 @@ func (a *AssetsAPIService) GetAssetExecute(r ApiGetAssetRequest) (*os.File, *htt
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
+type ApiListAssetsRequest struct {
+	ctx          context.Context
+	ApiService   *AssetsAPIService
+	contentType  *string
+	date         *string
+	unreferenced *bool
+	limit        *int32
+	offset       *int32
+}
+
+// return assets whose MIME type starts with this value, e.g. image/
+func (r ApiListAssetsRequest) ContentType(contentType string) ApiListAssetsRequest {
+	r.contentType = &contentType
+	return r
+}
+
+// return assets referenced by the diary item of this date
+func (r ApiListAssetsRequest) Date(date string) ApiListAssetsRequest {
+	r.date = &date
+	return r
+}
+
+// return only assets not referenced by any diary item
+func (r ApiListAssetsRequest) Unreferenced(unreferenced bool) ApiListAssetsRequest {
+	r.unreferenced = &unreferenced
+	return r
+}
+
+// maximum number of assets to return; all matching assets are returned if omitted
+func (r ApiListAssetsRequest) Limit(limit int32) ApiListAssetsRequest {
+	r.limit = &limit
+	return r
+}
+
+// number of matching assets to skip
+func (r ApiListAssetsRequest) Offset(offset int32) ApiListAssetsRequest {
+	r.offset = &offset
+	return r
+}
+
+func (r ApiListAssetsRequest) Execute() (*AssetsListResponse, *http.Response, error) {
+	return r.ApiService.ListAssetsExecute(r)
+}
+
+/*
+ListAssets list assets
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@return ApiListAssetsRequest
+*/
+func (a *AssetsAPIService) ListAssets(ctx context.Context) ApiListAssetsRequest {
+	return ApiListAssetsRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+// Execute executes the request
+//
+//	@return AssetsListResponse
+func (a *AssetsAPIService) ListAssetsExecute(r ApiListAssetsRequest) (*AssetsListResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue *AssetsListResponse
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AssetsAPIService.ListAssets")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/assets/list"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.contentType != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "contentType", r.contentType, "")
+	}
+	if r.date != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "date", r.date, "")
+	}
+	if r.unreferenced != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "unreferenced", r.unreferenced, "")
+	}
+	if r.limit != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "limit", r.limit, "")
+	}
+	if r.offset != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "offset", r.offset, "")
+	} else {
+		var defaultValue int32 = 0
+		r.offset = &defaultValue
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
 type ApiUploadAssetRequest struct {
 	ctx        context.Context
 	ApiService *AssetsAPIService
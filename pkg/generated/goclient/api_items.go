@@ -17,6 +17,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 // ItemsAPIService ItemsAPI service
@@ -26,8 +27,16 @@ type ApiGetItemsRequest struct {
 	ctx        context.Context
 	ApiService *ItemsAPIService
 	date       *string
+	dateFrom   *string
+	dateTo     *string
 	search     *string
 	tags       *string
+	tagsAll    *string
+	tagsNone   *string
+	limit      *int32
+	offset     *int32
+	sort       *string
+	fields     *string
 }
 
 // filter items by date (optional)
@@ -36,18 +45,66 @@ func (r ApiGetItemsRequest) Date(date string) ApiGetItemsRequest {
 	return r
 }
 
-// search text to filter items by title and body content
+// return items dated on or after this date
+func (r ApiGetItemsRequest) DateFrom(dateFrom string) ApiGetItemsRequest {
+	r.dateFrom = &dateFrom
+	return r
+}
+
+// return items dated on or before this date
+func (r ApiGetItemsRequest) DateTo(dateTo string) ApiGetItemsRequest {
+	r.dateTo = &dateTo
+	return r
+}
+
+// full-text search query over title and body; supports quoted phrases, prefix* matching and AND/OR/NOT operators, results are ranked by relevance
 func (r ApiGetItemsRequest) Search(search string) ApiGetItemsRequest {
 	r.search = &search
 	return r
 }
 
-// comma-separated list of tags to filter items
+// comma-separated list of tags, items having any of them are returned
 func (r ApiGetItemsRequest) Tags(tags string) ApiGetItemsRequest {
 	r.tags = &tags
 	return r
 }
 
+// comma-separated list of tags, only items having all of them are returned
+func (r ApiGetItemsRequest) TagsAll(tagsAll string) ApiGetItemsRequest {
+	r.tagsAll = &tagsAll
+	return r
+}
+
+// comma-separated list of tags, items having any of them are excluded
+func (r ApiGetItemsRequest) TagsNone(tagsNone string) ApiGetItemsRequest {
+	r.tagsNone = &tagsNone
+	return r
+}
+
+// maximum number of items to return
+func (r ApiGetItemsRequest) Limit(limit int32) ApiGetItemsRequest {
+	r.limit = &limit
+	return r
+}
+
+// number of matching items to skip
+func (r ApiGetItemsRequest) Offset(offset int32) ApiGetItemsRequest {
+	r.offset = &offset
+	return r
+}
+
+// sort order; defaults to relevance when searching and to date_desc otherwise
+func (r ApiGetItemsRequest) Sort(sort string) ApiGetItemsRequest {
+	r.sort = &sort
+	return r
+}
+
+// comma-separated list of item fields to return (title, body, tags, snippet, previousDate, nextDate); date is always returned, omitted title and body are returned empty. All fields are returned if not set
+func (r ApiGetItemsRequest) Fields(fields string) ApiGetItemsRequest {
+	r.fields = &fields
+	return r
+}
+
 func (r ApiGetItemsRequest) Execute() (*ItemsListResponse, *http.Response, error) {
 	return r.ApiService.GetItemsExecute(r)
 }
@@ -90,12 +147,42 @@ func (a *ItemsAPIService) GetItemsExecute(r ApiGetItemsRequest) (*ItemsListRespo
 	if r.date != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "date", r.date, "")
 	}
+	if r.dateFrom != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "dateFrom", r.dateFrom, "")
+	}
+	if r.dateTo != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "dateTo", r.dateTo, "")
+	}
 	if r.search != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "search", r.search, "")
 	}
 	if r.tags != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "tags", r.tags, "")
 	}
+	if r.tagsAll != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "tagsAll", r.tagsAll, "")
+	}
+	if r.tagsNone != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "tagsNone", r.tagsNone, "")
+	}
+	if r.limit != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "limit", r.limit, "")
+	} else {
+		var defaultValue int32 = 100
+		r.limit = &defaultValue
+	}
+	if r.offset != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "offset", r.offset, "")
+	} else {
+		var defaultValue int32 = 0
+		r.offset = &defaultValue
+	}
+	if r.sort != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "sort", r.sort, "")
+	}
+	if r.fields != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "fields", r.fields, "")
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -154,6 +241,7 @@ type ApiPutItemsRequest struct {
 	ctx          context.Context
 	ApiService   *ItemsAPIService
 	itemsRequest *ItemsRequest
+	ifMatch      *string
 }
 
 func (r ApiPutItemsRequest) ItemsRequest(itemsRequest ItemsRequest) ApiPutItemsRequest {
@@ -161,6 +249,12 @@ func (r ApiPutItemsRequest) ItemsRequest(itemsRequest ItemsRequest) ApiPutItemsR
 	return r
 }
 
+// Version of the item the change is based on (as returned in the version field, optionally quoted). The item is saved only if it still has this version, 0 means that the item must not exist yet
+func (r ApiPutItemsRequest) IfMatch(ifMatch string) ApiPutItemsRequest {
+	r.ifMatch = &ifMatch
+	return r
+}
+
 func (r ApiPutItemsRequest) Execute() (*ItemsResponse, *http.Response, error) {
 	return r.ApiService.PutItemsExecute(r)
 }
@@ -220,6 +314,9 @@ func (a *ItemsAPIService) PutItemsExecute(r ApiPutItemsRequest) (*ItemsResponse,
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	if r.ifMatch != nil {
+		parameterAddToHeaderOrQuery(localVarHeaderParams, "If-Match", r.ifMatch, "")
+	}
 	// body params
 	localVarPostBody = r.itemsRequest
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
@@ -239,6 +336,444 @@ func (a *ItemsAPIService) PutItemsExecute(r ApiPutItemsRequest) (*ItemsResponse,
 		return localVarReturnValue, localVarHTTPResponse, err
 	}
 
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 409 {
+			var v ItemsResponse
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiDeleteItemsRequest struct {
+	ctx        context.Context
+	ApiService *ItemsAPIService
+	date       *string
+}
+
+// date of the item to delete
+func (r ApiDeleteItemsRequest) Date(date string) ApiDeleteItemsRequest {
+	r.date = &date
+	return r
+}
+
+func (r ApiDeleteItemsRequest) Execute() (*http.Response, error) {
+	return r.ApiService.DeleteItemsExecute(r)
+}
+
+/*
+DeleteItems delete diary item
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@return ApiDeleteItemsRequest
+*/
+func (a *ItemsAPIService) DeleteItems(ctx context.Context) ApiDeleteItemsRequest {
+	return ApiDeleteItemsRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+// Execute executes the request
+func (a *ItemsAPIService) DeleteItemsExecute(r ApiDeleteItemsRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod = http.MethodDelete
+		localVarPostBody   interface{}
+		formFiles          []formFile
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "ItemsAPIService.DeleteItems")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/items"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.date == nil {
+		return nil, reportError("date is required and must be specified")
+	}
+
+	parameterAddToHeaderOrQuery(localVarQueryParams, "date", r.date, "")
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
+type ApiGetItemRevisionsRequest struct {
+	ctx        context.Context
+	ApiService *ItemsAPIService
+	date       string
+}
+
+func (r ApiGetItemRevisionsRequest) Execute() ([]ItemRevisionResponse, *http.Response, error) {
+	return r.ApiService.GetItemRevisionsExecute(r)
+}
+
+/*
+GetItemRevisions get revision history of diary item
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@param date date of the item
+	@return ApiGetItemRevisionsRequest
+*/
+func (a *ItemsAPIService) GetItemRevisions(ctx context.Context, date string) ApiGetItemRevisionsRequest {
+	return ApiGetItemRevisionsRequest{
+		ApiService: a,
+		ctx:        ctx,
+		date:       date,
+	}
+}
+
+// Execute executes the request
+//
+//	@return []ItemRevisionResponse
+func (a *ItemsAPIService) GetItemRevisionsExecute(r ApiGetItemRevisionsRequest) ([]ItemRevisionResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue []ItemRevisionResponse
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "ItemsAPIService.GetItemRevisions")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/items/{date}/revisions"
+	localVarPath = strings.Replace(localVarPath, "{"+"date"+"}", url.PathEscape(parameterValueToString(r.date, "date")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiGetItemRevisionsDiffRequest struct {
+	ctx        context.Context
+	ApiService *ItemsAPIService
+	date       string
+	from       *int64
+	to         *int64
+}
+
+// ID of the older revision
+func (r ApiGetItemRevisionsDiffRequest) From(from int64) ApiGetItemRevisionsDiffRequest {
+	r.from = &from
+	return r
+}
+
+// ID of the newer revision, the latest revision is used if omitted
+func (r ApiGetItemRevisionsDiffRequest) To(to int64) ApiGetItemRevisionsDiffRequest {
+	r.to = &to
+	return r
+}
+
+func (r ApiGetItemRevisionsDiffRequest) Execute() (*ItemRevisionDiffResponse, *http.Response, error) {
+	return r.ApiService.GetItemRevisionsDiffExecute(r)
+}
+
+/*
+GetItemRevisionsDiff compare two revisions of diary item
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@param date date of the item
+	@return ApiGetItemRevisionsDiffRequest
+*/
+func (a *ItemsAPIService) GetItemRevisionsDiff(ctx context.Context, date string) ApiGetItemRevisionsDiffRequest {
+	return ApiGetItemRevisionsDiffRequest{
+		ApiService: a,
+		ctx:        ctx,
+		date:       date,
+	}
+}
+
+// Execute executes the request
+//
+//	@return ItemRevisionDiffResponse
+func (a *ItemsAPIService) GetItemRevisionsDiffExecute(r ApiGetItemRevisionsDiffRequest) (*ItemRevisionDiffResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue *ItemRevisionDiffResponse
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "ItemsAPIService.GetItemRevisionsDiff")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/items/{date}/revisions/diff"
+	localVarPath = strings.Replace(localVarPath, "{"+"date"+"}", url.PathEscape(parameterValueToString(r.date, "date")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.from == nil {
+		return localVarReturnValue, nil, reportError("from is required and must be specified")
+	}
+
+	parameterAddToHeaderOrQuery(localVarQueryParams, "from", r.from, "")
+	if r.to != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "to", r.to, "")
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiRestoreItemRevisionRequest struct {
+	ctx        context.Context
+	ApiService *ItemsAPIService
+	date       string
+	id         int64
+}
+
+func (r ApiRestoreItemRevisionRequest) Execute() (*ItemsResponse, *http.Response, error) {
+	return r.ApiService.RestoreItemRevisionExecute(r)
+}
+
+/*
+RestoreItemRevision restore diary item to a previous revision
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@param date date of the item
+	@param id ID of the revision to restore
+	@return ApiRestoreItemRevisionRequest
+*/
+func (a *ItemsAPIService) RestoreItemRevision(ctx context.Context, date string, id int64) ApiRestoreItemRevisionRequest {
+	return ApiRestoreItemRevisionRequest{
+		ApiService: a,
+		ctx:        ctx,
+		date:       date,
+		id:         id,
+	}
+}
+
+// Execute executes the request
+//
+//	@return ItemsResponse
+func (a *ItemsAPIService) RestoreItemRevisionExecute(r ApiRestoreItemRevisionRequest) (*ItemsResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue *ItemsResponse
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "ItemsAPIService.RestoreItemRevision")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/items/{date}/revisions/{id}/restore"
+	localVarPath = strings.Replace(localVarPath, "{"+"date"+"}", url.PathEscape(parameterValueToString(r.date, "date")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
@@ -57,6 +57,8 @@ type APIClient struct {
 
 	SyncAPI *SyncAPIService
 
+	TagsAPI *TagsAPIService
+
 	UserAPI *UserAPIService
 }
 
@@ -80,6 +82,7 @@ func NewAPIClient(cfg *Configuration) *APIClient {
 	c.AuthAPI = (*AuthAPIService)(&c.common)
 	c.ItemsAPI = (*ItemsAPIService)(&c.common)
 	c.SyncAPI = (*SyncAPIService)(&c.common)
+	c.TagsAPI = (*TagsAPIService)(&c.common)
 	c.UserAPI = (*UserAPIService)(&c.common)
 
 	return c
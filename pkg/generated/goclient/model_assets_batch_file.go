@@ -15,6 +15,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"time"
 )
 
 // checks if the AssetsBatchFile type satisfies the MappedNullable interface at compile time
@@ -30,6 +31,10 @@ type AssetsBatchFile struct {
 	Size int64 `json:"size"`
 	// MIME type detected for the file
 	ContentType *string `json:"contentType,omitempty"`
+	// True if the content was already stored and savedName is the existing asset
+	Deduplicated bool `json:"deduplicated"`
+	// When the photo was taken according to its EXIF data
+	CapturedAt NullableTime `json:"capturedAt,omitempty"`
 }
 
 type _AssetsBatchFile AssetsBatchFile
@@ -38,11 +43,12 @@ type _AssetsBatchFile AssetsBatchFile
 // This constructor will assign default values to properties that have it defined,
 // and makes sure properties required by API are set, but the set of arguments
 // will change when the set of required properties is changed
-func NewAssetsBatchFile(originalName string, savedName string, size int64) *AssetsBatchFile {
+func NewAssetsBatchFile(originalName string, savedName string, size int64, deduplicated bool) *AssetsBatchFile {
 	this := AssetsBatchFile{}
 	this.OriginalName = originalName
 	this.SavedName = savedName
 	this.Size = size
+	this.Deduplicated = deduplicated
 	return &this
 }
 
@@ -158,6 +164,73 @@ func (o *AssetsBatchFile) SetContentType(v string) {
 	o.ContentType = &v
 }
 
+// GetDeduplicated returns the Deduplicated field value
+func (o *AssetsBatchFile) GetDeduplicated() bool {
+	if o == nil {
+		var ret bool
+		return ret
+	}
+
+	return o.Deduplicated
+}
+
+// GetDeduplicatedOk returns a tuple with the Deduplicated field value
+// and a boolean to check if the value has been set.
+func (o *AssetsBatchFile) GetDeduplicatedOk() (*bool, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.Deduplicated, true
+}
+
+// SetDeduplicated sets field value
+func (o *AssetsBatchFile) SetDeduplicated(v bool) {
+	o.Deduplicated = v
+}
+
+// GetCapturedAt returns the CapturedAt field value if set, zero value otherwise (both if not set or set to explicit null).
+func (o *AssetsBatchFile) GetCapturedAt() time.Time {
+	if o == nil || IsNil(o.CapturedAt.Get()) {
+		var ret time.Time
+		return ret
+	}
+	return *o.CapturedAt.Get()
+}
+
+// GetCapturedAtOk returns a tuple with the CapturedAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+// NOTE: If the value is an explicit nil, `nil, true` will be returned
+func (o *AssetsBatchFile) GetCapturedAtOk() (*time.Time, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return o.CapturedAt.Get(), o.CapturedAt.IsSet()
+}
+
+// HasCapturedAt returns a boolean if a field has been set.
+func (o *AssetsBatchFile) HasCapturedAt() bool {
+	if o != nil && o.CapturedAt.IsSet() {
+		return true
+	}
+
+	return false
+}
+
+// SetCapturedAt gets a reference to the given NullableTime and assigns it to the CapturedAt field.
+func (o *AssetsBatchFile) SetCapturedAt(v time.Time) {
+	o.CapturedAt.Set(&v)
+}
+
+// SetCapturedAtNil sets the value for CapturedAt to be an explicit nil
+func (o *AssetsBatchFile) SetCapturedAtNil() {
+	o.CapturedAt.Set(nil)
+}
+
+// UnsetCapturedAt ensures that no value is present for CapturedAt, not even an explicit nil
+func (o *AssetsBatchFile) UnsetCapturedAt() {
+	o.CapturedAt.Unset()
+}
+
 func (o AssetsBatchFile) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -174,6 +247,10 @@ func (o AssetsBatchFile) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.ContentType) {
 		toSerialize["contentType"] = o.ContentType
 	}
+	toSerialize["deduplicated"] = o.Deduplicated
+	if o.CapturedAt.IsSet() {
+		toSerialize["capturedAt"] = o.CapturedAt.Get()
+	}
 	return toSerialize, nil
 }
 
@@ -185,6 +262,7 @@ func (o *AssetsBatchFile) UnmarshalJSON(data []byte) (err error) {
 		"originalName",
 		"savedName",
 		"size",
+		"deduplicated",
 	}
 
 	allProperties := make(map[string]interface{})
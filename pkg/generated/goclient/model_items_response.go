@@ -28,6 +28,10 @@ type ItemsResponse struct {
 	Body         string         `json:"body"`
 	PreviousDate NullableString `json:"previousDate,omitempty"`
 	NextDate     NullableString `json:"nextDate,omitempty"`
+	// Excerpt of the body around search matches, HTML-escaped with matches wrapped in <mark> tags; only present when searching
+	Snippet *string `json:"snippet,omitempty"`
+	// Version of the item, incremented on every save. Send it back in the If-Match header to detect conflicting edits
+	Version *int64 `json:"version,omitempty"`
 }
 
 type _ItemsResponse ItemsResponse
@@ -242,6 +246,70 @@ func (o *ItemsResponse) UnsetNextDate() {
 	o.NextDate.Unset()
 }
 
+// GetSnippet returns the Snippet field value if set, zero value otherwise.
+func (o *ItemsResponse) GetSnippet() string {
+	if o == nil || IsNil(o.Snippet) {
+		var ret string
+		return ret
+	}
+	return *o.Snippet
+}
+
+// GetSnippetOk returns a tuple with the Snippet field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ItemsResponse) GetSnippetOk() (*string, bool) {
+	if o == nil || IsNil(o.Snippet) {
+		return nil, false
+	}
+	return o.Snippet, true
+}
+
+// HasSnippet returns a boolean if a field has been set.
+func (o *ItemsResponse) HasSnippet() bool {
+	if o != nil && !IsNil(o.Snippet) {
+		return true
+	}
+
+	return false
+}
+
+// SetSnippet gets a reference to the given string and assigns it to the Snippet field.
+func (o *ItemsResponse) SetSnippet(v string) {
+	o.Snippet = &v
+}
+
+// GetVersion returns the Version field value if set, zero value otherwise.
+func (o *ItemsResponse) GetVersion() int64 {
+	if o == nil || IsNil(o.Version) {
+		var ret int64
+		return ret
+	}
+	return *o.Version
+}
+
+// GetVersionOk returns a tuple with the Version field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ItemsResponse) GetVersionOk() (*int64, bool) {
+	if o == nil || IsNil(o.Version) {
+		return nil, false
+	}
+	return o.Version, true
+}
+
+// HasVersion returns a boolean if a field has been set.
+func (o *ItemsResponse) HasVersion() bool {
+	if o != nil && !IsNil(o.Version) {
+		return true
+	}
+
+	return false
+}
+
+// SetVersion gets a reference to the given int64 and assigns it to the Version field.
+func (o *ItemsResponse) SetVersion(v int64) {
+	o.Version = &v
+}
+
 func (o ItemsResponse) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -264,6 +332,12 @@ func (o ItemsResponse) ToMap() (map[string]interface{}, error) {
 	if o.NextDate.IsSet() {
 		toSerialize["nextDate"] = o.NextDate.Get()
 	}
+	if !IsNil(o.Snippet) {
+		toSerialize["snippet"] = o.Snippet
+	}
+	if !IsNil(o.Version) {
+		toSerialize["version"] = o.Version
+	}
 	return toSerialize, nil
 }
 
@@ -24,10 +24,10 @@ var _ MappedNullable = &SyncChangeResponse{}
 // SyncChangeResponse struct for SyncChangeResponse
 type SyncChangeResponse struct {
 	// Unique change ID
-	Id int32 `json:"id"`
+	Id int64 `json:"id"`
 	// User ID who made the change
 	UserId string `json:"userId"`
-	// Date of the diary entry that was changed
+	// Date of the diary entry that was changed, empty for asset changes
 	Date string `json:"date"`
 	// Type of operation performed
 	OperationType string `json:"operationType"`
@@ -37,6 +37,10 @@ type SyncChangeResponse struct {
 	ItemSnapshot NullableItemsResponse `json:"itemSnapshot,omitempty"`
 	// Additional metadata about the change
 	Metadata []string `json:"metadata,omitempty"`
+	// Kind of the changed entity, item or asset
+	EntityType string `json:"entityType"`
+	// Changed asset, set for asset changes only
+	AssetSnapshot NullableSyncAssetSnapshot `json:"assetSnapshot,omitempty"`
 }
 
 type _SyncChangeResponse SyncChangeResponse
@@ -45,13 +49,14 @@ type _SyncChangeResponse SyncChangeResponse
 // This constructor will assign default values to properties that have it defined,
 // and makes sure properties required by API are set, but the set of arguments
 // will change when the set of required properties is changed
-func NewSyncChangeResponse(id int32, userId string, date string, operationType string, timestamp time.Time) *SyncChangeResponse {
+func NewSyncChangeResponse(id int64, userId string, date string, operationType string, timestamp time.Time, entityType string) *SyncChangeResponse {
 	this := SyncChangeResponse{}
 	this.Id = id
 	this.UserId = userId
 	this.Date = date
 	this.OperationType = operationType
 	this.Timestamp = timestamp
+	this.EntityType = entityType
 	return &this
 }
 
@@ -64,9 +69,9 @@ func NewSyncChangeResponseWithDefaults() *SyncChangeResponse {
 }
 
 // GetId returns the Id field value
-func (o *SyncChangeResponse) GetId() int32 {
+func (o *SyncChangeResponse) GetId() int64 {
 	if o == nil {
-		var ret int32
+		var ret int64
 		return ret
 	}
 
@@ -75,7 +80,7 @@ func (o *SyncChangeResponse) GetId() int32 {
 
 // GetIdOk returns a tuple with the Id field value
 // and a boolean to check if the value has been set.
-func (o *SyncChangeResponse) GetIdOk() (*int32, bool) {
+func (o *SyncChangeResponse) GetIdOk() (*int64, bool) {
 	if o == nil {
 		return nil, false
 	}
@@ -83,7 +88,7 @@ func (o *SyncChangeResponse) GetIdOk() (*int32, bool) {
 }
 
 // SetId sets field value
-func (o *SyncChangeResponse) SetId(v int32) {
+func (o *SyncChangeResponse) SetId(v int64) {
 	o.Id = v
 }
 
@@ -258,6 +263,73 @@ func (o *SyncChangeResponse) SetMetadata(v []string) {
 	o.Metadata = v
 }
 
+// GetEntityType returns the EntityType field value
+func (o *SyncChangeResponse) GetEntityType() string {
+	if o == nil {
+		var ret string
+		return ret
+	}
+
+	return o.EntityType
+}
+
+// GetEntityTypeOk returns a tuple with the EntityType field value
+// and a boolean to check if the value has been set.
+func (o *SyncChangeResponse) GetEntityTypeOk() (*string, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.EntityType, true
+}
+
+// SetEntityType sets field value
+func (o *SyncChangeResponse) SetEntityType(v string) {
+	o.EntityType = v
+}
+
+// GetAssetSnapshot returns the AssetSnapshot field value if set, zero value otherwise (both if not set or set to explicit null).
+func (o *SyncChangeResponse) GetAssetSnapshot() SyncAssetSnapshot {
+	if o == nil || IsNil(o.AssetSnapshot.Get()) {
+		var ret SyncAssetSnapshot
+		return ret
+	}
+	return *o.AssetSnapshot.Get()
+}
+
+// GetAssetSnapshotOk returns a tuple with the AssetSnapshot field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+// NOTE: If the value is an explicit nil, `nil, true` will be returned
+func (o *SyncChangeResponse) GetAssetSnapshotOk() (*SyncAssetSnapshot, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return o.AssetSnapshot.Get(), o.AssetSnapshot.IsSet()
+}
+
+// HasAssetSnapshot returns a boolean if a field has been set.
+func (o *SyncChangeResponse) HasAssetSnapshot() bool {
+	if o != nil && o.AssetSnapshot.IsSet() {
+		return true
+	}
+
+	return false
+}
+
+// SetAssetSnapshot gets a reference to the given NullableSyncAssetSnapshot and assigns it to the AssetSnapshot field.
+func (o *SyncChangeResponse) SetAssetSnapshot(v SyncAssetSnapshot) {
+	o.AssetSnapshot.Set(&v)
+}
+
+// SetAssetSnapshotNil sets the value for AssetSnapshot to be an explicit nil
+func (o *SyncChangeResponse) SetAssetSnapshotNil() {
+	o.AssetSnapshot.Set(nil)
+}
+
+// UnsetAssetSnapshot ensures that no value is present for AssetSnapshot, not even an explicit nil
+func (o *SyncChangeResponse) UnsetAssetSnapshot() {
+	o.AssetSnapshot.Unset()
+}
+
 func (o SyncChangeResponse) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -279,6 +351,10 @@ func (o SyncChangeResponse) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.Metadata) {
 		toSerialize["metadata"] = o.Metadata
 	}
+	toSerialize["entityType"] = o.EntityType
+	if o.AssetSnapshot.IsSet() {
+		toSerialize["assetSnapshot"] = o.AssetSnapshot.Get()
+	}
 	return toSerialize, nil
 }
 
@@ -292,6 +368,7 @@ func (o *SyncChangeResponse) UnmarshalJSON(data []byte) (err error) {
 		"date",
 		"operationType",
 		"timestamp",
+		"entityType",
 	}
 
 	allProperties := make(map[string]interface{})
@@ -27,7 +27,7 @@ type SyncResponse struct {
 	// Whether there are more changes available
 	HasMore bool `json:"hasMore"`
 	// ID to use for the next sync request (if hasMore is true)
-	NextId *int32 `json:"nextId,omitempty"`
+	NextId *int64 `json:"nextId,omitempty"`
 }
 
 type _SyncResponse SyncResponse
@@ -100,9 +100,9 @@ func (o *SyncResponse) SetHasMore(v bool) {
 }
 
 // GetNextId returns the NextId field value if set, zero value otherwise.
-func (o *SyncResponse) GetNextId() int32 {
+func (o *SyncResponse) GetNextId() int64 {
 	if o == nil || IsNil(o.NextId) {
-		var ret int32
+		var ret int64
 		return ret
 	}
 	return *o.NextId
@@ -110,7 +110,7 @@ func (o *SyncResponse) GetNextId() int32 {
 
 // GetNextIdOk returns a tuple with the NextId field value if set, nil otherwise
 // and a boolean to check if the value has been set.
-func (o *SyncResponse) GetNextIdOk() (*int32, bool) {
+func (o *SyncResponse) GetNextIdOk() (*int64, bool) {
 	if o == nil || IsNil(o.NextId) {
 		return nil, false
 	}
@@ -126,8 +126,8 @@ func (o *SyncResponse) HasNextId() bool {
 	return false
 }
 
-// SetNextId gets a reference to the given int32 and assigns it to the NextId field.
-func (o *SyncResponse) SetNextId(v int32) {
+// SetNextId gets a reference to the given int64 and assigns it to the NextId field.
+func (o *SyncResponse) SetNextId(v int64) {
 	o.NextId = &v
 }
 
@@ -22,6 +22,7 @@ import (
 // pass the data to a AssetsAPIServicer to perform the required actions, then write the service results to the http response.
 type AssetsAPIRouter interface {
 	GetAsset(http.ResponseWriter, *http.Request)
+	ListAssets(http.ResponseWriter, *http.Request)
 	UploadAsset(http.ResponseWriter, *http.Request)
 	UploadAssetsBatch(http.ResponseWriter, *http.Request)
 }
@@ -39,6 +40,10 @@ type AuthAPIRouter interface {
 type ItemsAPIRouter interface {
 	GetItems(http.ResponseWriter, *http.Request)
 	PutItems(http.ResponseWriter, *http.Request)
+	DeleteItems(http.ResponseWriter, *http.Request)
+	GetItemRevisions(http.ResponseWriter, *http.Request)
+	GetItemRevisionsDiff(http.ResponseWriter, *http.Request)
+	RestoreItemRevision(http.ResponseWriter, *http.Request)
 }
 
 // SyncAPIRouter defines the required methods for binding the api requests to a responses for the SyncAPI
@@ -46,6 +51,18 @@ type ItemsAPIRouter interface {
 // pass the data to a SyncAPIServicer to perform the required actions, then write the service results to the http response.
 type SyncAPIRouter interface {
 	GetChanges(http.ResponseWriter, *http.Request)
+	GetSnapshot(http.ResponseWriter, *http.Request)
+	PushChanges(http.ResponseWriter, *http.Request)
+	StreamChanges(http.ResponseWriter, *http.Request)
+}
+
+// TagsAPIRouter defines the required methods for binding the api requests to a responses for the TagsAPI
+// The TagsAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a TagsAPIServicer to perform the required actions, then write the service results to the http response.
+type TagsAPIRouter interface {
+	GetTags(http.ResponseWriter, *http.Request)
+	MergeTags(http.ResponseWriter, *http.Request)
+	RenameTag(http.ResponseWriter, *http.Request)
 }
 
 // UserAPIRouter defines the required methods for binding the api requests to a responses for the UserAPI
@@ -53,6 +70,7 @@ type SyncAPIRouter interface {
 // pass the data to a UserAPIServicer to perform the required actions, then write the service results to the http response.
 type UserAPIRouter interface {
 	GetUser(http.ResponseWriter, *http.Request)
+	GetUserUsage(http.ResponseWriter, *http.Request)
 }
 
 // AssetsAPIServicer defines the api actions for the AssetsAPI service
@@ -60,7 +78,8 @@ type UserAPIRouter interface {
 // while the service implementation can be ignored with the .openapi-generator-ignore file
 // and updated with the logic required for the API.
 type AssetsAPIServicer interface {
-	GetAsset(context.Context, string) (ImplResponse, error)
+	GetAsset(context.Context, string, string) (ImplResponse, error)
+	ListAssets(context.Context, string, string, bool, int32, int32) (ImplResponse, error)
 	UploadAsset(context.Context, *os.File) (ImplResponse, error)
 	UploadAssetsBatch(context.Context, []*os.File) (ImplResponse, error)
 }
@@ -78,8 +97,12 @@ type AuthAPIServicer interface {
 // while the service implementation can be ignored with the .openapi-generator-ignore file
 // and updated with the logic required for the API.
 type ItemsAPIServicer interface {
-	GetItems(context.Context, string, string, string) (ImplResponse, error)
-	PutItems(context.Context, ItemsRequest) (ImplResponse, error)
+	GetItems(context.Context, string, string, string, string, string, string, string, int32, int32, string, string) (ImplResponse, error)
+	PutItems(context.Context, ItemsRequest, string) (ImplResponse, error)
+	DeleteItems(context.Context, string) (ImplResponse, error)
+	GetItemRevisions(context.Context, string) (ImplResponse, error)
+	GetItemRevisionsDiff(context.Context, string, int64, int64) (ImplResponse, error)
+	RestoreItemRevision(context.Context, string, int64) (ImplResponse, error)
 }
 
 // SyncAPIServicer defines the api actions for the SyncAPI service
@@ -87,7 +110,20 @@ type ItemsAPIServicer interface {
 // while the service implementation can be ignored with the .openapi-generator-ignore file
 // and updated with the logic required for the API.
 type SyncAPIServicer interface {
-	GetChanges(context.Context, int32, int32) (ImplResponse, error)
+	GetChanges(context.Context, int64, int32) (ImplResponse, error)
+	GetSnapshot(context.Context) (ImplResponse, error)
+	PushChanges(context.Context, SyncPushRequest) (ImplResponse, error)
+	StreamChanges(context.Context, int64, string) (ImplResponse, error)
+}
+
+// TagsAPIServicer defines the api actions for the TagsAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type TagsAPIServicer interface {
+	GetTags(context.Context) (ImplResponse, error)
+	MergeTags(context.Context, TagMergeRequest) (ImplResponse, error)
+	RenameTag(context.Context, TagRenameRequest) (ImplResponse, error)
 }
 
 // UserAPIServicer defines the api actions for the UserAPI service
@@ -96,4 +132,5 @@ type SyncAPIServicer interface {
 // and updated with the logic required for the API.
 type UserAPIServicer interface {
 	GetUser(context.Context) (ImplResponse, error)
+	GetUserUsage(context.Context) (ImplResponse, error)
 }
@@ -55,6 +55,11 @@ func (c *AssetsAPIController) Routes() Routes {
 			"/v1/assets",
 			c.GetAsset,
 		},
+		"ListAssets": Route{
+			strings.ToUpper("Get"),
+			"/v1/assets/list",
+			c.ListAssets,
+		},
 		"UploadAsset": Route{
 			strings.ToUpper("Post"),
 			"/v1/assets",
@@ -84,7 +89,92 @@ func (c *AssetsAPIController) GetAsset(w http.ResponseWriter, r *http.Request) {
 		c.errorHandler(w, r, &RequiredError{Field: "path"}, nil)
 		return
 	}
-	result, err := c.service.GetAsset(r.Context(), pathParam)
+	var sizeParam string
+	if query.Has("size") {
+		param := query.Get("size")
+
+		sizeParam = param
+	} else {
+	}
+	result, err := c.service.GetAsset(r.Context(), pathParam, sizeParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// ListAssets - list assets
+func (c *AssetsAPIController) ListAssets(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var contentTypeParam string
+	if query.Has("contentType") {
+		param := query.Get("contentType")
+
+		contentTypeParam = param
+	} else {
+	}
+	var dateParam string
+	if query.Has("date") {
+		param := string(query.Get("date"))
+
+		dateParam = param
+	} else {
+	}
+	var unreferencedParam bool
+	if query.Has("unreferenced") {
+		param, err := parseBoolParameter(
+			query.Get("unreferenced"),
+			WithParse[bool](parseBool),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "unreferenced", Err: err}, nil)
+			return
+		}
+
+		unreferencedParam = param
+	} else {
+	}
+	var limitParam int32
+	if query.Has("limit") {
+		param, err := parseNumericParameter[int32](
+			query.Get("limit"),
+			WithParse[int32](parseInt32),
+			WithMinimum[int32](1),
+			WithMaximum[int32](1000),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "limit", Err: err}, nil)
+			return
+		}
+
+		limitParam = param
+	} else {
+	}
+	var offsetParam int32
+	if query.Has("offset") {
+		param, err := parseNumericParameter[int32](
+			query.Get("offset"),
+			WithParse[int32](parseInt32),
+			WithMinimum[int32](0),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "offset", Err: err}, nil)
+			return
+		}
+
+		offsetParam = param
+	} else {
+		var param int32 = 0
+		offsetParam = param
+	}
+	result, err := c.service.ListAssets(r.Context(), contentTypeParam, dateParam, unreferencedParam, limitParam, offsetParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -21,7 +21,9 @@ import (
 // AssetsAPIService is an interface that defines the logic for the AssetsAPIServicer
 type AssetsAPIService interface {
 	// GetAsset - return asset by path
-	GetAsset(ctx context.Context, path string) (ImplResponse, error)
+	GetAsset(ctx context.Context, path string, size string) (ImplResponse, error)
+	// ListAssets - list assets
+	ListAssets(ctx context.Context, contentType string, date string, unreferenced bool, limit int32, offset int32) (ImplResponse, error)
 	// UploadAsset - upload an asset file
 	UploadAsset(ctx context.Context, asset *os.File) (ImplResponse, error)
 	// UploadAssetsBatch - upload multiple asset files
@@ -40,7 +42,7 @@ func NewAssetsAPIService() AssetsAPIService {
 }
 
 // GetAsset - return asset by path
-func (s *AssetsAPIServiceImpl) GetAsset(ctx context.Context, path string) (ImplResponse, error) {
+func (s *AssetsAPIServiceImpl) GetAsset(ctx context.Context, path string, size string) (ImplResponse, error) {
 	// TODO - update GetAsset with the required logic for this service method.
 	// Add api_assets_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
 
@@ -53,6 +55,23 @@ func (s *AssetsAPIServiceImpl) GetAsset(ctx context.Context, path string) (ImplR
 	return Response(http.StatusNotImplemented, nil), errors.New("GetAsset method not implemented")
 }
 
+// ListAssets - list assets
+func (s *AssetsAPIServiceImpl) ListAssets(ctx context.Context, contentType string, date string, unreferenced bool, limit int32, offset int32) (ImplResponse, error) {
+	// TODO - update ListAssets with the required logic for this service method.
+	// Add api_assets_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(200, AssetsListResponse{}) or use other options such as http.Ok ...
+	// return Response(200, AssetsListResponse{}), nil
+
+	// TODO: Uncomment the next line to return response Response(400, {}) or use other options such as http.Ok ...
+	// return Response(400, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(401, {}) or use other options such as http.Ok ...
+	// return Response(401, nil),nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("ListAssets method not implemented")
+}
+
 // UploadAsset - upload an asset file
 func (s *AssetsAPIServiceImpl) UploadAsset(ctx context.Context, asset *os.File) (ImplResponse, error) {
 	// TODO - update UploadAsset with the required logic for this service method.
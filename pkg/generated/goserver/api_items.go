@@ -15,6 +15,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"strings"
+
+	"github.com/gorilla/mux"
 )
 
 // ItemsAPIController binds http requests to an api service and writes the service results to the http response
@@ -60,6 +62,26 @@ func (c *ItemsAPIController) Routes() Routes {
 			"/v1/items",
 			c.PutItems,
 		},
+		"DeleteItems": Route{
+			strings.ToUpper("Delete"),
+			"/v1/items",
+			c.DeleteItems,
+		},
+		"GetItemRevisions": Route{
+			strings.ToUpper("Get"),
+			"/v1/items/{date}/revisions",
+			c.GetItemRevisions,
+		},
+		"GetItemRevisionsDiff": Route{
+			strings.ToUpper("Get"),
+			"/v1/items/{date}/revisions/diff",
+			c.GetItemRevisionsDiff,
+		},
+		"RestoreItemRevision": Route{
+			strings.ToUpper("Post"),
+			"/v1/items/{date}/revisions/{id}/restore",
+			c.RestoreItemRevision,
+		},
 	}
 }
 
@@ -77,6 +99,20 @@ func (c *ItemsAPIController) GetItems(w http.ResponseWriter, r *http.Request) {
 		dateParam = param
 	} else {
 	}
+	var dateFromParam string
+	if query.Has("dateFrom") {
+		param := string(query.Get("dateFrom"))
+
+		dateFromParam = param
+	} else {
+	}
+	var dateToParam string
+	if query.Has("dateTo") {
+		param := string(query.Get("dateTo"))
+
+		dateToParam = param
+	} else {
+	}
 	var searchParam string
 	if query.Has("search") {
 		param := query.Get("search")
@@ -91,7 +127,70 @@ func (c *ItemsAPIController) GetItems(w http.ResponseWriter, r *http.Request) {
 		tagsParam = param
 	} else {
 	}
-	result, err := c.service.GetItems(r.Context(), dateParam, searchParam, tagsParam)
+	var tagsAllParam string
+	if query.Has("tagsAll") {
+		param := query.Get("tagsAll")
+
+		tagsAllParam = param
+	} else {
+	}
+	var tagsNoneParam string
+	if query.Has("tagsNone") {
+		param := query.Get("tagsNone")
+
+		tagsNoneParam = param
+	} else {
+	}
+	var limitParam int32
+	if query.Has("limit") {
+		param, err := parseNumericParameter[int32](
+			query.Get("limit"),
+			WithParse[int32](parseInt32),
+			WithMinimum[int32](1),
+			WithMaximum[int32](1000),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "limit", Err: err}, nil)
+			return
+		}
+
+		limitParam = param
+	} else {
+		var param int32 = 100
+		limitParam = param
+	}
+	var offsetParam int32
+	if query.Has("offset") {
+		param, err := parseNumericParameter[int32](
+			query.Get("offset"),
+			WithParse[int32](parseInt32),
+			WithMinimum[int32](0),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "offset", Err: err}, nil)
+			return
+		}
+
+		offsetParam = param
+	} else {
+		var param int32 = 0
+		offsetParam = param
+	}
+	var sortParam string
+	if query.Has("sort") {
+		param := query.Get("sort")
+
+		sortParam = param
+	} else {
+	}
+	var fieldsParam string
+	if query.Has("fields") {
+		param := query.Get("fields")
+
+		fieldsParam = param
+	} else {
+	}
+	result, err := c.service.GetItems(r.Context(), dateParam, dateFromParam, dateToParam, searchParam, tagsParam, tagsAllParam, tagsNoneParam, limitParam, offsetParam, sortParam, fieldsParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -118,7 +217,131 @@ func (c *ItemsAPIController) PutItems(w http.ResponseWriter, r *http.Request) {
 		c.errorHandler(w, r, err, nil)
 		return
 	}
-	result, err := c.service.PutItems(r.Context(), itemsRequestParam)
+	ifMatchParam := r.Header.Get("If-Match")
+	result, err := c.service.PutItems(r.Context(), itemsRequestParam, ifMatchParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// DeleteItems - delete diary item
+func (c *ItemsAPIController) DeleteItems(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var dateParam string
+	if query.Has("date") {
+		param := string(query.Get("date"))
+
+		dateParam = param
+	} else {
+		c.errorHandler(w, r, &RequiredError{Field: "date"}, nil)
+		return
+	}
+	result, err := c.service.DeleteItems(r.Context(), dateParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// GetItemRevisions - get revision history of diary item
+func (c *ItemsAPIController) GetItemRevisions(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	dateParam := params["date"]
+	if dateParam == "" {
+		c.errorHandler(w, r, &RequiredError{"date"}, nil)
+		return
+	}
+	result, err := c.service.GetItemRevisions(r.Context(), dateParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// GetItemRevisionsDiff - compare two revisions of diary item
+func (c *ItemsAPIController) GetItemRevisionsDiff(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	dateParam := params["date"]
+	if dateParam == "" {
+		c.errorHandler(w, r, &RequiredError{"date"}, nil)
+		return
+	}
+	var fromParam int64
+	if query.Has("from") {
+		param, err := parseNumericParameter[int64](
+			query.Get("from"),
+			WithParse[int64](parseInt64),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "from", Err: err}, nil)
+			return
+		}
+
+		fromParam = param
+	} else {
+		c.errorHandler(w, r, &RequiredError{Field: "from"}, nil)
+		return
+	}
+	var toParam int64
+	if query.Has("to") {
+		param, err := parseNumericParameter[int64](
+			query.Get("to"),
+			WithParse[int64](parseInt64),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "to", Err: err}, nil)
+			return
+		}
+
+		toParam = param
+	} else {
+	}
+	result, err := c.service.GetItemRevisionsDiff(r.Context(), dateParam, fromParam, toParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// RestoreItemRevision - restore diary item to a previous revision
+func (c *ItemsAPIController) RestoreItemRevision(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	dateParam := params["date"]
+	if dateParam == "" {
+		c.errorHandler(w, r, &RequiredError{"date"}, nil)
+		return
+	}
+	idParam, err := parseNumericParameter[int64](
+		params["id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Param: "id", Err: err}, nil)
+		return
+	}
+	result, err := c.service.RestoreItemRevision(r.Context(), dateParam, idParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
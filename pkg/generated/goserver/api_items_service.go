@@ -20,9 +20,17 @@ import (
 // ItemsAPIService is an interface that defines the logic for the ItemsAPIServicer
 type ItemsAPIService interface {
 	// GetItems - get diary items
-	GetItems(ctx context.Context, date string, search string, tags string) (ImplResponse, error)
+	GetItems(ctx context.Context, date string, dateFrom string, dateTo string, search string, tags string, tagsAll string, tagsNone string, limit int32, offset int32, sort string, fields string) (ImplResponse, error)
 	// PutItems - upsert diary item
-	PutItems(ctx context.Context, itemsRequest ItemsRequest) (ImplResponse, error)
+	PutItems(ctx context.Context, itemsRequest ItemsRequest, ifMatch string) (ImplResponse, error)
+	// DeleteItems - delete diary item
+	DeleteItems(ctx context.Context, date string) (ImplResponse, error)
+	// GetItemRevisions - get revision history of diary item
+	GetItemRevisions(ctx context.Context, date string) (ImplResponse, error)
+	// GetItemRevisionsDiff - compare two revisions of diary item
+	GetItemRevisionsDiff(ctx context.Context, date string, from int64, to int64) (ImplResponse, error)
+	// RestoreItemRevision - restore diary item to a previous revision
+	RestoreItemRevision(ctx context.Context, date string, id int64) (ImplResponse, error)
 }
 
 // ItemsAPIService is a service that implements the logic for the ItemsAPIServicer
@@ -37,7 +45,7 @@ func NewItemsAPIService() ItemsAPIService {
 }
 
 // GetItems - get diary items
-func (s *ItemsAPIServiceImpl) GetItems(ctx context.Context, date string, search string, tags string) (ImplResponse, error) {
+func (s *ItemsAPIServiceImpl) GetItems(ctx context.Context, date string, dateFrom string, dateTo string, search string, tags string, tagsAll string, tagsNone string, limit int32, offset int32, sort string, fields string) (ImplResponse, error) {
 	// TODO - update GetItems with the required logic for this service method.
 	// Add api_items_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
 
@@ -54,7 +62,7 @@ func (s *ItemsAPIServiceImpl) GetItems(ctx context.Context, date string, search
 }
 
 // PutItems - upsert diary item
-func (s *ItemsAPIServiceImpl) PutItems(ctx context.Context, itemsRequest ItemsRequest) (ImplResponse, error) {
+func (s *ItemsAPIServiceImpl) PutItems(ctx context.Context, itemsRequest ItemsRequest, ifMatch string) (ImplResponse, error) {
 	// TODO - update PutItems with the required logic for this service method.
 	// Add api_items_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
 
@@ -67,5 +75,85 @@ func (s *ItemsAPIServiceImpl) PutItems(ctx context.Context, itemsRequest ItemsRe
 	// TODO: Uncomment the next line to return response Response(401, {}) or use other options such as http.Ok ...
 	// return Response(401, nil),nil
 
+	// TODO: Uncomment the next line to return response Response(409, ItemsResponse{}) or use other options such as http.Ok ...
+	// return Response(409, ItemsResponse{}), nil
+
 	return Response(http.StatusNotImplemented, nil), errors.New("PutItems method not implemented")
 }
+
+// DeleteItems - delete diary item
+func (s *ItemsAPIServiceImpl) DeleteItems(ctx context.Context, date string) (ImplResponse, error) {
+	// TODO - update DeleteItems with the required logic for this service method.
+	// Add api_items_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(204, {}) or use other options such as http.Ok ...
+	// return Response(204, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(400, {}) or use other options such as http.Ok ...
+	// return Response(400, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(401, {}) or use other options such as http.Ok ...
+	// return Response(401, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(404, {}) or use other options such as http.Ok ...
+	// return Response(404, nil),nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("DeleteItems method not implemented")
+}
+
+// GetItemRevisions - get revision history of diary item
+func (s *ItemsAPIServiceImpl) GetItemRevisions(ctx context.Context, date string) (ImplResponse, error) {
+	// TODO - update GetItemRevisions with the required logic for this service method.
+	// Add api_items_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(200, []ItemRevisionResponse{}) or use other options such as http.Ok ...
+	// return Response(200, []ItemRevisionResponse{}), nil
+
+	// TODO: Uncomment the next line to return response Response(400, {}) or use other options such as http.Ok ...
+	// return Response(400, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(401, {}) or use other options such as http.Ok ...
+	// return Response(401, nil),nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("GetItemRevisions method not implemented")
+}
+
+// GetItemRevisionsDiff - compare two revisions of diary item
+func (s *ItemsAPIServiceImpl) GetItemRevisionsDiff(ctx context.Context, date string, from int64, to int64) (ImplResponse, error) {
+	// TODO - update GetItemRevisionsDiff with the required logic for this service method.
+	// Add api_items_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(200, ItemRevisionDiffResponse{}) or use other options such as http.Ok ...
+	// return Response(200, ItemRevisionDiffResponse{}), nil
+
+	// TODO: Uncomment the next line to return response Response(400, {}) or use other options such as http.Ok ...
+	// return Response(400, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(401, {}) or use other options such as http.Ok ...
+	// return Response(401, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(404, {}) or use other options such as http.Ok ...
+	// return Response(404, nil),nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("GetItemRevisionsDiff method not implemented")
+}
+
+// RestoreItemRevision - restore diary item to a previous revision
+func (s *ItemsAPIServiceImpl) RestoreItemRevision(ctx context.Context, date string, id int64) (ImplResponse, error) {
+	// TODO - update RestoreItemRevision with the required logic for this service method.
+	// Add api_items_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(200, ItemsResponse{}) or use other options such as http.Ok ...
+	// return Response(200, ItemsResponse{}), nil
+
+	// TODO: Uncomment the next line to return response Response(400, {}) or use other options such as http.Ok ...
+	// return Response(400, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(401, {}) or use other options such as http.Ok ...
+	// return Response(401, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(404, {}) or use other options such as http.Ok ...
+	// return Response(404, nil),nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("RestoreItemRevision method not implemented")
+}
@@ -12,6 +12,7 @@
 package goserver
 
 import (
+	"encoding/json"
 	"net/http"
 	"strings"
 )
@@ -54,6 +55,21 @@ func (c *SyncAPIController) Routes() Routes {
 			"/v1/sync/changes",
 			c.GetChanges,
 		},
+		"GetSnapshot": Route{
+			strings.ToUpper("Get"),
+			"/v1/sync/snapshot",
+			c.GetSnapshot,
+		},
+		"PushChanges": Route{
+			strings.ToUpper("Post"),
+			"/v1/sync/push",
+			c.PushChanges,
+		},
+		"StreamChanges": Route{
+			strings.ToUpper("Get"),
+			"/v1/sync/stream",
+			c.StreamChanges,
+		},
 	}
 }
 
@@ -64,12 +80,12 @@ func (c *SyncAPIController) GetChanges(w http.ResponseWriter, r *http.Request) {
 		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
 		return
 	}
-	var sinceParam int32
+	var sinceParam int64
 	if query.Has("since") {
-		param, err := parseNumericParameter[int32](
+		param, err := parseNumericParameter[int64](
 			query.Get("since"),
-			WithParse[int32](parseInt32),
-			WithMinimum[int32](0),
+			WithParse[int64](parseInt64),
+			WithMinimum[int64](0),
 		)
 		if err != nil {
 			c.errorHandler(w, r, &ParsingError{Param: "since", Err: err}, nil)
@@ -106,3 +122,75 @@ func (c *SyncAPIController) GetChanges(w http.ResponseWriter, r *http.Request) {
 	// If no error, encode the body and the result code
 	_ = EncodeJSONResponse(result.Body, &result.Code, w)
 }
+
+// GetSnapshot - get current state for synchronization
+func (c *SyncAPIController) GetSnapshot(w http.ResponseWriter, r *http.Request) {
+	result, err := c.service.GetSnapshot(r.Context())
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// PushChanges - push client changes for synchronization
+func (c *SyncAPIController) PushChanges(w http.ResponseWriter, r *http.Request) {
+	syncPushRequestParam := SyncPushRequest{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&syncPushRequestParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertSyncPushRequestRequired(syncPushRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertSyncPushRequestConstraints(syncPushRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.PushChanges(r.Context(), syncPushRequestParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// StreamChanges - stream changes for synchronization
+func (c *SyncAPIController) StreamChanges(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var sinceParam int64
+	if query.Has("since") {
+		param, err := parseNumericParameter[int64](
+			query.Get("since"),
+			WithParse[int64](parseInt64),
+			WithMinimum[int64](0),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "since", Err: err}, nil)
+			return
+		}
+
+		sinceParam = param
+	} else {
+	}
+	lastEventIDParam := r.Header.Get("Last-Event-ID")
+	result, err := c.service.StreamChanges(r.Context(), sinceParam, lastEventIDParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
@@ -20,7 +20,13 @@ import (
 // SyncAPIService is an interface that defines the logic for the SyncAPIServicer
 type SyncAPIService interface {
 	// GetChanges - get changes for synchronization
-	GetChanges(ctx context.Context, since int32, limit int32) (ImplResponse, error)
+	GetChanges(ctx context.Context, since int64, limit int32) (ImplResponse, error)
+	// GetSnapshot - get current state for synchronization
+	GetSnapshot(ctx context.Context) (ImplResponse, error)
+	// PushChanges - push client changes for synchronization
+	PushChanges(ctx context.Context, syncPushRequest SyncPushRequest) (ImplResponse, error)
+	// StreamChanges - stream changes for synchronization
+	StreamChanges(ctx context.Context, since int64, lastEventID string) (ImplResponse, error)
 }
 
 // SyncAPIService is a service that implements the logic for the SyncAPIServicer
@@ -35,7 +41,7 @@ func NewSyncAPIService() SyncAPIService {
 }
 
 // GetChanges - get changes for synchronization
-func (s *SyncAPIServiceImpl) GetChanges(ctx context.Context, since int32, limit int32) (ImplResponse, error) {
+func (s *SyncAPIServiceImpl) GetChanges(ctx context.Context, since int64, limit int32) (ImplResponse, error) {
 	// TODO - update GetChanges with the required logic for this service method.
 	// Add api_sync_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
 
@@ -48,5 +54,59 @@ func (s *SyncAPIServiceImpl) GetChanges(ctx context.Context, since int32, limit
 	// TODO: Uncomment the next line to return response Response(401, {}) or use other options such as http.Ok ...
 	// return Response(401, nil),nil
 
+	// TODO: Uncomment the next line to return response Response(410, SyncResyncRequiredResponse{}) or use other options such as http.Ok ...
+	// return Response(410, SyncResyncRequiredResponse{}), nil
+
 	return Response(http.StatusNotImplemented, nil), errors.New("GetChanges method not implemented")
 }
+
+// GetSnapshot - get current state for synchronization
+func (s *SyncAPIServiceImpl) GetSnapshot(ctx context.Context) (ImplResponse, error) {
+	// TODO - update GetSnapshot with the required logic for this service method.
+	// Add api_sync_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(200, SyncSnapshotResponse{}) or use other options such as http.Ok ...
+	// return Response(200, SyncSnapshotResponse{}), nil
+
+	// TODO: Uncomment the next line to return response Response(401, {}) or use other options such as http.Ok ...
+	// return Response(401, nil),nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("GetSnapshot method not implemented")
+}
+
+// PushChanges - push client changes for synchronization
+func (s *SyncAPIServiceImpl) PushChanges(ctx context.Context, syncPushRequest SyncPushRequest) (ImplResponse, error) {
+	// TODO - update PushChanges with the required logic for this service method.
+	// Add api_sync_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(200, SyncPushResponse{}) or use other options such as http.Ok ...
+	// return Response(200, SyncPushResponse{}), nil
+
+	// TODO: Uncomment the next line to return response Response(400, {}) or use other options such as http.Ok ...
+	// return Response(400, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(401, {}) or use other options such as http.Ok ...
+	// return Response(401, nil),nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("PushChanges method not implemented")
+}
+
+// StreamChanges - stream changes for synchronization
+func (s *SyncAPIServiceImpl) StreamChanges(ctx context.Context, since int64, lastEventID string) (ImplResponse, error) {
+	// TODO - update StreamChanges with the required logic for this service method.
+	// Add api_sync_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(200, string{}) or use other options such as http.Ok ...
+	// return Response(200, string{}), nil
+
+	// TODO: Uncomment the next line to return response Response(400, {}) or use other options such as http.Ok ...
+	// return Response(400, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(401, {}) or use other options such as http.Ok ...
+	// return Response(401, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(410, SyncResyncRequiredResponse{}) or use other options such as http.Ok ...
+	// return Response(410, SyncResyncRequiredResponse{}), nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("StreamChanges method not implemented")
+}
@@ -54,6 +54,11 @@ func (c *UserAPIController) Routes() Routes {
 			"/v1/user",
 			c.GetUser,
 		},
+		"GetUserUsage": Route{
+			strings.ToUpper("Get"),
+			"/v1/user/usage",
+			c.GetUserUsage,
+		},
 	}
 }
 
@@ -68,3 +73,15 @@ func (c *UserAPIController) GetUser(w http.ResponseWriter, r *http.Request) {
 	// If no error, encode the body and the result code
 	_ = EncodeJSONResponse(result.Body, &result.Code, w)
 }
+
+// GetUserUsage - return the storage used by the user and the remaining quota
+func (c *UserAPIController) GetUserUsage(w http.ResponseWriter, r *http.Request) {
+	result, err := c.service.GetUserUsage(r.Context())
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
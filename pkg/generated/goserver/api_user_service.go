@@ -21,6 +21,8 @@ import (
 type UserAPIService interface {
 	// GetUser - return user object
 	GetUser(ctx context.Context) (ImplResponse, error)
+	// GetUserUsage - return the storage used by the user and the remaining quota
+	GetUserUsage(ctx context.Context) (ImplResponse, error)
 }
 
 // UserAPIService is a service that implements the logic for the UserAPIServicer
@@ -44,3 +46,17 @@ func (s *UserAPIServiceImpl) GetUser(ctx context.Context) (ImplResponse, error)
 
 	return Response(http.StatusNotImplemented, nil), errors.New("GetUser method not implemented")
 }
+
+// GetUserUsage - return the storage used by the user and the remaining quota
+func (s *UserAPIServiceImpl) GetUserUsage(ctx context.Context) (ImplResponse, error) {
+	// TODO - update GetUserUsage with the required logic for this service method.
+	// Add api_user_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(200, UserUsage{}) or use other options such as http.Ok ...
+	// return Response(200, UserUsage{}), nil
+
+	// TODO: Uncomment the next line to return response Response(401, {}) or use other options such as http.Ok ...
+	// return Response(401, nil),nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("GetUserUsage method not implemented")
+}
@@ -30,6 +30,7 @@ type CustomControllers struct {
 	AuthAPIService   AuthAPIService
 	ItemsAPIService  ItemsAPIService
 	SyncAPIService   SyncAPIService
+	TagsAPIService   TagsAPIService
 	UserAPIService   UserAPIService
 }
 
@@ -65,13 +66,19 @@ func Serve(ctx context.Context, logger *slog.Logger, cfg *config.Config,
 	}
 	SyncAPIController := NewSyncAPIController(SyncAPIService)
 
+	TagsAPIService := NewTagsAPIService()
+	if controllers.TagsAPIService != nil {
+		TagsAPIService = controllers.TagsAPIService
+	}
+	TagsAPIController := NewTagsAPIController(TagsAPIService)
+
 	UserAPIService := NewUserAPIService()
 	if controllers.UserAPIService != nil {
 		UserAPIService = controllers.UserAPIService
 	}
 	UserAPIController := NewUserAPIController(UserAPIService)
 
-	routers := append(extraRouters, AssetsAPIController, AuthAPIController, ItemsAPIController, SyncAPIController, UserAPIController)
+	routers := append(extraRouters, AssetsAPIController, AuthAPIController, ItemsAPIController, SyncAPIController, TagsAPIController, UserAPIController)
 	router := NewRouter(routers...)
 
 	router.Use(middlewares...)
@@ -127,8 +134,9 @@ func createCORSMiddleware(allowedOrigins []string) func(http.Handler) http.Handl
 				// Set CORS headers for allowed origins
 				w.Header().Set("Access-Control-Allow-Origin", origin)
 				w.Header().Set("Access-Control-Allow-Credentials", "true")
-				w.Header().Set("Access-Control-Allow-Methods", "GET, HEAD, POST, PUT, DELETE, OPTIONS")
-				w.Header().Set("Access-Control-Allow-Headers", "X-Requested-With, Content-Type, Authorization")
+				w.Header().Set("Access-Control-Allow-Methods", "GET, HEAD, POST, PUT, PATCH, DELETE, OPTIONS")
+				w.Header().Set("Access-Control-Allow-Headers", "X-Requested-With, Content-Type, Authorization, Upload-Offset, Range, If-Range, If-None-Match, If-Modified-Since")
+				w.Header().Set("Access-Control-Expose-Headers", "Upload-Offset, ETag, Content-Range, Accept-Ranges")
 			}
 
 			// Handle preflight requests
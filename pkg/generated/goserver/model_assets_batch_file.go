@@ -11,6 +11,10 @@
 
 package goserver
 
+import (
+	"time"
+)
+
 type AssetsBatchFile struct {
 
 	// Original filename provided by the client
@@ -24,6 +28,12 @@ type AssetsBatchFile struct {
 
 	// MIME type detected for the file
 	ContentType string `json:"contentType,omitempty"`
+
+	// True if the content was already stored and savedName is the existing asset
+	Deduplicated bool `json:"deduplicated"`
+
+	// When the photo was taken according to its EXIF data
+	CapturedAt *time.Time `json:"capturedAt,omitempty"`
 }
 
 type AssetsBatchFileInterface interface {
@@ -31,6 +41,8 @@ type AssetsBatchFileInterface interface {
 	GetSavedName() string
 	GetSize() int64
 	GetContentType() string
+	GetDeduplicated() bool
+	GetCapturedAt() *time.Time
 }
 
 func (c *AssetsBatchFile) GetOriginalName() string {
@@ -45,6 +57,12 @@ func (c *AssetsBatchFile) GetSize() int64 {
 func (c *AssetsBatchFile) GetContentType() string {
 	return c.ContentType
 }
+func (c *AssetsBatchFile) GetDeduplicated() bool {
+	return c.Deduplicated
+}
+func (c *AssetsBatchFile) GetCapturedAt() *time.Time {
+	return c.CapturedAt
+}
 
 // AssertAssetsBatchFileRequired checks if the required fields are not zero-ed
 func AssertAssetsBatchFileRequired(obj AssetsBatchFile) error {
@@ -52,6 +70,7 @@ func AssertAssetsBatchFileRequired(obj AssetsBatchFile) error {
 		"originalName": obj.OriginalName,
 		"savedName":    obj.SavedName,
 		"size":         obj.Size,
+		"deduplicated": obj.Deduplicated,
 	}
 	for name, el := range elements {
 		if isZero := IsZeroValue(el); isZero {
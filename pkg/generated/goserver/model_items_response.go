@@ -23,6 +23,12 @@ type ItemsResponse struct {
 	PreviousDate *string `json:"previousDate,omitempty"`
 
 	NextDate *string `json:"nextDate,omitempty"`
+
+	// Excerpt of the body around search matches, HTML-escaped with matches wrapped in <mark> tags; only present when searching
+	Snippet string `json:"snippet,omitempty"`
+
+	// Version of the item, incremented on every save. Send it back in the If-Match header to detect conflicting edits
+	Version int64 `json:"version,omitempty"`
 }
 
 type ItemsResponseInterface interface {
@@ -32,6 +38,8 @@ type ItemsResponseInterface interface {
 	GetBody() string
 	GetPreviousDate() *string
 	GetNextDate() *string
+	GetSnippet() string
+	GetVersion() int64
 }
 
 func (c *ItemsResponse) GetDate() string {
@@ -52,6 +60,12 @@ func (c *ItemsResponse) GetPreviousDate() *string {
 func (c *ItemsResponse) GetNextDate() *string {
 	return c.NextDate
 }
+func (c *ItemsResponse) GetSnippet() string {
+	return c.Snippet
+}
+func (c *ItemsResponse) GetVersion() int64 {
+	return c.Version
+}
 
 // AssertItemsResponseRequired checks if the required fields are not zero-ed
 func AssertItemsResponseRequired(obj ItemsResponse) error {
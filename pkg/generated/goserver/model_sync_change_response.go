@@ -18,12 +18,12 @@ import (
 type SyncChangeResponse struct {
 
 	// Unique change ID
-	Id int32 `json:"id"`
+	Id int64 `json:"id"`
 
 	// User ID who made the change
 	UserId string `json:"userId"`
 
-	// Date of the diary entry that was changed
+	// Date of the diary entry that was changed, empty for asset changes
 	Date string `json:"date"`
 
 	// Type of operation performed
@@ -37,19 +37,27 @@ type SyncChangeResponse struct {
 
 	// Additional metadata about the change
 	Metadata []string `json:"metadata,omitempty"`
+
+	// Kind of the changed entity, item or asset
+	EntityType string `json:"entityType"`
+
+	// Changed asset, set for asset changes only
+	AssetSnapshot *SyncAssetSnapshot `json:"assetSnapshot,omitempty"`
 }
 
 type SyncChangeResponseInterface interface {
-	GetId() int32
+	GetId() int64
 	GetUserId() string
 	GetDate() string
 	GetOperationType() string
 	GetTimestamp() time.Time
 	GetItemSnapshot() *ItemsResponse
 	GetMetadata() []string
+	GetEntityType() string
+	GetAssetSnapshot() *SyncAssetSnapshot
 }
 
-func (c *SyncChangeResponse) GetId() int32 {
+func (c *SyncChangeResponse) GetId() int64 {
 	return c.Id
 }
 func (c *SyncChangeResponse) GetUserId() string {
@@ -70,6 +78,12 @@ func (c *SyncChangeResponse) GetItemSnapshot() *ItemsResponse {
 func (c *SyncChangeResponse) GetMetadata() []string {
 	return c.Metadata
 }
+func (c *SyncChangeResponse) GetEntityType() string {
+	return c.EntityType
+}
+func (c *SyncChangeResponse) GetAssetSnapshot() *SyncAssetSnapshot {
+	return c.AssetSnapshot
+}
 
 // AssertSyncChangeResponseRequired checks if the required fields are not zero-ed
 func AssertSyncChangeResponseRequired(obj SyncChangeResponse) error {
@@ -79,6 +93,7 @@ func AssertSyncChangeResponseRequired(obj SyncChangeResponse) error {
 		"date":          obj.Date,
 		"operationType": obj.OperationType,
 		"timestamp":     obj.Timestamp,
+		"entityType":    obj.EntityType,
 	}
 	for name, el := range elements {
 		if isZero := IsZeroValue(el); isZero {
@@ -91,6 +106,11 @@ func AssertSyncChangeResponseRequired(obj SyncChangeResponse) error {
 			return err
 		}
 	}
+	if obj.AssetSnapshot != nil {
+		if err := AssertSyncAssetSnapshotRequired(*obj.AssetSnapshot); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -101,5 +121,10 @@ func AssertSyncChangeResponseConstraints(obj SyncChangeResponse) error {
 			return err
 		}
 	}
+	if obj.AssetSnapshot != nil {
+		if err := AssertSyncAssetSnapshotConstraints(*obj.AssetSnapshot); err != nil {
+			return err
+		}
+	}
 	return nil
 }
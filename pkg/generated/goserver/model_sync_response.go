@@ -20,13 +20,13 @@ type SyncResponse struct {
 	HasMore bool `json:"hasMore"`
 
 	// ID to use for the next sync request (if hasMore is true)
-	NextId int32 `json:"nextId,omitempty"`
+	NextId int64 `json:"nextId,omitempty"`
 }
 
 type SyncResponseInterface interface {
 	GetChanges() []SyncChangeResponse
 	GetHasMore() bool
-	GetNextId() int32
+	GetNextId() int64
 }
 
 func (c *SyncResponse) GetChanges() []SyncChangeResponse {
@@ -35,7 +35,7 @@ func (c *SyncResponse) GetChanges() []SyncChangeResponse {
 func (c *SyncResponse) GetHasMore() bool {
 	return c.HasMore
 }
-func (c *SyncResponse) GetNextId() int32 {
+func (c *SyncResponse) GetNextId() int64 {
 	return c.NextId
 }
 
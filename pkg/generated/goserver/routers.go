@@ -19,6 +19,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -70,6 +71,18 @@ type PlainTextResponse struct {
 	Text string
 }
 
+// File is the content of a file response, like an *os.File
+type File interface {
+	io.ReadSeekCloser
+	Name() string
+}
+
+// FileResponse is a wrapper for file responses with a known content type, clients must not sniff it
+type FileResponse struct {
+	File        File
+	ContentType string
+}
+
 // EncodeJSONResponse uses the json encoder to write an interface to the http response with an optional status code
 func EncodeJSONResponse(i interface{}, status *int, w http.ResponseWriter) error {
 	wHeader := w.Header()
@@ -91,6 +104,22 @@ func EncodeJSONResponse(i interface{}, status *int, w http.ResponseWriter) error
 		return err
 	}
 
+	// Handle file responses
+	file, ok := i.(FileResponse)
+	if ok {
+		defer file.File.Close()
+		wHeader.Set("Content-Type", file.ContentType)
+		wHeader.Set("X-Content-Type-Options", "nosniff")
+		wHeader.Set("Content-Disposition", "attachment; filename="+filepath.Base(file.File.Name()))
+		if status != nil {
+			w.WriteHeader(*status)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+		_, err := io.Copy(w, file.File)
+		return err
+	}
+
 	// Handle plain text responses
 	plainText, ok := i.(PlainTextResponse)
 	if ok {
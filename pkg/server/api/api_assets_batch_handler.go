@@ -1,17 +1,19 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"mime/multipart"
 	"net/http"
-	"os"
-	"path/filepath"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/ya-breeze/diary.be/pkg/config"
+	"github.com/ya-breeze/diary.be/pkg/database"
+	"github.com/ya-breeze/diary.be/pkg/database/models"
 	"github.com/ya-breeze/diary.be/pkg/generated/goserver"
 	"github.com/ya-breeze/diary.be/pkg/server/assets"
 	"github.com/ya-breeze/diary.be/pkg/server/common"
@@ -27,15 +29,22 @@ type AssetsBatchFile struct {
 	SavedName    string `json:"savedName"`
 	Size         int64  `json:"size"`
 	ContentType  string `json:"contentType"`
+	Deduplicated bool   `json:"deduplicated"`
+	// CapturedAt is when the photo was taken according to its EXIF data
+	CapturedAt *time.Time `json:"capturedAt,omitempty"`
 }
 
 type AssetsBatchRouter struct {
 	logger *slog.Logger
 	cfg    *config.Config
+	db     database.Storage
+	blobs  assets.BlobStore
 }
 
-func NewAssetsBatchRouter(logger *slog.Logger, cfg *config.Config) *AssetsBatchRouter {
-	return &AssetsBatchRouter{logger: logger, cfg: cfg}
+func NewAssetsBatchRouter(
+	logger *slog.Logger, cfg *config.Config, db database.Storage, blobs assets.BlobStore,
+) *AssetsBatchRouter {
+	return &AssetsBatchRouter{logger: logger, cfg: cfg, db: db, blobs: blobs}
 }
 
 // Implement goserver.Router
@@ -62,12 +71,12 @@ func (r *AssetsBatchRouter) handleBatch(w http.ResponseWriter, req *http.Request
 
 	files := req.MultipartForm.File["assets"]
 	r.logger.Info("Batch upload request", "userID", userID, "files", len(files))
-	if code, err := r.prevalidate(files, limits); err != nil {
+	if code, err := r.prevalidate(req.Context(), userID, files, limits); err != nil {
 		writeJSONError(w, code, err.Error())
 		return
 	}
 
-	resp, code, err := r.saveAllFiles(userID, files, limits)
+	resp, code, err := r.saveAllFiles(req.Context(), userID, files, limits)
 	if err != nil {
 		writeJSONError(w, code, err.Error())
 		return
@@ -80,7 +89,9 @@ func (r *AssetsBatchRouter) handleBatch(w http.ResponseWriter, req *http.Request
 	}
 }
 
-func (r *AssetsBatchRouter) prevalidate(files []*multipart.FileHeader, limits assets.BatchLimits) (int, error) {
+func (r *AssetsBatchRouter) prevalidate(
+	ctx context.Context, userID string, files []*multipart.FileHeader, limits assets.BatchLimits,
+) (int, error) {
 	if len(files) == 0 {
 		return http.StatusBadRequest, errors.New("missing assets")
 	}
@@ -99,10 +110,18 @@ func (r *AssetsBatchRouter) prevalidate(files []*multipart.FileHeader, limits as
 	if limits.MaxBatchTotalBytes > 0 && totalSize > limits.MaxBatchTotalBytes {
 		return http.StatusRequestEntityTooLarge, errors.New("batch total size exceeded")
 	}
+	if err := assets.CheckQuota(ctx, r.cfg, r.db, r.blobs, userID, len(files), totalSize); err != nil {
+		if errors.Is(err, database.ErrQuotaExceeded) {
+			return http.StatusInsufficientStorage, err
+		}
+		r.logger.Error("Failed to check quota", "error", err, "userID", userID)
+		return http.StatusInternalServerError, errors.New("failed to check quota")
+	}
 	return 0, nil
 }
 
 func (r *AssetsBatchRouter) saveAllFiles(
+	ctx context.Context,
 	userID string,
 	files []*multipart.FileHeader,
 	limits assets.BatchLimits,
@@ -111,37 +130,55 @@ func (r *AssetsBatchRouter) saveAllFiles(
 	int,
 	error,
 ) {
-	userAssetPath := filepath.Join(r.cfg.AssetPath, userID)
 	created := make([]string, 0, len(files))
+	registered := make([]*models.Asset, 0, len(files))
 	resp := AssetsBatchResponse{Files: make([]AssetsBatchFile, 0, len(files))}
 
 	for _, fh := range files {
 		if limits.MaxPerFileBytes > 0 && fh.Size > limits.MaxPerFileBytes {
-			rollback(created)
+			r.rollback(ctx, created)
 			return AssetsBatchResponse{}, http.StatusRequestEntityTooLarge, errors.New("file too large")
 		}
 		src, err := fh.Open()
 		if err != nil {
-			rollback(created)
+			r.rollback(ctx, created)
 			return AssetsBatchResponse{}, http.StatusBadRequest, fmt.Errorf("failed to open part: %w", err)
 		}
-		name, path, err := func() (string, string, error) {
+		saved, err := func() (assets.SavedFile, error) {
 			defer src.Close()
-			return assets.SaveFileAtomically(userAssetPath, fh, src, "")
+			return assets.SaveFileAtomically(ctx, r.blobs, userID, fh, src, r.cfg.StripPhotoLocation)
 		}()
 		if err != nil {
-			rollback(created)
+			r.rollback(ctx, created)
+			if errors.Is(err, assets.ErrInvalidContent) {
+				return AssetsBatchResponse{}, http.StatusBadRequest, fmt.Errorf("%s: %w", fh.Filename, err)
+			}
 			return AssetsBatchResponse{}, http.StatusInternalServerError, fmt.Errorf("failed to save file: %w", err)
 		}
-		created = append(created, path)
+		// A deduplicated file was stored before and must survive a rollback
+		if !saved.Deduplicated {
+			created = append(created, saved.Key)
+		}
+		registered = append(registered, saved.Asset())
 		resp.Files = append(resp.Files, AssetsBatchFile{
 			OriginalName: fh.Filename,
-			SavedName:    name,
-			Size:         fh.Size,
-			ContentType:  contentType(fh),
+			SavedName:    saved.Name,
+			Size:         saved.Size,
+			ContentType:  saved.ContentType,
+			Deduplicated: saved.Deduplicated,
+			CapturedAt:   saved.CapturedAt,
 		})
 	}
 
+	// Register the new assets, which also makes them visible to synchronized clients
+	if err := r.db.CreateAssets(userID, registered); err != nil {
+		r.rollback(ctx, created)
+		if errors.Is(err, database.ErrQuotaExceeded) {
+			return AssetsBatchResponse{}, http.StatusInsufficientStorage, err
+		}
+		return AssetsBatchResponse{}, http.StatusInternalServerError, fmt.Errorf("failed to record assets: %w", err)
+	}
+
 	resp.Count = len(resp.Files)
 	return resp, http.StatusOK, nil
 }
@@ -152,17 +189,10 @@ func (r *AssetsBatchRouter) Use(router *mux.Router) {
 	}
 }
 
-func rollback(paths []string) {
-	for i := len(paths) - 1; i >= 0; i-- {
-		_ = os.Remove(paths[i])
-	}
-}
-
-func contentType(fh *multipart.FileHeader) string {
-	if ct := fh.Header.Get("Content-Type"); ct != "" {
-		return ct
+func (r *AssetsBatchRouter) rollback(ctx context.Context, keys []string) {
+	for i := len(keys) - 1; i >= 0; i-- {
+		_ = r.blobs.Delete(ctx, keys[i])
 	}
-	return "application/octet-stream"
 }
 
 // writeJSONError writes a minimal JSON error response {"error":"..."}
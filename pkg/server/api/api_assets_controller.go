@@ -0,0 +1,67 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/ya-breeze/diary.be/pkg/generated/goserver"
+	"github.com/ya-breeze/diary.be/pkg/server/assets"
+)
+
+// CustomAssetsAPIController replaces the generated GetAsset route. The generated encoder copies files
+// without access to the request, so it can't answer Range and conditional requests.
+type CustomAssetsAPIController struct {
+	service      goserver.AssetsAPIServicer
+	errorHandler goserver.ErrorHandler
+	logger       *slog.Logger
+}
+
+// NewCustomAssetsAPIController creates a custom assets controller serving files with assets.ServeFile
+func NewCustomAssetsAPIController(service goserver.AssetsAPIServicer, logger *slog.Logger) *CustomAssetsAPIController {
+	return &CustomAssetsAPIController{
+		service:      service,
+		errorHandler: goserver.DefaultErrorHandler,
+		logger:       logger,
+	}
+}
+
+// Routes returns all the api routes for the CustomAssetsAPIController
+func (c *CustomAssetsAPIController) Routes() goserver.Routes {
+	return goserver.Routes{
+		"GetAsset": goserver.Route{
+			Method:      strings.ToUpper("Get"),
+			Pattern:     "/v1/assets",
+			HandlerFunc: c.GetAsset,
+		},
+	}
+}
+
+// GetAsset - return asset by path
+func (c *CustomAssetsAPIController) GetAsset(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if !query.Has("path") {
+		c.errorHandler(w, r, &goserver.RequiredError{Field: "path"}, nil)
+		return
+	}
+	result, err := c.service.GetAsset(r.Context(), query.Get("path"), query.Get("size"))
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+
+	file, ok := result.Body.(goserver.FileResponse)
+	if !ok {
+		_ = goserver.EncodeJSONResponse(result.Body, &result.Code, w)
+		return
+	}
+	defer file.File.Close()
+	blob, ok := file.File.(*assets.Blob)
+	if !ok {
+		c.logger.Error("Asset isn't a blob", "name", file.File.Name())
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	assets.ServeFile(w, r, blob)
+}
@@ -2,33 +2,42 @@ package api
 
 import (
 	"context"
-	"io"
+	"errors"
 	"log/slog"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
-	"github.com/google/uuid"
 	"github.com/ya-breeze/diary.be/pkg/config"
+	"github.com/ya-breeze/diary.be/pkg/database"
+	"github.com/ya-breeze/diary.be/pkg/database/models"
 	"github.com/ya-breeze/diary.be/pkg/generated/goserver"
+	"github.com/ya-breeze/diary.be/pkg/server/assets"
 	"github.com/ya-breeze/diary.be/pkg/server/common"
 )
 
 type AssetsAPIServiceImpl struct {
 	logger *slog.Logger
 	cfg    *config.Config
+	db     database.Storage
+	blobs  assets.BlobStore
 }
 
-func NewAssetsAPIService(logger *slog.Logger, cfg *config.Config) goserver.AssetsAPIService {
+func NewAssetsAPIService(
+	logger *slog.Logger, cfg *config.Config, db database.Storage, blobs assets.BlobStore,
+) goserver.AssetsAPIService {
 	return &AssetsAPIServiceImpl{
 		logger: logger,
 		cfg:    cfg,
+		db:     db,
+		blobs:  blobs,
 	}
 }
 
-// GetAsset - return asset by path
-func (s *AssetsAPIServiceImpl) GetAsset(ctx context.Context, path string) (goserver.ImplResponse, error) {
+// GetAsset - return asset by path, images can be requested as a smaller variant
+func (s *AssetsAPIServiceImpl) GetAsset(ctx context.Context, path string, size string) (goserver.ImplResponse, error) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := ctx.Value(common.UserIDKey).(string)
 	if !ok {
@@ -36,112 +45,78 @@ func (s *AssetsAPIServiceImpl) GetAsset(ctx context.Context, path string) (goser
 		return goserver.Response(http.StatusUnauthorized, nil), nil
 	}
 
-	// Validate and clean the path
-	cleanPath, response := s.validateAndCleanPath(path, userID)
-	if response != nil {
-		return *response, nil
-	}
-
-	// Get the full asset path and validate it's within user directory
-	userAssetPath, response := s.validateAssetPath(cleanPath, userID)
-	if response != nil {
-		return *response, nil
-	}
-
-	s.logger.Info("Serving asset", "path", userAssetPath, "userID", userID)
-
-	// Validate file exists and is accessible
-	if response := s.validateFileAccess(userAssetPath, userID); response != nil {
-		return *response, nil
+	variantSize, err := assets.ParseSize(size)
+	if err != nil {
+		s.logger.Warn("Invalid asset size requested", "size", size, "userID", userID)
+		return goserver.Response(http.StatusBadRequest, nil), nil
 	}
 
-	// Open and return the file
-	file, err := os.Open(userAssetPath)
-	if err != nil {
-		s.logger.Error("Failed to open asset file", "error", err, "path", userAssetPath, "userID", userID)
+	blob, err := assets.OpenAsset(ctx, s.blobs, userID, path, variantSize, s.cfg.AssetVariantFormat)
+	switch {
+	case errors.Is(err, assets.ErrInvalidPath):
+		s.logger.Warn("Invalid asset path requested", "error", err, "path", path, "userID", userID)
+		return goserver.Response(http.StatusBadRequest, nil), nil
+	case errors.Is(err, assets.ErrAssetNotFound):
+		s.logger.Debug("Asset not found", "path", path, "userID", userID)
+		return goserver.Response(http.StatusNotFound, nil), nil
+	case err != nil:
+		s.logger.Error("Failed to open asset file", "error", err, "path", path, "size", size, "userID", userID)
 		return goserver.Response(http.StatusInternalServerError, nil), nil
 	}
+	s.logger.Info("Serving asset", "key", blob.Name(), "userID", userID)
 
-	// Return the file - the framework will handle closing it and setting appropriate headers
-	return goserver.Response(http.StatusOK, file), nil
+	// Return the blob - CustomAssetsAPIController serves it with assets.ServeFile and closes it, the
+	// type follows the extension which was detected from the content on upload
+	return goserver.Response(http.StatusOK, goserver.FileResponse{
+		File:        blob,
+		ContentType: assets.ContentTypeByName(blob.Name()),
+	}), nil
 }
 
-// validateAndCleanPath validates the path and returns a cleaned version
-func (s *AssetsAPIServiceImpl) validateAndCleanPath(path, userID string) (string, *goserver.ImplResponse) {
-	// Validate path to prevent directory traversal attacks
-	if strings.Contains(path, "..") {
-		s.logger.Warn("Invalid asset path requested (contains ..)", "path", path, "userID", userID)
-		response := goserver.Response(http.StatusBadRequest, nil)
-		return "", &response
-	}
-
-	// Clean the path to normalize separators and remove any redundant elements
-	cleanPath := filepath.Clean(path)
-
-	// Ensure the clean path doesn't start with / or \ (absolute path)
-	if filepath.IsAbs(cleanPath) {
-		s.logger.Warn("Invalid asset path requested (absolute path)", "path", path, "userID", userID)
-		response := goserver.Response(http.StatusBadRequest, nil)
-		return "", &response
+// ListAssets - list assets
+func (s *AssetsAPIServiceImpl) ListAssets(
+	ctx context.Context,
+	contentType string,
+	date string,
+	unreferenced bool,
+	limit int32,
+	offset int32,
+) (goserver.ImplResponse, error) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := ctx.Value(common.UserIDKey).(string)
+	if !ok {
+		s.logger.Error("Failed to get user ID from context")
+		return goserver.Response(http.StatusUnauthorized, nil), nil
 	}
 
-	return cleanPath, nil
-}
-
-// validateAssetPath constructs and validates the asset path is within user directory
-func (s *AssetsAPIServiceImpl) validateAssetPath(cleanPath, userID string) (string, *goserver.ImplResponse) {
-	// Construct the full path to the user's asset
-	userAssetBasePath := filepath.Join(s.cfg.AssetPath, userID)
-	userAssetPath := filepath.Join(userAssetBasePath, cleanPath)
-
-	// Ensure the resolved path is still within the user's asset directory
-	absBasePath, err := filepath.Abs(userAssetBasePath)
-	if err != nil {
-		s.logger.Error("Failed to get absolute base path", "error", err, "basePath", userAssetBasePath)
-		response := goserver.Response(http.StatusInternalServerError, nil)
-		return "", &response
+	if date != "" {
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			s.logger.Warn("Invalid date", "error", err, "userID", userID, "date", date)
+			return goserver.Response(http.StatusBadRequest, nil), nil
+		}
 	}
 
-	absAssetPath, err := filepath.Abs(userAssetPath)
+	assets, totalCount, err := s.db.GetAssets(userID, database.AssetFilter{
+		ContentType:  contentType,
+		Date:         date,
+		Unreferenced: unreferenced,
+		Limit:        int(limit),
+		Offset:       int(offset),
+	})
 	if err != nil {
-		s.logger.Error("Failed to get absolute asset path", "error", err, "assetPath", userAssetPath)
-		response := goserver.Response(http.StatusInternalServerError, nil)
-		return "", &response
-	}
-
-	// Check if the resolved path is within the user's directory
-	if !strings.HasPrefix(absAssetPath, absBasePath+string(filepath.Separator)) && absAssetPath != absBasePath {
-		s.logger.Warn("Asset path outside user directory", "path", cleanPath, "resolvedPath", absAssetPath, "userID", userID)
-		response := goserver.Response(http.StatusBadRequest, nil)
-		return "", &response
+		s.logger.Error("Failed to get assets", "error", err, "userID", userID)
+		return goserver.Response(http.StatusInternalServerError, nil), nil
 	}
 
-	return userAssetPath, nil
-}
-
-// validateFileAccess checks if the file exists and is accessible
-func (s *AssetsAPIServiceImpl) validateFileAccess(userAssetPath, userID string) *goserver.ImplResponse {
-	// Check if file exists and is accessible
-	fileInfo, err := os.Stat(userAssetPath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			s.logger.Debug("Asset not found", "path", userAssetPath, "userID", userID)
-			response := goserver.Response(http.StatusNotFound, nil)
-			return &response
-		}
-		s.logger.Error("Failed to stat asset file", "error", err, "path", userAssetPath, "userID", userID)
-		response := goserver.Response(http.StatusInternalServerError, nil)
-		return &response
+	response := goserver.AssetsListResponse{
+		Assets:     make([]goserver.AssetResponse, 0, len(assets)),
+		TotalCount: int32(totalCount),
 	}
-
-	// Ensure it's a file, not a directory
-	if fileInfo.IsDir() {
-		s.logger.Warn("Requested path is a directory", "path", userAssetPath, "userID", userID)
-		response := goserver.Response(http.StatusBadRequest, nil)
-		return &response
+	for _, asset := range assets {
+		response.Assets = append(response.Assets, asset.ToResponse())
 	}
 
-	return nil
+	return goserver.Response(http.StatusOK, response), nil
 }
 
 // UploadAsset - upload an asset file
@@ -160,37 +135,60 @@ func (s *AssetsAPIServiceImpl) UploadAsset(ctx context.Context, asset *os.File)
 	}
 	defer asset.Close()
 
-	// Create user asset directory if it doesn't exist
-	userAssetPath := filepath.Join(s.cfg.AssetPath, userID)
-	if err := os.MkdirAll(userAssetPath, 0o755); err != nil {
-		s.logger.Error("Failed to create user asset directory", "error", err, "path", userAssetPath, "userID", userID)
-		return goserver.Response(http.StatusInternalServerError, nil), nil
+	if response := s.checkQuota(ctx, asset, userID); response != nil {
+		return *response, nil
 	}
 
-	// Generate a unique filename for the uploaded asset
-	filename := uuid.New().String() + ".jpg"
-	filePath := filepath.Join(userAssetPath, filename)
-
-	s.logger.Info("Uploading asset", "filename", filename, "path", filePath, "userID", userID)
-
-	// Create the destination file
-	dst, err := os.Create(filePath)
+	// Save the uploaded file's data under the hash of its content, the extension follows the content
+	originalName := originalFileName(asset)
+	saved, err := assets.SaveReaderAtomically(ctx, s.blobs, userID, originalName, asset, s.cfg.StripPhotoLocation)
 	if err != nil {
-		s.logger.Error("Failed to create destination file", "error", err, "path", filePath, "userID", userID)
+		if errors.Is(err, assets.ErrInvalidContent) {
+			s.logger.Warn("Rejected asset upload", "error", err, "filename", originalName, "userID", userID)
+			return goserver.Response(http.StatusBadRequest, nil), nil
+		}
+		s.logger.Error("Failed to save asset", "error", err, "filename", originalName, "userID", userID)
 		return goserver.Response(http.StatusInternalServerError, nil), nil
 	}
-	defer dst.Close()
 
-	// Copy the uploaded file's data to the destination file
-	if _, err := io.Copy(dst, asset); err != nil {
-		s.logger.Error("Failed to copy asset data", "error", err, "userID", userID)
+	// Register the new asset, which also makes it visible to synchronized clients
+	saved.OriginalName = originalName
+	if err := s.db.CreateAssets(userID, []*models.Asset{saved.Asset()}); err != nil {
+		s.logger.Error("Failed to register asset", "error", err, "filename", saved.Name, "userID", userID)
+		if !saved.Deduplicated {
+			_ = s.blobs.Delete(ctx, saved.Key)
+		}
+		if errors.Is(err, database.ErrQuotaExceeded) {
+			return goserver.Response(http.StatusInsufficientStorage, nil), nil
+		}
 		return goserver.Response(http.StatusInternalServerError, nil), nil
 	}
 
-	s.logger.Info("Asset uploaded successfully", "filename", filename, "userID", userID)
+	s.logger.Info("Asset uploaded successfully", "filename", saved.Name, "deduplicated", saved.Deduplicated, "userID", userID)
 
 	// Return the filename as the response body using PlainTextResponse for text/plain content type
-	return goserver.Response(http.StatusOK, goserver.PlainTextResponse{Text: filename}), nil
+	return goserver.Response(http.StatusOK, goserver.PlainTextResponse{Text: saved.Name}), nil
+}
+
+// checkQuota checks the upload against the storage quota of the user
+func (s *AssetsAPIServiceImpl) checkQuota(ctx context.Context, asset *os.File, userID string) *goserver.ImplResponse {
+	info, err := asset.Stat()
+	if err != nil {
+		s.logger.Error("Failed to stat uploaded asset", "error", err, "userID", userID)
+		response := goserver.Response(http.StatusInternalServerError, nil)
+		return &response
+	}
+	if err := assets.CheckQuota(ctx, s.cfg, s.db, s.blobs, userID, 1, info.Size()); err != nil {
+		if errors.Is(err, database.ErrQuotaExceeded) {
+			s.logger.Warn("Rejected asset upload", "error", err, "userID", userID)
+			response := goserver.Response(http.StatusInsufficientStorage, nil)
+			return &response
+		}
+		s.logger.Error("Failed to check quota", "error", err, "userID", userID)
+		response := goserver.Response(http.StatusInternalServerError, nil)
+		return &response
+	}
+	return nil
 }
 
 // UploadAssetsBatch - not implemented here; manual router handles /v1/assets/batch.
@@ -200,3 +198,13 @@ func (s *AssetsAPIServiceImpl) UploadAssetsBatch(
 ) (goserver.ImplResponse, error) {
 	return goserver.Response(http.StatusNotImplemented, nil), nil
 }
+
+// originalFileName returns the name of the uploaded file. The generated controller stores uploads
+// in temporary files named after the original file with a random suffix.
+func originalFileName(f *os.File) string {
+	name := filepath.Base(f.Name())
+	if i := strings.LastIndex(name, "."); i > 0 {
+		return name[:i]
+	}
+	return name
+}
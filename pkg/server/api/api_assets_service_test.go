@@ -2,21 +2,33 @@ package api_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"image"
+	"image/png"
+	"io"
 	"log/slog"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/ya-breeze/diary.be/pkg/config"
+	"github.com/ya-breeze/diary.be/pkg/database"
+	"github.com/ya-breeze/diary.be/pkg/database/models"
 	"github.com/ya-breeze/diary.be/pkg/generated/goserver"
 	"github.com/ya-breeze/diary.be/pkg/server/api"
+	"github.com/ya-breeze/diary.be/pkg/server/assets"
 	"github.com/ya-breeze/diary.be/pkg/server/common"
 )
 
+// jpegMagic starts uploaded test content, so it is detected as a JPEG
+const jpegMagic = "\xff\xd8\xff\xe0"
+
 // Helper function to create context with user ID for assets tests
 func createContextWithUserIDForAssets(userID string) context.Context {
 	ctx := context.Background()
@@ -28,6 +40,7 @@ var _ = Describe("AssetsAPIService", func() {
 		service  *api.AssetsAPIServiceImpl
 		logger   *slog.Logger
 		cfg      *config.Config
+		storage  database.Storage
 		tempDir  string
 		userID   string
 		testFile string
@@ -47,7 +60,10 @@ var _ = Describe("AssetsAPIService", func() {
 
 		cfg = &config.Config{
 			AssetPath: tempDir,
+			DBPath:    ":memory:",
 		}
+		storage = database.NewStorage(logger, cfg)
+		Expect(storage.Open()).To(Succeed())
 
 		// Create user directory and test file
 		userDir := filepath.Join(tempDir, userID)
@@ -58,7 +74,7 @@ var _ = Describe("AssetsAPIService", func() {
 		err = os.WriteFile(testFile, []byte("fake image content"), 0o600)
 		Expect(err).NotTo(HaveOccurred())
 
-		serviceInterface := api.NewAssetsAPIService(logger, cfg)
+		serviceInterface := api.NewAssetsAPIService(logger, cfg, storage, assets.NewLocalStore(tempDir))
 		var ok bool
 		service, ok = serviceInterface.(*api.AssetsAPIServiceImpl)
 		Expect(ok).To(BeTrue(), "Failed to cast service to AssetsAPIServiceImpl")
@@ -72,7 +88,7 @@ var _ = Describe("AssetsAPIService", func() {
 		Context("when user ID is missing from context", func() {
 			It("should return unauthorized", func() {
 				emptyCtx := context.Background()
-				response, err := service.GetAsset(emptyCtx, "test-image.jpg")
+				response, err := service.GetAsset(emptyCtx, "test-image.jpg", "")
 
 				Expect(err).NotTo(HaveOccurred())
 				Expect(response.Code).To(Equal(http.StatusUnauthorized))
@@ -81,14 +97,14 @@ var _ = Describe("AssetsAPIService", func() {
 
 		Context("when path contains directory traversal", func() {
 			It("should return bad request for .. in path", func() {
-				response, err := service.GetAsset(ctx, "../secret.txt")
+				response, err := service.GetAsset(ctx, "../secret.txt", "")
 
 				Expect(err).NotTo(HaveOccurred())
 				Expect(response.Code).To(Equal(http.StatusBadRequest))
 			})
 
 			It("should return bad request for absolute path", func() {
-				response, err := service.GetAsset(ctx, "/etc/passwd")
+				response, err := service.GetAsset(ctx, "/etc/passwd", "")
 
 				Expect(err).NotTo(HaveOccurred())
 				Expect(response.Code).To(Equal(http.StatusBadRequest))
@@ -108,18 +124,19 @@ var _ = Describe("AssetsAPIService", func() {
 			})
 
 			It("should allow access to files in subdirectories", func() {
-				response, err := service.GetAsset(ctx, "images/photo.jpg")
+				response, err := service.GetAsset(ctx, "images/photo.jpg", "")
 
 				Expect(err).NotTo(HaveOccurred())
 				Expect(response.Code).To(Equal(http.StatusOK))
-				Expect(response.Body).To(BeAssignableToTypeOf(&os.File{}))
+				Expect(response.Body).To(BeAssignableToTypeOf(goserver.FileResponse{}))
 
 				// Verify we can read from the file
-				file, ok := response.Body.(*os.File)
-				Expect(ok).To(BeTrue(), "Failed to cast response body to *os.File")
+				fileResponse, ok := response.Body.(goserver.FileResponse)
+				Expect(ok).To(BeTrue(), "Failed to cast response body to FileResponse")
+				file := fileResponse.File
 				defer file.Close()
 
-				content, err := os.ReadFile(file.Name())
+				content, err := io.ReadAll(file)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(string(content)).To(Equal("photo content"))
 			})
@@ -134,7 +151,7 @@ var _ = Describe("AssetsAPIService", func() {
 				err = os.WriteFile(deepFile, []byte("report content"), 0o600)
 				Expect(err).NotTo(HaveOccurred())
 
-				response, err := service.GetAsset(ctx, "docs/2023/reports/report.pdf")
+				response, err := service.GetAsset(ctx, "docs/2023/reports/report.pdf", "")
 
 				Expect(err).NotTo(HaveOccurred())
 				Expect(response.Code).To(Equal(http.StatusOK))
@@ -143,7 +160,7 @@ var _ = Describe("AssetsAPIService", func() {
 
 		Context("when file does not exist", func() {
 			It("should return not found", func() {
-				response, err := service.GetAsset(ctx, "nonexistent.jpg")
+				response, err := service.GetAsset(ctx, "nonexistent.jpg", "")
 
 				Expect(err).NotTo(HaveOccurred())
 				Expect(response.Code).To(Equal(http.StatusNotFound))
@@ -152,18 +169,20 @@ var _ = Describe("AssetsAPIService", func() {
 
 		Context("when file exists", func() {
 			It("should return the file successfully", func() {
-				response, err := service.GetAsset(ctx, "test-image.jpg")
+				response, err := service.GetAsset(ctx, "test-image.jpg", "")
 
 				Expect(err).NotTo(HaveOccurred())
 				Expect(response.Code).To(Equal(http.StatusOK))
-				Expect(response.Body).To(BeAssignableToTypeOf(&os.File{}))
+				Expect(response.Body).To(BeAssignableToTypeOf(goserver.FileResponse{}))
 
 				// Verify we can read from the file
-				file, ok := response.Body.(*os.File)
-				Expect(ok).To(BeTrue(), "Failed to cast response body to *os.File")
+				fileResponse, ok := response.Body.(goserver.FileResponse)
+				Expect(ok).To(BeTrue(), "Failed to cast response body to FileResponse")
+				file := fileResponse.File
 				defer file.Close()
+				Expect(fileResponse.ContentType).To(Equal("image/jpeg"))
 
-				content, err := os.ReadFile(file.Name())
+				content, err := io.ReadAll(file)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(string(content)).To(Equal("fake image content"))
 			})
@@ -176,13 +195,84 @@ var _ = Describe("AssetsAPIService", func() {
 				err := os.MkdirAll(subDir, 0o755)
 				Expect(err).NotTo(HaveOccurred())
 
-				response, err := service.GetAsset(ctx, "emptydir")
+				response, err := service.GetAsset(ctx, "emptydir", "")
 
 				Expect(err).NotTo(HaveOccurred())
 				Expect(response.Code).To(Equal(http.StatusBadRequest))
 			})
 		})
 
+		Context("when an image variant is requested", func() {
+			writePNG := func(name string, width, height int) {
+				f, err := os.Create(filepath.Join(tempDir, userID, name))
+				Expect(err).NotTo(HaveOccurred())
+				defer f.Close()
+				Expect(png.Encode(f, image.NewRGBA(image.Rect(0, 0, width, height)))).To(Succeed())
+			}
+
+			decodeBody := func(response goserver.ImplResponse) image.Config {
+				fileResponse, ok := response.Body.(goserver.FileResponse)
+				Expect(ok).To(BeTrue())
+				file := fileResponse.File
+				defer file.Close()
+				imgCfg, _, err := image.DecodeConfig(file)
+				Expect(err).NotTo(HaveOccurred())
+				return imgCfg
+			}
+
+			It("should scale the image and cache the variant", func() {
+				writePNG("photo.png", 2000, 1000)
+
+				response, err := service.GetAsset(ctx, "photo.png", "thumb")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Code).To(Equal(http.StatusOK))
+				imgCfg := decodeBody(response)
+				Expect(imgCfg.Width).To(Equal(320))
+				Expect(imgCfg.Height).To(Equal(160))
+				Expect(filepath.Join(tempDir, userID, ".variants", "thumb", "photo.png")).To(BeARegularFile())
+
+				response, err = service.GetAsset(ctx, "photo.png", "medium")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decodeBody(response).Width).To(Equal(1280))
+			})
+
+			It("should return small images and other files unchanged", func() {
+				writePNG("small.png", 100, 100)
+
+				response, err := service.GetAsset(ctx, "small.png", "thumb")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decodeBody(response).Width).To(Equal(100))
+
+				response, err = service.GetAsset(ctx, "test-image.jpg", "thumb")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Code).To(Equal(http.StatusOK))
+				fileResponse, ok := response.Body.(goserver.FileResponse)
+				Expect(ok).To(BeTrue())
+				file := fileResponse.File
+				defer file.Close()
+				Expect(file.Name()).To(Equal(userID + "/test-image.jpg"))
+			})
+
+			It("should encode variants as WebP if configured", func() {
+				cfg.AssetVariantFormat = "webp"
+				writePNG("photo.png", 800, 400)
+
+				response, err := service.GetAsset(ctx, "photo.png", "thumb")
+				Expect(err).NotTo(HaveOccurred())
+				fileResponse, ok := response.Body.(goserver.FileResponse)
+				Expect(ok).To(BeTrue())
+				file := fileResponse.File
+				defer file.Close()
+				Expect(file.Name()).To(Equal(userID + "/.variants/thumb/photo.png.webp"))
+			})
+
+			It("should reject an unknown size", func() {
+				response, err := service.GetAsset(ctx, "test-image.jpg", "huge")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
 		Describe("UploadAsset", func() {
 			Context("when user ID is missing from context", func() {
 				It("should return unauthorized", func() {
@@ -215,6 +305,37 @@ var _ = Describe("AssetsAPIService", func() {
 				})
 			})
 
+			Context("when the content is not allowed", func() {
+				upload := func(pattern, content string) goserver.ImplResponse {
+					tempFile, err := os.CreateTemp("", pattern)
+					Expect(err).NotTo(HaveOccurred())
+					defer os.Remove(tempFile.Name())
+					defer tempFile.Close()
+					_, err = tempFile.WriteString(content)
+					Expect(err).NotTo(HaveOccurred())
+					_, err = tempFile.Seek(0, 0)
+					Expect(err).NotTo(HaveOccurred())
+
+					response, err := service.UploadAsset(ctx, tempFile)
+					Expect(err).NotTo(HaveOccurred())
+					return response
+				}
+
+				It("should reject content of a type that isn't allowed", func() {
+					response := upload("page.jpg.*", "<html><script>alert(1)</script></html>")
+					Expect(response.Code).To(Equal(http.StatusBadRequest))
+				})
+
+				It("should reject content that doesn't match the extension", func() {
+					response := upload("photo.png.*", jpegMagic+"photo")
+					Expect(response.Code).To(Equal(http.StatusBadRequest))
+
+					registered, _, err := storage.GetAssets(userID, database.AssetFilter{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(registered).To(BeEmpty())
+				})
+			})
+
 			Context("when uploading a valid file", func() {
 				It("should save the file and return the filename", func() {
 					// Create a temporary file to upload
@@ -223,7 +344,7 @@ var _ = Describe("AssetsAPIService", func() {
 					defer os.Remove(tempFile.Name())
 					defer tempFile.Close()
 
-					testContent := "test image content for upload"
+					testContent := jpegMagic + "test image content for upload"
 					_, err = tempFile.WriteString(testContent)
 					Expect(err).NotTo(HaveOccurred())
 					_, err = tempFile.Seek(0, 0) // Reset file pointer to beginning
@@ -238,8 +359,8 @@ var _ = Describe("AssetsAPIService", func() {
 					plainTextResponse, ok := response.Body.(goserver.PlainTextResponse)
 					Expect(ok).To(BeTrue(), "Response body should be a PlainTextResponse")
 					filename := plainTextResponse.Text
-					Expect(filename).To(HaveSuffix(".jpg"))
-					Expect(strings.Contains(filename, "-")).To(BeTrue(), "Filename should contain UUID format")
+					hash := sha256.Sum256([]byte(testContent))
+					Expect(filename).To(Equal(hex.EncodeToString(hash[:])+".jpg"), "Filename should be the content hash")
 
 					// Verify the file was actually saved
 					savedFilePath := filepath.Join(tempDir, userID, filename)
@@ -249,6 +370,114 @@ var _ = Describe("AssetsAPIService", func() {
 					savedContent, err := os.ReadFile(savedFilePath)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(string(savedContent)).To(Equal(testContent))
+
+					// Verify the upload was recorded for synchronization
+					changes, err := storage.GetChangesSince(userID, 0, 10)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(changes).To(HaveLen(1))
+					Expect(changes[0].EntityType).To(Equal(models.EntityTypeAsset))
+					Expect(changes[0].OperationType).To(Equal(models.OperationTypeCreated))
+					Expect(*changes[0].AssetSnapshot).To(Equal(models.AssetInfo{
+						Name:        filename,
+						Size:        int64(len(testContent)),
+						ContentType: "image/jpeg",
+						Hash:        hex.EncodeToString(hash[:]),
+					}))
+
+					// Verify the asset was registered
+					registered, _, err := storage.GetAssets(userID, database.AssetFilter{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(registered).To(HaveLen(1))
+					Expect(registered[0].Name).To(Equal(filename))
+					Expect(registered[0].OriginalName).To(HavePrefix("upload_test_"))
+				})
+
+				It("should return the existing asset for identical content", func() {
+					upload := func(pattern string) string {
+						tempFile, err := os.CreateTemp("", pattern)
+						Expect(err).NotTo(HaveOccurred())
+						defer os.Remove(tempFile.Name())
+						defer tempFile.Close()
+						_, err = tempFile.WriteString(jpegMagic + "same photo")
+						Expect(err).NotTo(HaveOccurred())
+						_, err = tempFile.Seek(0, 0)
+						Expect(err).NotTo(HaveOccurred())
+
+						response, err := service.UploadAsset(ctx, tempFile)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(response.Code).To(Equal(http.StatusOK))
+						plainTextResponse, ok := response.Body.(goserver.PlainTextResponse)
+						Expect(ok).To(BeTrue())
+						return plainTextResponse.Text
+					}
+
+					first := upload("phone_*.jpg")
+					second := upload("tablet_*.jpg")
+					Expect(second).To(Equal(first))
+
+					entries, err := os.ReadDir(filepath.Join(tempDir, userID))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(entries).To(HaveLen(2), "only the test image and one copy of the upload are stored")
+
+					registered, _, err := storage.GetAssets(userID, database.AssetFilter{ContentType: "image/"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(registered).To(HaveLen(1))
+					Expect(registered[0].ToResponse().RefCount).To(BeZero())
+
+					// Only the first upload is a change for synchronized clients
+					changes, err := storage.GetChangesSince(userID, 0, 10)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(changes).To(HaveLen(1))
+
+					// The asset counts the items referencing it
+					Expect(storage.PutItem(userID, &models.Item{Date: "2024-01-01", Body: "![](" + first + ")"})).To(Succeed())
+					Expect(storage.PutItem(userID, &models.Item{Date: "2024-01-02", Body: "![](" + first + "?size=thumb)"})).To(Succeed())
+					registered, _, err = storage.GetAssets(userID, database.AssetFilter{ContentType: "image/"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(registered[0].ToResponse().RefCount).To(Equal(int64(2)))
+				})
+
+				It("should name the file after its detected type", func() {
+					tempFile, err := os.CreateTemp("", "chart.*")
+					Expect(err).NotTo(HaveOccurred())
+					defer os.Remove(tempFile.Name())
+					defer tempFile.Close()
+					Expect(png.Encode(tempFile, image.NewRGBA(image.Rect(0, 0, 1, 1)))).To(Succeed())
+					_, err = tempFile.Seek(0, 0)
+					Expect(err).NotTo(HaveOccurred())
+
+					response, err := service.UploadAsset(ctx, tempFile)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(response.Code).To(Equal(http.StatusOK))
+					plainTextResponse, ok := response.Body.(goserver.PlainTextResponse)
+					Expect(ok).To(BeTrue())
+					Expect(plainTextResponse.Text).To(HaveSuffix(".png"))
+
+					registered, _, err := storage.GetAssets(userID, database.AssetFilter{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(registered).To(HaveLen(1))
+					Expect(registered[0].ContentType).To(Equal("image/png"))
+				})
+
+				It("should register the original name of the uploaded file", func() {
+					// The generated controller names temporary files after the original file
+					tempFile, err := os.CreateTemp("", "holiday.jpg.*")
+					Expect(err).NotTo(HaveOccurred())
+					defer os.Remove(tempFile.Name())
+					defer tempFile.Close()
+					_, err = tempFile.WriteString(jpegMagic + "holiday")
+					Expect(err).NotTo(HaveOccurred())
+					_, err = tempFile.Seek(0, 0)
+					Expect(err).NotTo(HaveOccurred())
+
+					response, err := service.UploadAsset(ctx, tempFile)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(response.Code).To(Equal(http.StatusOK))
+
+					registered, _, err := storage.GetAssets(userID, database.AssetFilter{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(registered).To(HaveLen(1))
+					Expect(registered[0].OriginalName).To(Equal("holiday.jpg"))
 				})
 			})
 
@@ -264,7 +493,7 @@ var _ = Describe("AssetsAPIService", func() {
 					defer os.Remove(tempFile.Name())
 					defer tempFile.Close()
 
-					testContent := "test content for new user"
+					testContent := jpegMagic + "test content for new user"
 					_, err = tempFile.WriteString(testContent)
 					Expect(err).NotTo(HaveOccurred())
 					_, err = tempFile.Seek(0, 0) // Reset file pointer to beginning
@@ -292,4 +521,109 @@ var _ = Describe("AssetsAPIService", func() {
 			})
 		})
 	})
+
+	Describe("ListAssets", func() {
+		BeforeEach(func() {
+			Expect(storage.CreateAssets(userID, []*models.Asset{
+				{
+					Name: "a.jpg", OriginalName: "beach.jpg", Size: 1, ContentType: "image/jpeg", Hash: "a",
+					UploadedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					Name: "b.png", OriginalName: "chart.png", Size: 2, ContentType: "image/png", Hash: "b",
+					UploadedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					Name: "c.mp4", OriginalName: "party.mp4", Size: 3, ContentType: "video/mp4", Hash: "c",
+					UploadedAt: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+				},
+			})).To(Succeed())
+			Expect(storage.PutItem(userID, &models.Item{
+				Date: "2024-01-15", Title: "Trip", Body: "![](a.jpg)\n![](c.mp4)\n![](https://example.com/x.jpg)",
+			})).To(Succeed())
+			Expect(storage.PutItem(userID, &models.Item{Date: "2024-01-16", Title: "Back", Body: "![](a.jpg)"})).To(Succeed())
+		})
+
+		list := func(contentType, date string, unreferenced bool, limit, offset int32) goserver.AssetsListResponse {
+			response, err := service.ListAssets(ctx, contentType, date, unreferenced, limit, offset)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Code).To(Equal(http.StatusOK))
+			result, ok := response.Body.(goserver.AssetsListResponse)
+			Expect(ok).To(BeTrue())
+			return result
+		}
+
+		names := func(result goserver.AssetsListResponse) []string {
+			res := make([]string, 0, len(result.Assets))
+			for _, asset := range result.Assets {
+				res = append(res, asset.Name)
+			}
+			return res
+		}
+
+		It("should return unauthorized without user ID", func() {
+			response, err := service.ListAssets(context.Background(), "", "", false, 0, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should list all assets newest first with the referencing dates", func() {
+			result := list("", "", false, 0, 0)
+			Expect(result.TotalCount).To(Equal(int32(3)))
+			Expect(names(result)).To(Equal([]string{"c.mp4", "b.png", "a.jpg"}))
+			Expect(result.Assets[2].OriginalName).To(Equal("beach.jpg"))
+			Expect(result.Assets[2].Dates).To(Equal([]string{"2024-01-15", "2024-01-16"}))
+			Expect(result.Assets[1].Dates).To(BeEmpty())
+		})
+
+		It("should filter by content type prefix", func() {
+			Expect(names(list("image/", "", false, 0, 0))).To(Equal([]string{"b.png", "a.jpg"}))
+			Expect(names(list("video/mp4", "", false, 0, 0))).To(Equal([]string{"c.mp4"}))
+		})
+
+		It("should filter by referencing item", func() {
+			Expect(names(list("", "2024-01-15", false, 0, 0))).To(Equal([]string{"c.mp4", "a.jpg"}))
+		})
+
+		It("should follow item changes", func() {
+			Expect(storage.PutItem(userID, &models.Item{Date: "2024-01-15", Title: "Trip", Body: "![](b.png)"})).To(Succeed())
+			Expect(storage.DeleteItem(userID, "2024-01-16")).To(Succeed())
+
+			Expect(names(list("", "", true, 0, 0))).To(Equal([]string{"c.mp4", "a.jpg"}))
+			Expect(names(list("", "2024-01-15", false, 0, 0))).To(Equal([]string{"b.png"}))
+		})
+
+		It("should return unreferenced assets", func() {
+			Expect(names(list("", "", true, 0, 0))).To(Equal([]string{"b.png"}))
+		})
+
+		It("should paginate", func() {
+			result := list("", "", false, 1, 1)
+			Expect(result.TotalCount).To(Equal(int32(3)))
+			Expect(names(result)).To(Equal([]string{"b.png"}))
+		})
+
+		It("should register existing asset files on startup", func() {
+			// Variants and unfinished uploads aren't assets
+			blobs := assets.NewLocalStore(tempDir)
+			Expect(blobs.Put(ctx, userID+"/.variants/thumb/test-image.jpg", strings.NewReader("thumb"), 5)).To(Succeed())
+			Expect(blobs.Put(ctx, userID+"/.uploads/upload.json", strings.NewReader("{}"), 2)).To(Succeed())
+
+			restored := database.NewStorage(logger, cfg)
+			Expect(restored.Open()).To(Succeed())
+			Expect(assets.BackfillAssets(ctx, logger, restored, blobs)).To(Succeed())
+			registered, _, err := restored.GetAssets(userID, database.AssetFilter{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(registered).To(HaveLen(1))
+			Expect(registered[0].Name).To(Equal("test-image.jpg"))
+			Expect(registered[0].ContentType).To(Equal("image/jpeg"))
+			Expect(registered[0].Size).To(Equal(int64(len("fake image content"))))
+		})
+
+		It("should reject an invalid date", func() {
+			response, err := service.ListAssets(ctx, "", "15.01.2024", false, 0, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
 })
@@ -0,0 +1,257 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ya-breeze/diary.be/pkg/config"
+	"github.com/ya-breeze/diary.be/pkg/database"
+	"github.com/ya-breeze/diary.be/pkg/database/models"
+	"github.com/ya-breeze/diary.be/pkg/generated/goserver"
+	"github.com/ya-breeze/diary.be/pkg/server/assets"
+	"github.com/ya-breeze/diary.be/pkg/server/common"
+)
+
+// uploadOffsetHeader carries the number of bytes of an upload the server has received
+const uploadOffsetHeader = "Upload-Offset"
+
+type AssetsUploadRequest struct {
+	Filename string `json:"filename"`
+	Size     int64  `json:"size"`
+}
+
+type AssetsUploadCompleteRequest struct {
+	// SHA256 is the hex-encoded SHA-256 of the complete file
+	SHA256 string `json:"sha256"`
+}
+
+// AssetsUploadsRouter implements resumable uploads: a client creates an upload, appends the content
+// in chunks with PATCH requests and completes it with the checksum of the file. After an interrupted
+// request the client reads the offset of the upload and continues from there.
+type AssetsUploadsRouter struct {
+	logger  *slog.Logger
+	cfg     *config.Config
+	db      database.Storage
+	blobs   assets.BlobStore
+	uploads *assets.Uploads
+}
+
+func NewAssetsUploadsRouter(
+	logger *slog.Logger, cfg *config.Config, db database.Storage, blobs assets.BlobStore,
+) *AssetsUploadsRouter {
+	return &AssetsUploadsRouter{logger: logger, cfg: cfg, db: db, blobs: blobs, uploads: assets.NewUploads(blobs)}
+}
+
+// Implement goserver.Router
+func (r *AssetsUploadsRouter) Routes() goserver.Routes {
+	return goserver.Routes{
+		"createAssetUpload": {
+			Method: http.MethodPost, Pattern: "/v1/assets/uploads", HandlerFunc: r.handleCreate,
+		},
+		"getAssetUpload": {
+			Method: http.MethodGet, Pattern: "/v1/assets/uploads/{id}", HandlerFunc: r.handleGet,
+		},
+		"appendAssetUpload": {
+			Method: http.MethodPatch, Pattern: "/v1/assets/uploads/{id}", HandlerFunc: r.handleAppend,
+		},
+		"completeAssetUpload": {
+			Method: http.MethodPost, Pattern: "/v1/assets/uploads/{id}/complete", HandlerFunc: r.handleComplete,
+		},
+		"abortAssetUpload": {
+			Method: http.MethodDelete, Pattern: "/v1/assets/uploads/{id}", HandlerFunc: r.handleAbort,
+		},
+	}
+}
+
+func (r *AssetsUploadsRouter) handleCreate(w http.ResponseWriter, req *http.Request) {
+	userID, _ := req.Context().Value(common.UserIDKey).(string)
+	if userID == "" {
+		writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var body AssetsUploadRequest
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if err := assets.ValidateExtension(body.Filename); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if body.Size <= 0 {
+		writeJSONError(w, http.StatusBadRequest, "size must be positive")
+		return
+	}
+	if limits := assets.ComputeBatchLimits(r.cfg); limits.MaxPerFileBytes > 0 && body.Size > limits.MaxPerFileBytes {
+		writeJSONError(w, http.StatusRequestEntityTooLarge, "file too large")
+		return
+	}
+	if !r.checkQuota(w, req, userID, 1, body.Size) {
+		return
+	}
+
+	upload, err := r.uploads.Create(req.Context(), userID, body.Filename, body.Size)
+	if err != nil {
+		r.logger.Error("Failed to create upload", "error", err, "userID", userID)
+		writeJSONError(w, http.StatusInternalServerError, "failed to create upload")
+		return
+	}
+	r.logger.Info("Upload created", "id", upload.ID, "filename", upload.Filename, "size", upload.Size, "userID", userID)
+	writeUpload(w, http.StatusCreated, upload)
+}
+
+func (r *AssetsUploadsRouter) handleGet(w http.ResponseWriter, req *http.Request) {
+	userID, _ := req.Context().Value(common.UserIDKey).(string)
+	if userID == "" {
+		writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	upload, err := r.uploads.Get(req.Context(), userID, mux.Vars(req)["id"])
+	if err != nil {
+		r.writeUploadError(w, userID, err)
+		return
+	}
+	writeUpload(w, http.StatusOK, upload)
+}
+
+func (r *AssetsUploadsRouter) handleAppend(w http.ResponseWriter, req *http.Request) {
+	userID, _ := req.Context().Value(common.UserIDKey).(string)
+	if userID == "" {
+		writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	offset, err := strconv.ParseInt(req.Header.Get(uploadOffsetHeader), 10, 64)
+	if err != nil || offset < 0 {
+		writeJSONError(w, http.StatusBadRequest, "invalid "+uploadOffsetHeader+" header")
+		return
+	}
+
+	upload, err := r.uploads.Append(req.Context(), userID, mux.Vars(req)["id"], offset, req.Body)
+	if err != nil {
+		if upload != nil {
+			w.Header().Set(uploadOffsetHeader, strconv.FormatInt(upload.Offset, 10))
+		}
+		r.writeUploadError(w, userID, err)
+		return
+	}
+	writeUpload(w, http.StatusOK, upload)
+}
+
+func (r *AssetsUploadsRouter) handleComplete(w http.ResponseWriter, req *http.Request) {
+	userID, _ := req.Context().Value(common.UserIDKey).(string)
+	if userID == "" {
+		writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var body AssetsUploadCompleteRequest
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil || body.SHA256 == "" {
+		writeJSONError(w, http.StatusBadRequest, "sha256 is required")
+		return
+	}
+
+	// The upload is already counted as in progress, it only exceeds the quota if the quota was lowered
+	// since it was created. It is kept, so the client can retry after freeing space.
+	if _, err := r.uploads.Get(req.Context(), userID, mux.Vars(req)["id"]); err != nil {
+		r.writeUploadError(w, userID, err)
+		return
+	}
+	if !r.checkQuota(w, req, userID, 0, 0) {
+		return
+	}
+
+	saved, err := r.uploads.Complete(req.Context(), userID, mux.Vars(req)["id"], body.SHA256, r.cfg.StripPhotoLocation)
+	if err != nil {
+		r.writeUploadError(w, userID, err)
+		return
+	}
+
+	// Register the new asset, which also makes it visible to synchronized clients
+	if err := r.db.CreateAssets(userID, []*models.Asset{saved.Asset()}); err != nil {
+		r.logger.Error("Failed to register asset", "error", err, "filename", saved.Name, "userID", userID)
+		if !saved.Deduplicated {
+			_ = r.blobs.Delete(req.Context(), saved.Key)
+		}
+		if errors.Is(err, database.ErrQuotaExceeded) {
+			writeJSONError(w, http.StatusInsufficientStorage, err.Error())
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "failed to record asset")
+		return
+	}
+	r.logger.Info("Upload completed", "filename", saved.Name, "deduplicated", saved.Deduplicated, "userID", userID)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(AssetsBatchFile{
+		OriginalName: saved.OriginalName,
+		SavedName:    saved.Name,
+		Size:         saved.Size,
+		ContentType:  saved.ContentType,
+		Deduplicated: saved.Deduplicated,
+		CapturedAt:   saved.CapturedAt,
+	}); err != nil {
+		r.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+func (r *AssetsUploadsRouter) handleAbort(w http.ResponseWriter, req *http.Request) {
+	userID, _ := req.Context().Value(common.UserIDKey).(string)
+	if userID == "" {
+		writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if err := r.uploads.Abort(req.Context(), userID, mux.Vars(req)["id"]); err != nil {
+		r.writeUploadError(w, userID, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// checkQuota checks uploads of the given total size against the storage quota of the user and writes
+// the error response if it's exceeded
+func (r *AssetsUploadsRouter) checkQuota(
+	w http.ResponseWriter, req *http.Request, userID string, files int, size int64,
+) bool {
+	err := assets.CheckQuota(req.Context(), r.cfg, r.db, r.blobs, userID, files, size)
+	switch {
+	case errors.Is(err, database.ErrQuotaExceeded):
+		writeJSONError(w, http.StatusInsufficientStorage, err.Error())
+	case err != nil:
+		r.logger.Error("Failed to check quota", "error", err, "userID", userID)
+		writeJSONError(w, http.StatusInternalServerError, "failed to check quota")
+	}
+	return err == nil
+}
+
+// writeUploadError maps errors of the upload store to status codes
+func (r *AssetsUploadsRouter) writeUploadError(w http.ResponseWriter, userID string, err error) {
+	switch {
+	case errors.Is(err, assets.ErrUploadNotFound):
+		writeJSONError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, assets.ErrUploadOffset), errors.Is(err, assets.ErrUploadIncomplete):
+		writeJSONError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, assets.ErrUploadTooLarge):
+		writeJSONError(w, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, assets.ErrChecksumMismatch), errors.Is(err, assets.ErrInvalidContent):
+		writeJSONError(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		r.logger.Error("Upload failed", "error", err, "userID", userID)
+		writeJSONError(w, http.StatusInternalServerError, "upload failed")
+	}
+}
+
+func writeUpload(w http.ResponseWriter, code int, upload *assets.Upload) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(uploadOffsetHeader, strconv.FormatInt(upload.Offset, 10))
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(upload)
+}
@@ -2,8 +2,13 @@ package api
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ya-breeze/diary.be/pkg/database"
 	"github.com/ya-breeze/diary.be/pkg/database/models"
@@ -23,12 +28,121 @@ func NewItemsAPIService(logger *slog.Logger, db database.Storage) goserver.Items
 	}
 }
 
+// defaultItemsLimit is the page size of GET /v1/items if the client doesn't set a limit
+const defaultItemsLimit = 100
+
+// optionalItemFields lists the ItemsResponse fields that can be selected with the "fields" parameter.
+// The date is always returned.
+var optionalItemFields = []string{"title", "body", "tags", "snippet", "previousDate", "nextDate"}
+
+// itemFields is the set of ItemsResponse fields requested by the client
+type itemFields map[string]bool
+
+// parseItemFields parses the comma-separated "fields" parameter, all fields are selected if it's empty
+func parseItemFields(fields string) (itemFields, error) {
+	res := itemFields{}
+	if strings.TrimSpace(fields) == "" {
+		for _, field := range optionalItemFields {
+			res[field] = true
+		}
+		return res, nil
+	}
+
+	for field := range strings.SplitSeq(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || field == "date" {
+			continue
+		}
+		if !slices.Contains(optionalItemFields, field) {
+			return nil, fmt.Errorf("unknown field %q", field)
+		}
+		res[field] = true
+	}
+
+	return res, nil
+}
+
+// splitTags parses a comma-separated list of tags, trimming whitespace and skipping empty values
+func splitTags(tags string) []string {
+	var res []string
+	for tag := range strings.SplitSeq(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			res = append(res, tag)
+		}
+	}
+	return res
+}
+
+// filterTags trims spaces of the tags and skips empty values
+func filterTags(tags []string) models.StringList {
+	res := make(models.StringList, 0, len(tags))
+	for _, t := range tags {
+		if t = strings.TrimSpace(t); t != "" {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// validateDateRange checks that the optional range bounds are valid dates and are in order
+func validateDateRange(dateFrom, dateTo string) error {
+	var from, to time.Time
+	var err error
+	if dateFrom != "" {
+		if from, err = time.Parse(time.DateOnly, dateFrom); err != nil {
+			return fmt.Errorf("invalid dateFrom: %w", err)
+		}
+	}
+	if dateTo != "" {
+		if to, err = time.Parse(time.DateOnly, dateTo); err != nil {
+			return fmt.Errorf("invalid dateTo: %w", err)
+		}
+	}
+	if dateFrom != "" && dateTo != "" && to.Before(from) {
+		return errors.New("dateTo is before dateFrom")
+	}
+
+	return nil
+}
+
+// parseItemVersion parses the If-Match header, the version may be quoted like an ETag
+func parseItemVersion(ifMatch string) (int64, error) {
+	value := strings.TrimPrefix(strings.TrimSpace(ifMatch), "W/")
+	value = strings.Trim(value, `"`)
+	version, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("invalid item version %q", ifMatch)
+	}
+	return version, nil
+}
+
+// toItemsResponse converts the item to the API response format, without navigation dates
+func toItemsResponse(item *models.Item) goserver.ItemsResponse {
+	return goserver.ItemsResponse{
+		Date:    item.Date,
+		Title:   item.Title,
+		Body:    item.Body,
+		Tags:    []string(item.Tags),
+		Version: item.Version,
+	}
+}
+
 // GetItems - get diary items
+//
+//nolint:funlen // parameter validation and response conversion are kept together
 func (s *ItemsAPIServiceImpl) GetItems(
 	ctx context.Context,
 	date string,
+	dateFrom string,
+	dateTo string,
 	search string,
 	tags string,
+	tagsAll string,
+	tagsNone string,
+	limit int32,
+	offset int32,
+	sort string,
+	fields string,
 ) (goserver.ImplResponse, error) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := ctx.Value(common.UserIDKey).(string)
@@ -37,41 +151,85 @@ func (s *ItemsAPIServiceImpl) GetItems(
 		return goserver.Response(401, nil), nil
 	}
 
-	s.logger.Info("Getting items", "userID", userID, "date", date, "search", search, "tags", tags)
+	s.logger.Info("Getting items", "userID", userID, "date", date, "dateFrom", dateFrom, "dateTo", dateTo,
+		"search", search, "tags", tags, "tagsAll", tagsAll, "tagsNone", tagsNone, "limit", limit, "offset", offset, "sort", sort, "fields", fields)
+
+	if err := validateDateRange(dateFrom, dateTo); err != nil {
+		s.logger.Warn("Invalid date range", "error", err, "userID", userID, "dateFrom", dateFrom, "dateTo", dateTo)
+		return goserver.Response(400, nil), nil
+	}
+
+	sortOrder := database.SortOrder(sort)
+	switch sortOrder {
+	case "", database.SortRelevance, database.SortDateDesc, database.SortDateAsc:
+	default:
+		s.logger.Warn("Invalid sort order", "userID", userID, "sort", sort)
+		return goserver.Response(400, nil), nil
+	}
+
+	selectedFields, err := parseItemFields(fields)
+	if err != nil {
+		s.logger.Warn("Invalid fields parameter", "error", err, "userID", userID, "fields", fields)
+		return goserver.Response(400, nil), nil
+	}
+
+	if limit <= 0 {
+		limit = defaultItemsLimit
+	}
 
 	// Parse search parameters
 	searchParams := database.SearchParams{
 		Date:       date,
+		DateFrom:   dateFrom,
+		DateTo:     dateTo,
 		SearchText: search,
-	}
-
-	// Parse tags parameter (comma-separated)
-	if tags != "" {
-		searchParams.Tags = strings.Split(tags, ",")
-		// Trim whitespace from each tag
-		for i, tag := range searchParams.Tags {
-			searchParams.Tags[i] = strings.TrimSpace(tag)
-		}
+		Sort:       sortOrder,
+		Limit:      int(limit),
+		Offset:     int(offset),
+		Tags: database.TagFilter{
+			AnyOf:  splitTags(tags),
+			AllOf:  splitTags(tagsAll),
+			NoneOf: splitTags(tagsNone),
+		},
 	}
 
 	// Get items using the new search method
 	items, totalCount, err := s.db.GetItems(userID, searchParams)
+	if errors.Is(err, database.ErrInvalidSearchQuery) {
+		s.logger.Warn("Invalid search query", "error", err, "userID", userID, "search", search)
+		return goserver.Response(400, nil), nil
+	}
 	if err != nil {
 		s.logger.Error("Failed to get items", "error", err, "userID", userID, "searchParams", searchParams)
 		return goserver.Response(500, nil), nil
 	}
 
+	// Terms are only needed to highlight matches in snippets
+	withSnippets := search != "" && selectedFields["snippet"]
+	var searchTerms []database.SearchTerm
+	if withSnippets {
+		searchTerms = database.ParseSearchTerms(search)
+	}
+
 	// Convert database items to API response items
 	responseItems := make([]goserver.ItemsResponse, len(items))
 	for i, item := range items {
-		responseItems[i] = goserver.ItemsResponse{
-			Date:  item.Date,
-			Title: item.Title,
-			Body:  item.Body,
-			Tags:  []string(item.Tags),
+		responseItems[i] = goserver.ItemsResponse{Date: item.Date, Version: item.Version}
+		if selectedFields["title"] {
+			responseItems[i].Title = item.Title
+		}
+		if selectedFields["body"] {
+			responseItems[i].Body = item.Body
+		}
+		if selectedFields["tags"] {
+			responseItems[i].Tags = []string(item.Tags)
+		}
+		if withSnippets {
+			responseItems[i].Snippet = BuildItemSnippet(item.Title, item.Body, searchTerms)
 		}
-		// Add navigation dates for each item
-		s.addNavigationDates(&responseItems[i], userID, item.Date)
+	}
+	if selectedFields["previousDate"] || selectedFields["nextDate"] {
+		s.addPageNavigationDates(responseItems, userID, selectedFields)
 	}
 
 	// Create the list response
@@ -83,10 +241,12 @@ func (s *ItemsAPIServiceImpl) GetItems(
 	return goserver.Response(200, response), nil
 }
 
-// PutItems - upsert diary item
+// PutItems - upsert diary item. With If-Match the item is saved only if it wasn't changed
+// since that version, otherwise 409 with the current server copy is returned.
 func (s *ItemsAPIServiceImpl) PutItems(
 	ctx context.Context,
 	itemsRequest goserver.ItemsRequest,
+	ifMatch string,
 ) (goserver.ImplResponse, error) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := ctx.Value(common.UserIDKey).(string)
@@ -95,16 +255,16 @@ func (s *ItemsAPIServiceImpl) PutItems(
 		return goserver.Response(401, nil), nil
 	}
 
-	s.logger.Info("Saving item", "userID", userID, "date", itemsRequest.Date)
+	s.logger.Info("Saving item", "userID", userID, "date", itemsRequest.Date, "ifMatch", ifMatch)
 
-	// Filter tags: trim spaces and skip empty values
-	filteredTags := make([]string, 0, len(itemsRequest.Tags))
-	for _, t := range itemsRequest.Tags {
-		t = strings.TrimSpace(t)
-		if t == "" {
-			continue
+	var expectedVersion *int64
+	if ifMatch != "" {
+		version, err := parseItemVersion(ifMatch)
+		if err != nil {
+			s.logger.Warn("Invalid If-Match header", "error", err, "userID", userID)
+			return goserver.Response(400, nil), nil
 		}
-		filteredTags = append(filteredTags, t)
+		expectedVersion = &version
 	}
 
 	// Convert request to database model
@@ -113,35 +273,254 @@ func (s *ItemsAPIServiceImpl) PutItems(
 		Date:   itemsRequest.Date,
 		Title:  itemsRequest.Title,
 		Body:   itemsRequest.Body,
-		Tags:   models.StringList(filteredTags),
+		Tags:   filterTags(itemsRequest.Tags),
 	}
 
 	// Save the item to database
-	if err := s.db.PutItem(userID, item); err != nil {
+	var err error
+	if expectedVersion != nil {
+		err = s.db.PutItemIfVersion(userID, item, *expectedVersion)
+	} else {
+		err = s.db.PutItem(userID, item)
+	}
+	if errors.Is(err, database.ErrVersionConflict) {
+		s.logger.Warn("Item version conflict", "userID", userID, "date", item.Date, "ifMatch", ifMatch)
+		return s.conflictResponse(userID, item.Date)
+	}
+	if errors.Is(err, database.ErrQuotaExceeded) {
+		s.logger.Warn("Item quota exceeded", "error", err, "userID", userID, "date", item.Date)
+		return goserver.Response(507, nil), nil
+	}
+	if err != nil {
 		s.logger.Error("Failed to save item", "error", err, "item", item)
 		return goserver.Response(500, nil), nil
 	}
 
 	// Return the saved item as response
-	response := goserver.ItemsResponse{
-		Date:  item.Date,
-		Title: item.Title,
-		Body:  item.Body,
-		Tags:  []string(item.Tags),
-	}
+	response := toItemsResponse(item)
 
 	// Add navigation dates
-	s.addNavigationDates(&response, userID, item.Date)
+	s.addNavigationDates(&response, userID, item.Date, nil)
 
 	return goserver.Response(200, response), nil
 }
 
-// addNavigationDates adds previous and next dates to the response
-func (s *ItemsAPIServiceImpl) addNavigationDates(response *goserver.ItemsResponse, userID, date string) {
-	if previousDate, err := s.db.GetPreviousDate(userID, date); err == nil {
-		response.PreviousDate = &previousDate
+// conflictResponse returns 409 with the current server copy of the item, or without a body
+// if the item was deleted
+func (s *ItemsAPIServiceImpl) conflictResponse(userID, date string) (goserver.ImplResponse, error) {
+	current, err := s.db.GetItem(userID, date)
+	if errors.Is(err, database.ErrNotFound) {
+		return goserver.Response(409, nil), nil
+	}
+	if err != nil {
+		s.logger.Error("Failed to get item", "error", err, "userID", userID, "date", date)
+		return goserver.Response(500, nil), nil
+	}
+
+	response := toItemsResponse(current)
+	s.addNavigationDates(&response, userID, date, nil)
+
+	return goserver.Response(409, response), nil
+}
+
+// DeleteItems - delete diary item
+func (s *ItemsAPIServiceImpl) DeleteItems(ctx context.Context, date string) (goserver.ImplResponse, error) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := ctx.Value(common.UserIDKey).(string)
+	if !ok {
+		s.logger.Error("User ID not found in context")
+		return goserver.Response(401, nil), nil
 	}
-	if nextDate, err := s.db.GetNextDate(userID, date); err == nil {
-		response.NextDate = &nextDate
+
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		s.logger.Warn("Invalid date", "error", err, "userID", userID, "date", date)
+		return goserver.Response(400, nil), nil
+	}
+
+	s.logger.Info("Deleting item", "userID", userID, "date", date)
+
+	if err := s.db.DeleteItem(userID, date); err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return goserver.Response(404, nil), nil
+		}
+		s.logger.Error("Failed to delete item", "error", err, "userID", userID, "date", date)
+		return goserver.Response(500, nil), nil
+	}
+
+	return goserver.Response(204, nil), nil
+}
+
+// GetItemRevisions - get revision history of diary item
+func (s *ItemsAPIServiceImpl) GetItemRevisions(ctx context.Context, date string) (goserver.ImplResponse, error) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := ctx.Value(common.UserIDKey).(string)
+	if !ok {
+		s.logger.Error("User ID not found in context")
+		return goserver.Response(401, nil), nil
+	}
+
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		s.logger.Warn("Invalid date", "error", err, "userID", userID, "date", date)
+		return goserver.Response(400, nil), nil
+	}
+
+	revisions, err := s.db.GetItemRevisions(userID, date)
+	if err != nil {
+		s.logger.Error("Failed to get item revisions", "error", err, "userID", userID, "date", date)
+		return goserver.Response(500, nil), nil
+	}
+
+	response := make([]goserver.ItemRevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		response[i] = revision.ToRevisionResponse()
+	}
+
+	return goserver.Response(200, response), nil
+}
+
+// GetItemRevisionsDiff - compare two revisions of diary item
+func (s *ItemsAPIServiceImpl) GetItemRevisionsDiff(
+	ctx context.Context, date string, from int64, to int64,
+) (goserver.ImplResponse, error) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := ctx.Value(common.UserIDKey).(string)
+	if !ok {
+		s.logger.Error("User ID not found in context")
+		return goserver.Response(401, nil), nil
+	}
+
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		s.logger.Warn("Invalid date", "error", err, "userID", userID, "date", date)
+		return goserver.Response(400, nil), nil
+	}
+	if from <= 0 || to < 0 {
+		s.logger.Warn("Invalid revision IDs", "userID", userID, "date", date, "from", from, "to", to)
+		return goserver.Response(400, nil), nil
+	}
+
+	fromRevision, err := s.db.GetItemRevision(userID, date, uint(from))
+	if err != nil {
+		return s.revisionErrorResponse(err, userID, date, from)
+	}
+
+	var toRevision *models.ItemRevision
+	if to == 0 {
+		// Compare with the latest revision by default
+		revisions, err := s.db.GetItemRevisions(userID, date)
+		if err != nil {
+			s.logger.Error("Failed to get item revisions", "error", err, "userID", userID, "date", date)
+			return goserver.Response(500, nil), nil
+		}
+		if len(revisions) == 0 {
+			return goserver.Response(404, nil), nil
+		}
+		toRevision = revisions[0]
+	} else if toRevision, err = s.db.GetItemRevision(userID, date, uint(to)); err != nil {
+		return s.revisionErrorResponse(err, userID, date, to)
+	}
+
+	response := goserver.ItemRevisionDiffResponse{
+		FromId: int64(fromRevision.ID), //nolint:gosec // revision IDs fit into int64
+		ToId:   int64(toRevision.ID),   //nolint:gosec // revision IDs fit into int64
+		Title:  DiffLines(fromRevision.Title, toRevision.Title),
+		Body:   DiffLines(fromRevision.Body, toRevision.Body),
+	}
+	response.TagsAdded, response.TagsRemoved = DiffTags(fromRevision.Tags, toRevision.Tags)
+
+	return goserver.Response(200, response), nil
+}
+
+// RestoreItemRevision - restore diary item to a previous revision
+func (s *ItemsAPIServiceImpl) RestoreItemRevision(
+	ctx context.Context, date string, id int64,
+) (goserver.ImplResponse, error) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := ctx.Value(common.UserIDKey).(string)
+	if !ok {
+		s.logger.Error("User ID not found in context")
+		return goserver.Response(401, nil), nil
+	}
+
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		s.logger.Warn("Invalid date", "error", err, "userID", userID, "date", date)
+		return goserver.Response(400, nil), nil
+	}
+	if id <= 0 {
+		s.logger.Warn("Invalid revision ID", "userID", userID, "date", date, "id", id)
+		return goserver.Response(400, nil), nil
+	}
+
+	s.logger.Info("Restoring item revision", "userID", userID, "date", date, "id", id)
+
+	revision, err := s.db.GetItemRevision(userID, date, uint(id))
+	if err != nil {
+		return s.revisionErrorResponse(err, userID, date, id)
+	}
+
+	// The restored version is saved as a new revision, so the restore itself can be undone
+	item := revision.ToItem()
+	if err := s.db.PutItem(userID, item); err != nil {
+		if errors.Is(err, database.ErrQuotaExceeded) {
+			s.logger.Warn("Item quota exceeded", "error", err, "userID", userID, "date", date)
+			return goserver.Response(507, nil), nil
+		}
+		s.logger.Error("Failed to save item", "error", err, "item", item)
+		return goserver.Response(500, nil), nil
+	}
+
+	response := toItemsResponse(item)
+	s.addNavigationDates(&response, userID, item.Date, nil)
+
+	return goserver.Response(200, response), nil
+}
+
+// revisionErrorResponse converts an error of loading a revision to the API response
+func (s *ItemsAPIServiceImpl) revisionErrorResponse(
+	err error, userID, date string, id int64,
+) (goserver.ImplResponse, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return goserver.Response(404, nil), nil
+	}
+	s.logger.Error("Failed to get item revision", "error", err, "userID", userID, "date", date, "id", id)
+	return goserver.Response(500, nil), nil
+}
+
+// addPageNavigationDates sets the selected navigation dates of all items of a page at once
+func (s *ItemsAPIServiceImpl) addPageNavigationDates(items []goserver.ItemsResponse, userID string, fields itemFields) {
+	dates := make([]string, len(items))
+	for i, item := range items {
+		dates[i] = item.Date
+	}
+	navigation, err := s.db.GetNavigationDates(userID, dates)
+	if err != nil {
+		s.logger.Error("Failed to get navigation dates", "error", err, "userID", userID)
+		return
+	}
+
+	for i := range items {
+		nav := navigation[items[i].Date]
+		if fields["previousDate"] && nav.Previous != "" {
+			items[i].PreviousDate = &nav.Previous
+		}
+		if fields["nextDate"] && nav.Next != "" {
+			items[i].NextDate = &nav.Next
+		}
+	}
+}
+
+// addNavigationDates adds previous and next dates to the response.
+// Only the dates present in fields are added, nil fields means both.
+func (s *ItemsAPIServiceImpl) addNavigationDates(
+	response *goserver.ItemsResponse, userID, date string, fields itemFields,
+) {
+	if fields == nil || fields["previousDate"] {
+		if previousDate, err := s.db.GetPreviousDate(userID, date); err == nil {
+			response.PreviousDate = &previousDate
+		}
+	}
+	if fields == nil || fields["nextDate"] {
+		if nextDate, err := s.db.GetNextDate(userID, date); err == nil {
+			response.NextDate = &nextDate
+		}
 	}
 }
@@ -2,8 +2,10 @@ package api_test
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -86,7 +88,7 @@ var _ = Describe("ItemsAPIService", func() {
 		Context("when no user ID in context", func() {
 			It("should return 401 unauthorized", func() {
 				emptyCtx := context.Background()
-				response, err := service.GetItems(emptyCtx, testDate, "", "")
+				response, err := service.GetItems(emptyCtx, testDate, "", "", "", "", "", "", 0, 0, "", "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(response.Code).To(Equal(401))
 			})
@@ -94,7 +96,7 @@ var _ = Describe("ItemsAPIService", func() {
 
 		Context("when item does not exist (backward compatibility with date filter)", func() {
 			It("should return empty list with 200 status", func() {
-				response, err := service.GetItems(ctx, testDate, "", "")
+				response, err := service.GetItems(ctx, testDate, "", "", "", "", "", "", 0, 0, "", "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(response.Code).To(Equal(200))
 
@@ -119,7 +121,7 @@ var _ = Describe("ItemsAPIService", func() {
 			})
 
 			It("should return the item in list format with 200 status", func() {
-				response, err := service.GetItems(ctx, testDate, "", "")
+				response, err := service.GetItems(ctx, testDate, "", "", "", "", "", "", 0, 0, "", "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(response.Code).To(Equal(200))
 
@@ -167,7 +169,7 @@ var _ = Describe("ItemsAPIService", func() {
 			})
 
 			It("should include previous and next dates", func() {
-				response, err := service.GetItems(ctx, testDate, "", "")
+				response, err := service.GetItems(ctx, testDate, "", "", "", "", "", "", 0, 0, "", "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(response.Code).To(Equal(200))
 
@@ -216,7 +218,7 @@ var _ = Describe("ItemsAPIService", func() {
 			})
 
 			It("should return items matching search text in title", func() {
-				response, err := service.GetItems(ctx, "", "vacation", "")
+				response, err := service.GetItems(ctx, "", "", "", "vacation", "", "", "", 0, 0, "", "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(response.Code).To(Equal(200))
 
@@ -228,7 +230,7 @@ var _ = Describe("ItemsAPIService", func() {
 			})
 
 			It("should return items matching search text in body", func() {
-				response, err := service.GetItems(ctx, "", "beach", "")
+				response, err := service.GetItems(ctx, "", "", "", "beach", "", "", "", 0, 0, "", "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(response.Code).To(Equal(200))
 
@@ -238,8 +240,34 @@ var _ = Describe("ItemsAPIService", func() {
 				Expect(itemsListResponse.TotalCount).To(Equal(int32(2)))
 			})
 
+			It("should highlight matches in snippets", func() {
+				response, err := service.GetItems(ctx, "", "", "", "beach", "", "", "", 0, 0, "", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.Code).To(Equal(200))
+
+				itemsListResponse, ok := response.Body.(goserver.ItemsListResponse)
+				Expect(ok).To(BeTrue())
+				Expect(itemsListResponse.Items).To(HaveLen(2))
+				for _, item := range itemsListResponse.Items {
+					Expect(item.Snippet).To(ContainSubstring("<mark>beach</mark>"))
+				}
+			})
+
+			It("should not return snippets without search text", func() {
+				response, err := service.GetItems(ctx, "", "", "", "", "", "", "", 0, 0, "", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.Code).To(Equal(200))
+
+				itemsListResponse, ok := response.Body.(goserver.ItemsListResponse)
+				Expect(ok).To(BeTrue())
+				Expect(itemsListResponse.Items).To(HaveLen(3))
+				for _, item := range itemsListResponse.Items {
+					Expect(item.Snippet).To(BeEmpty())
+				}
+			})
+
 			It("should return empty list when no matches found", func() {
-				response, err := service.GetItems(ctx, "", "nonexistent", "")
+				response, err := service.GetItems(ctx, "", "", "", "nonexistent", "", "", "", 0, 0, "", "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(response.Code).To(Equal(200))
 
@@ -282,7 +310,7 @@ var _ = Describe("ItemsAPIService", func() {
 			})
 
 			It("should return items matching single tag", func() {
-				response, err := service.GetItems(ctx, "", "", "work")
+				response, err := service.GetItems(ctx, "", "", "", "", "work", "", "", 0, 0, "", "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(response.Code).To(Equal(200))
 
@@ -293,7 +321,7 @@ var _ = Describe("ItemsAPIService", func() {
 			})
 
 			It("should return items matching multiple tags", func() {
-				response, err := service.GetItems(ctx, "", "", "family,personal")
+				response, err := service.GetItems(ctx, "", "", "", "", "family,personal", "", "", 0, 0, "", "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(response.Code).To(Equal(200))
 
@@ -305,7 +333,7 @@ var _ = Describe("ItemsAPIService", func() {
 			})
 
 			It("should return empty list when no tag matches found", func() {
-				response, err := service.GetItems(ctx, "", "", "nonexistent")
+				response, err := service.GetItems(ctx, "", "", "", "", "nonexistent", "", "", 0, 0, "", "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(response.Code).To(Equal(200))
 
@@ -314,6 +342,56 @@ var _ = Describe("ItemsAPIService", func() {
 				Expect(itemsListResponse.Items).To(BeEmpty())
 				Expect(itemsListResponse.TotalCount).To(Equal(int32(0)))
 			})
+
+			getTitles := func(response goserver.ImplResponse) []string {
+				Expect(response.Code).To(Equal(200))
+				itemsListResponse, ok := response.Body.(goserver.ItemsListResponse)
+				Expect(ok).To(BeTrue())
+
+				titles := make([]string, 0, len(itemsListResponse.Items))
+				for _, item := range itemsListResponse.Items {
+					titles = append(titles, item.Title)
+				}
+				return titles
+			}
+
+			It("should return items having all of the tags", func() {
+				response, err := service.GetItems(ctx, "", "", "", "", "", "work, meeting", "", 0, 0, "", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(getTitles(response)).To(ConsistOf("Work Meeting"))
+			})
+
+			It("should exclude items having any of the excluded tags", func() {
+				response, err := service.GetItems(ctx, "", "", "", "", "", "", "meeting,family", 0, 0, "", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(getTitles(response)).To(ConsistOf("Work Project"))
+			})
+
+			It("should combine tag conditions", func() {
+				response, err := service.GetItems(ctx, "", "", "", "", "project,family,meeting", "work", "meeting", 0, 0, "", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(getTitles(response)).To(ConsistOf("Work Project"))
+			})
+
+			It("should match tags exactly", func() {
+				item := &models.Item{
+					UserID: userID,
+					Date:   "2024-01-13",
+					Title:  "Wildcards",
+					Tags:   models.StringList{"100%", "to_do"},
+				}
+				Expect(storage.PutItem(userID, item)).To(Succeed())
+
+				for _, tags := range []string{"%", "wor_", "work%", "Work", "to"} {
+					response, err := service.GetItems(ctx, "", "", "", "", "", tags, "", 0, 0, "", "")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(getTitles(response)).To(BeEmpty(), "tags: %s", tags)
+				}
+
+				response, err := service.GetItems(ctx, "", "", "", "", "100%", "", "", 0, 0, "", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(getTitles(response)).To(ConsistOf("Wildcards"))
+			})
 		})
 
 		Context("when searching with combined filters", func() {
@@ -341,7 +419,7 @@ var _ = Describe("ItemsAPIService", func() {
 			})
 
 			It("should return items matching both text and tags", func() {
-				response, err := service.GetItems(ctx, "", "project", "work")
+				response, err := service.GetItems(ctx, "", "", "", "project", "work", "", "", 0, 0, "", "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(response.Code).To(Equal(200))
 
@@ -352,6 +430,148 @@ var _ = Describe("ItemsAPIService", func() {
 				Expect(itemsListResponse.Items[0].Title).To(Equal("Work Project Meeting"))
 			})
 		})
+
+		Context("when paginating and sorting", func() {
+			BeforeEach(func() {
+				for _, date := range []string{"2024-01-01", "2024-01-02", "2024-01-03", "2024-01-04", "2024-01-05"} {
+					item := &models.Item{
+						UserID: userID,
+						Date:   date,
+						Title:  "Entry " + date,
+						Body:   "Body of " + date,
+						Tags:   models.StringList{"daily"},
+					}
+					Expect(storage.PutItem(userID, item)).To(Succeed())
+				}
+			})
+
+			getDates := func(response goserver.ImplResponse) []string {
+				Expect(response.Code).To(Equal(200))
+				itemsListResponse, ok := response.Body.(goserver.ItemsListResponse)
+				Expect(ok).To(BeTrue())
+				Expect(itemsListResponse.TotalCount).To(Equal(int32(5)))
+
+				dates := make([]string, 0, len(itemsListResponse.Items))
+				for _, item := range itemsListResponse.Items {
+					dates = append(dates, item.Date)
+				}
+				return dates
+			}
+
+			It("should return the requested page newest first by default", func() {
+				response, err := service.GetItems(ctx, "", "", "", "", "", "", "", 2, 0, "", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(getDates(response)).To(Equal([]string{"2024-01-05", "2024-01-04"}))
+
+				response, err = service.GetItems(ctx, "", "", "", "", "", "", "", 2, 4, "", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(getDates(response)).To(Equal([]string{"2024-01-01"}))
+			})
+
+			It("should skip items without a limit", func() {
+				response, err := service.GetItems(ctx, "", "", "", "", "", "", "", 0, 3, "", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(getDates(response)).To(Equal([]string{"2024-01-02", "2024-01-01"}))
+			})
+
+			It("should return at most 100 items without a limit", func() {
+				start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+				for i := range 100 {
+					item := &models.Item{UserID: userID, Date: start.AddDate(0, 0, i).Format(time.DateOnly), Title: "Old entry"}
+					Expect(storage.PutItem(userID, item)).To(Succeed())
+				}
+
+				response, err := service.GetItems(ctx, "", "", "", "", "", "", "", 0, 0, "", "")
+				Expect(err).ToNot(HaveOccurred())
+				itemsListResponse, ok := response.Body.(goserver.ItemsListResponse)
+				Expect(ok).To(BeTrue())
+				Expect(itemsListResponse.TotalCount).To(Equal(int32(105)))
+				Expect(itemsListResponse.Items).To(HaveLen(100))
+			})
+
+			It("should add the navigation dates of every item of a page", func() {
+				response, err := service.GetItems(ctx, "", "", "", "", "", "", "", 3, 1, "date_asc", "")
+				Expect(err).ToNot(HaveOccurred())
+				itemsListResponse, ok := response.Body.(goserver.ItemsListResponse)
+				Expect(ok).To(BeTrue())
+				Expect(itemsListResponse.Items).To(HaveLen(3))
+				for i, item := range itemsListResponse.Items {
+					Expect(*item.PreviousDate).To(Equal(fmt.Sprintf("2024-01-0%d", i+1)))
+					Expect(*item.NextDate).To(Equal(fmt.Sprintf("2024-01-0%d", i+3)))
+				}
+
+				response, err = service.GetItems(ctx, "", "", "", "", "", "", "", 1, 0, "", "")
+				Expect(err).ToNot(HaveOccurred())
+				itemsListResponse, ok = response.Body.(goserver.ItemsListResponse)
+				Expect(ok).To(BeTrue())
+				Expect(*itemsListResponse.Items[0].PreviousDate).To(Equal("2024-01-04"))
+				Expect(itemsListResponse.Items[0].NextDate).To(BeNil())
+			})
+
+			It("should sort by date ascending", func() {
+				response, err := service.GetItems(ctx, "", "", "", "", "", "", "", 3, 1, "date_asc", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(getDates(response)).To(Equal([]string{"2024-01-02", "2024-01-03", "2024-01-04"}))
+			})
+
+			It("should reject unknown sort orders", func() {
+				response, err := service.GetItems(ctx, "", "", "", "", "", "", "", 0, 0, "title", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.Code).To(Equal(400))
+			})
+
+			It("should return only the requested fields", func() {
+				response, err := service.GetItems(ctx, "", "", "", "", "", "", "", 1, 1, "", "title, date")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.Code).To(Equal(200))
+
+				itemsListResponse, ok := response.Body.(goserver.ItemsListResponse)
+				Expect(ok).To(BeTrue())
+				Expect(itemsListResponse.Items).To(HaveLen(1))
+				item := itemsListResponse.Items[0]
+				Expect(item.Date).To(Equal("2024-01-04"))
+				Expect(item.Title).To(Equal("Entry 2024-01-04"))
+				Expect(item.Body).To(BeEmpty())
+				Expect(item.Tags).To(BeNil())
+				Expect(item.PreviousDate).To(BeNil())
+				Expect(item.NextDate).To(BeNil())
+			})
+
+			It("should filter by date range", func() {
+				response, err := service.GetItems(ctx, "", "2024-01-02", "2024-01-04", "", "", "", "", 0, 0, "date_asc", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.Code).To(Equal(200))
+
+				itemsListResponse, ok := response.Body.(goserver.ItemsListResponse)
+				Expect(ok).To(BeTrue())
+				Expect(itemsListResponse.TotalCount).To(Equal(int32(3)))
+				Expect(itemsListResponse.Items).To(HaveLen(3))
+				Expect(itemsListResponse.Items[0].Date).To(Equal("2024-01-02"))
+				Expect(itemsListResponse.Items[2].Date).To(Equal("2024-01-04"))
+
+				response, err = service.GetItems(ctx, "", "2024-01-04", "", "", "", "", "", 0, 0, "", "")
+				Expect(err).ToNot(HaveOccurred())
+				itemsListResponse, ok = response.Body.(goserver.ItemsListResponse)
+				Expect(ok).To(BeTrue())
+				Expect(itemsListResponse.TotalCount).To(Equal(int32(2)))
+			})
+
+			It("should reject invalid date ranges", func() {
+				response, err := service.GetItems(ctx, "", "2024-01", "", "", "", "", "", 0, 0, "", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.Code).To(Equal(400))
+
+				response, err = service.GetItems(ctx, "", "2024-01-04", "2024-01-02", "", "", "", "", 0, 0, "", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.Code).To(Equal(400))
+			})
+
+			It("should reject unknown fields", func() {
+				response, err := service.GetItems(ctx, "", "", "", "", "", "", "", 0, 0, "", "title,author")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.Code).To(Equal(400))
+			})
+		})
 	})
 
 	Describe("PutItems", func() {
@@ -364,7 +584,7 @@ var _ = Describe("ItemsAPIService", func() {
 					Body:  "Test Body",
 					Tags:  []string{"tag1", "tag2"},
 				}
-				response, err := service.PutItems(emptyCtx, request)
+				response, err := service.PutItems(emptyCtx, request, "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(response.Code).To(Equal(401))
 			})
@@ -379,7 +599,7 @@ var _ = Describe("ItemsAPIService", func() {
 					Tags:  []string{"new", "test"},
 				}
 
-				response, err := service.PutItems(ctx, request)
+				response, err := service.PutItems(ctx, request, "")
 				Expect(err).ToNot(HaveOccurred())
 
 				assertSuccessfulPutResponse(response, "New Test Title", "New Test Body", []string{"new", "test"}, testDate)
@@ -408,7 +628,7 @@ var _ = Describe("ItemsAPIService", func() {
 					Tags:  []string{"updated", "modified"},
 				}
 
-				response, err := service.PutItems(ctx, request)
+				response, err := service.PutItems(ctx, request, "")
 				Expect(err).ToNot(HaveOccurred())
 
 				assertSuccessfulPutResponse(response, "Updated Title", "Updated Body", []string{"updated", "modified"}, testDate)
@@ -445,7 +665,7 @@ var _ = Describe("ItemsAPIService", func() {
 					Tags:  []string{"current"},
 				}
 
-				response, err := service.PutItems(ctx, request)
+				response, err := service.PutItems(ctx, request, "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(response.Code).To(Equal(200))
 
@@ -458,5 +678,205 @@ var _ = Describe("ItemsAPIService", func() {
 				Expect(*itemsResponse.NextDate).To(Equal("2024-01-16"))
 			})
 		})
+
+		Context("with If-Match", func() {
+			request := goserver.ItemsRequest{Date: testDate, Title: "Mine", Body: "My body", Tags: []string{"mine"}}
+
+			BeforeEach(func() {
+				Expect(storage.PutItem(userID, &models.Item{Date: testDate, Title: "Server", Body: "v1"})).To(Succeed())
+				Expect(storage.PutItem(userID, &models.Item{Date: testDate, Title: "Server", Body: "v2"})).To(Succeed())
+			})
+
+			It("should save when the version matches and bump the version", func() {
+				response, err := service.PutItems(ctx, request, `"2"`)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.Code).To(Equal(200))
+				itemsResponse, ok := response.Body.(goserver.ItemsResponse)
+				Expect(ok).To(BeTrue())
+				Expect(itemsResponse.Version).To(Equal(int64(3)))
+
+				verifyItemInDatabase(storage, userID, testDate, "Mine", "My body", []string{"mine"})
+			})
+
+			It("should return 409 with the server copy when the version is stale", func() {
+				response, err := service.PutItems(ctx, request, "1")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.Code).To(Equal(409))
+				itemsResponse, ok := response.Body.(goserver.ItemsResponse)
+				Expect(ok).To(BeTrue())
+				Expect(itemsResponse.Body).To(Equal("v2"))
+				Expect(itemsResponse.Version).To(Equal(int64(2)))
+
+				item, err := storage.GetItem(userID, testDate)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(item.Body).To(Equal("v2"))
+			})
+
+			It("should return 409 when version 0 is used for an existing item", func() {
+				response, err := service.PutItems(ctx, request, "0")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.Code).To(Equal(409))
+			})
+
+			It("should return 409 without a body when the item was deleted", func() {
+				Expect(storage.DeleteItem(userID, testDate)).To(Succeed())
+
+				response, err := service.PutItems(ctx, request, "2")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.Code).To(Equal(409))
+				Expect(response.Body).To(BeNil())
+			})
+
+			It("should return 400 for an invalid version", func() {
+				response, err := service.PutItems(ctx, request, "abc")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.Code).To(Equal(400))
+			})
+		})
+	})
+
+	Describe("DeleteItems", func() {
+		It("should return 401 unauthorized without user ID", func() {
+			response, err := service.DeleteItems(context.Background(), testDate)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Code).To(Equal(401))
+		})
+
+		It("should return 400 for an invalid date", func() {
+			response, err := service.DeleteItems(ctx, "15.01.2024")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Code).To(Equal(400))
+		})
+
+		It("should return 404 when the item does not exist", func() {
+			response, err := service.DeleteItems(ctx, testDate)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Code).To(Equal(404))
+		})
+
+		It("should delete the item and record the deletion", func() {
+			Expect(storage.PutItem(userID, &models.Item{Date: testDate, Title: "To delete"})).To(Succeed())
+
+			response, err := service.DeleteItems(ctx, testDate)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Code).To(Equal(204))
+
+			_, err = storage.GetItem(userID, testDate)
+			Expect(err).To(MatchError(database.ErrNotFound))
+
+			changes, err := storage.GetChangesSince(userID, 0, 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes).To(HaveLen(2))
+			Expect(changes[1].OperationType).To(Equal(models.OperationTypeDeleted))
+			Expect(changes[1].Date).To(Equal(testDate))
+		})
+	})
+
+	Describe("Revisions", func() {
+		var revisionIDs []int64
+
+		getRevisions := func() []goserver.ItemRevisionResponse {
+			response, err := service.GetItemRevisions(ctx, testDate)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Code).To(Equal(200))
+			revisions, ok := response.Body.([]goserver.ItemRevisionResponse)
+			Expect(ok).To(BeTrue())
+			return revisions
+		}
+
+		BeforeEach(func() {
+			Expect(storage.PutItem(userID, &models.Item{
+				Date: testDate, Title: "First", Body: "line 1\nline 2", Tags: models.StringList{"a", "b"},
+			})).To(Succeed())
+			Expect(storage.PutItem(userID, &models.Item{
+				Date: testDate, Title: "Second", Body: "line 1\nline 3", Tags: models.StringList{"b", "c"},
+			})).To(Succeed())
+
+			revisionIDs = nil
+			for _, revision := range getRevisions() {
+				revisionIDs = append(revisionIDs, revision.Id)
+			}
+		})
+
+		It("should return 401 unauthorized without user ID", func() {
+			response, err := service.GetItemRevisions(context.Background(), testDate)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Code).To(Equal(401))
+		})
+
+		It("should list revisions newest first", func() {
+			revisions := getRevisions()
+			Expect(revisions).To(HaveLen(2))
+			Expect(revisions[0].Title).To(Equal("Second"))
+			Expect(revisions[1].Title).To(Equal("First"))
+			Expect(revisions[0].Id).To(BeNumerically(">", revisions[1].Id))
+			Expect(revisions[1].Timestamp).ToNot(BeZero())
+		})
+
+		It("should return 400 for an invalid date", func() {
+			response, err := service.GetItemRevisions(ctx, "15.01.2024")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Code).To(Equal(400))
+		})
+
+		It("should diff a revision against the latest one by default", func() {
+			response, err := service.GetItemRevisionsDiff(ctx, testDate, revisionIDs[1], 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Code).To(Equal(200))
+			diff, ok := response.Body.(goserver.ItemRevisionDiffResponse)
+			Expect(ok).To(BeTrue())
+
+			Expect(diff.FromId).To(Equal(revisionIDs[1]))
+			Expect(diff.ToId).To(Equal(revisionIDs[0]))
+			Expect(diff.Body).To(Equal([]goserver.DiffLine{
+				{Op: api.DiffOpEqual, Text: "line 1"},
+				{Op: api.DiffOpDelete, Text: "line 2"},
+				{Op: api.DiffOpInsert, Text: "line 3"},
+			}))
+			Expect(diff.TagsAdded).To(Equal([]string{"c"}))
+			Expect(diff.TagsRemoved).To(Equal([]string{"a"}))
+		})
+
+		It("should return 404 when diffing an unknown revision", func() {
+			response, err := service.GetItemRevisionsDiff(ctx, testDate, revisionIDs[0]+100, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Code).To(Equal(404))
+		})
+
+		It("should restore an old revision as a new revision", func() {
+			response, err := service.RestoreItemRevision(ctx, testDate, revisionIDs[1])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Code).To(Equal(200))
+			restored, ok := response.Body.(goserver.ItemsResponse)
+			Expect(ok).To(BeTrue())
+			Expect(restored.Title).To(Equal("First"))
+
+			item, err := storage.GetItem(userID, testDate)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(item.Body).To(Equal("line 1\nline 2"))
+			Expect(item.Tags).To(Equal(models.StringList{"a", "b"}))
+
+			revisions := getRevisions()
+			Expect(revisions).To(HaveLen(3))
+			Expect(revisions[0].Title).To(Equal("First"))
+		})
+
+		It("should restore a deleted item", func() {
+			Expect(storage.DeleteItem(userID, testDate)).To(Succeed())
+
+			response, err := service.RestoreItemRevision(ctx, testDate, revisionIDs[0])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Code).To(Equal(200))
+
+			item, err := storage.GetItem(userID, testDate)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(item.Title).To(Equal("Second"))
+		})
+
+		It("should return 404 when restoring a revision of another date", func() {
+			response, err := service.RestoreItemRevision(ctx, "2024-02-01", revisionIDs[0])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Code).To(Equal(404))
+		})
 	})
 })
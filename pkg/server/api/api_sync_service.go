@@ -26,7 +26,7 @@ func NewSyncAPIService(logger *slog.Logger, db database.Storage) goserver.SyncAP
 // GetChanges - get changes for synchronization
 func (s *SyncAPIServiceImpl) GetChanges(
 	ctx context.Context,
-	since int32,
+	since int64,
 	limit int32,
 ) (goserver.ImplResponse, error) {
 	start := time.Now()
@@ -44,6 +44,21 @@ func (s *SyncAPIServiceImpl) GetChanges(
 	// Validate and normalize parameters
 	limit = s.validateLimit(limit)
 
+	// Changes after "since" may have been removed by compaction, a fresh client (since 0)
+	// still gets the current state from the remaining changes
+	horizon, err := s.db.GetSyncHorizon(userID)
+	if err != nil {
+		s.logSyncError(op, userID, since, limit, start, err)
+		return goserver.Response(500, nil), nil
+	}
+	if since > 0 && uint(since) < horizon {
+		s.logger.Warn("Sync resync required", "syncOp", op, "userID", userID, "since", since, "horizon", horizon)
+		return goserver.Response(410, goserver.SyncResyncRequiredResponse{
+			Error:     "resync required",
+			HorizonId: toChangeID(horizon),
+		}), nil
+	}
+
 	// Get changes from database
 	changes, err := s.fetchChanges(userID, since, limit)
 	if err != nil {
@@ -59,7 +74,7 @@ func (s *SyncAPIServiceImpl) GetChanges(
 	return goserver.Response(200, response), nil
 }
 
-func (s *SyncAPIServiceImpl) logAuthError(op string, since, limit int32, start time.Time) {
+func (s *SyncAPIServiceImpl) logAuthError(op string, since int64, limit int32, start time.Time) {
 	s.logger.With(
 		"syncOp", op,
 		"since", since,
@@ -68,7 +83,7 @@ func (s *SyncAPIServiceImpl) logAuthError(op string, since, limit int32, start t
 	).Error("User ID not found in context")
 }
 
-func (s *SyncAPIServiceImpl) logSyncRequest(op, userID string, since, limit int32) {
+func (s *SyncAPIServiceImpl) logSyncRequest(op, userID string, since int64, limit int32) {
 	s.logger.Info("Sync request received",
 		"syncOp", op,
 		"userID", userID,
@@ -84,7 +99,7 @@ func (s *SyncAPIServiceImpl) validateLimit(limit int32) int32 {
 	return limit
 }
 
-func (s *SyncAPIServiceImpl) fetchChanges(userID string, since, limit int32) ([]*models.ItemChange, error) {
+func (s *SyncAPIServiceImpl) fetchChanges(userID string, since int64, limit int32) ([]*models.ItemChange, error) {
 	sinceUint := uint(since)
 	if since < 0 {
 		sinceUint = 0
@@ -92,7 +107,7 @@ func (s *SyncAPIServiceImpl) fetchChanges(userID string, since, limit int32) ([]
 	return s.db.GetChangesSince(userID, sinceUint, int(limit))
 }
 
-func (s *SyncAPIServiceImpl) logSyncError(op, userID string, since, limit int32, start time.Time, err error) {
+func (s *SyncAPIServiceImpl) logSyncError(op, userID string, since int64, limit int32, start time.Time, err error) {
 	s.logger.Error("Sync operation failed",
 		"syncOp", op,
 		"userID", userID,
@@ -113,12 +128,9 @@ func (s *SyncAPIServiceImpl) buildSyncResponse(changes []*models.ItemChange, lim
 
 	// Determine if there are more changes available
 	hasMore := len(changes) == int(limit)
-	var nextID int32
+	var nextID int64
 	if hasMore && len(changes) > 0 {
-		lastID := changes[len(changes)-1].ID
-		if lastID <= uint(^uint32(0)>>1) { // Check if it fits in int32 (max positive value)
-			nextID = int32(lastID) // #nosec G115 - checked above
-		}
+		nextID = toChangeID(changes[len(changes)-1].ID)
 	}
 
 	return goserver.SyncResponse{
@@ -130,7 +142,8 @@ func (s *SyncAPIServiceImpl) buildSyncResponse(changes []*models.ItemChange, lim
 
 func (s *SyncAPIServiceImpl) logSyncSuccess(
 	op, userID string,
-	since, limit int32,
+	since int64,
+	limit int32,
 	response goserver.SyncResponse,
 	start time.Time,
 ) {
@@ -146,3 +159,178 @@ func (s *SyncAPIServiceImpl) logSyncSuccess(
 		"duration", time.Since(start),
 	)
 }
+
+// maxPushChanges is the maximum number of changes accepted in one push
+const maxPushChanges = 1000
+
+// PushChanges - push client changes for synchronization
+func (s *SyncAPIServiceImpl) PushChanges(
+	ctx context.Context,
+	request goserver.SyncPushRequest,
+) (goserver.ImplResponse, error) {
+	start := time.Now()
+	const op = "push"
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := ctx.Value(common.UserIDKey).(string)
+	if !ok {
+		s.logger.With("syncOp", op, "duration", time.Since(start)).Error("User ID not found in context")
+		return goserver.Response(401, nil), nil
+	}
+
+	s.logger.Info("Sync push received",
+		"syncOp", op,
+		"userID", userID,
+		"baseChangeId", request.BaseChangeId,
+		"changes", len(request.Changes),
+	)
+
+	if request.BaseChangeId < 0 || len(request.Changes) > maxPushChanges {
+		s.logger.Warn("Invalid push request", "syncOp", op, "userID", userID,
+			"baseChangeId", request.BaseChangeId, "changes", len(request.Changes))
+		return goserver.Response(400, nil), nil
+	}
+
+	// Invalid changes are rejected here, the valid ones are applied together
+	results := make([]goserver.SyncPushResult, len(request.Changes))
+	var changes []database.PushChange
+	var indexes []int
+	for i, change := range request.Changes {
+		results[i] = goserver.SyncPushResult{ClientId: change.ClientId, Date: change.Date}
+		if reason := validatePushChange(change); reason != "" {
+			results[i].Status = string(database.PushStatusRejected)
+			results[i].Reason = reason
+			continue
+		}
+
+		changes = append(changes, database.PushChange{
+			Operation: database.PushOperation(change.OperationType),
+			Item: &models.Item{
+				Date:  change.Date,
+				Title: change.Title,
+				Body:  change.Body,
+				Tags:  filterTags(change.Tags),
+			},
+			BaseVersion: change.BaseVersion,
+		})
+		indexes = append(indexes, i)
+	}
+
+	pushResults, err := s.db.PushChanges(userID, uint(request.BaseChangeId), changes)
+	if err != nil {
+		s.logger.Error("Sync operation failed", "syncOp", op, "userID", userID, "status", 500,
+			"error", err, "duration", time.Since(start))
+		return goserver.Response(500, nil), nil
+	}
+	for i, pushResult := range pushResults {
+		result := &results[indexes[i]]
+		result.Status = string(pushResult.Status)
+		result.Reason = pushResult.Reason
+		if pushResult.Item == nil {
+			continue
+		}
+		switch pushResult.Status {
+		case database.PushStatusApplied:
+			result.Version = pushResult.Item.Version
+		case database.PushStatusConflict:
+			snapshot := toItemsResponse(pushResult.Item)
+			result.ServerSnapshot = &snapshot
+		}
+	}
+
+	latestChangeID, err := s.db.GetLatestChangeID(userID)
+	if err != nil {
+		s.logger.Error("Sync operation failed", "syncOp", op, "userID", userID, "status", 500,
+			"error", err, "duration", time.Since(start))
+		return goserver.Response(500, nil), nil
+	}
+	latestID := toChangeID(latestChangeID)
+
+	s.logger.Info("Sync push completed",
+		"syncOp", op,
+		"userID", userID,
+		"changes", len(request.Changes),
+		"latestChangeId", latestID,
+		"status", 200,
+		"duration", time.Since(start),
+	)
+
+	return goserver.Response(200, goserver.SyncPushResponse{
+		Results:        results,
+		LatestChangeId: latestID,
+	}), nil
+}
+
+// validatePushChange returns the reason why the change is rejected, or an empty string if it's valid
+func validatePushChange(change goserver.SyncPushChange) string {
+	if _, err := time.Parse(time.DateOnly, change.Date); err != nil {
+		return "invalid date"
+	}
+	switch database.PushOperation(change.OperationType) {
+	case database.PushOperationUpsert, database.PushOperationDelete:
+	default:
+		return "unknown operation type"
+	}
+	if change.BaseVersion != nil && *change.BaseVersion < 0 {
+		return "invalid base version"
+	}
+	return ""
+}
+
+// GetSnapshot - get current state for synchronization
+func (s *SyncAPIServiceImpl) GetSnapshot(ctx context.Context) (goserver.ImplResponse, error) {
+	start := time.Now()
+	const op = "snapshot"
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := ctx.Value(common.UserIDKey).(string)
+	if !ok {
+		s.logger.With("syncOp", op, "duration", time.Since(start)).Error("User ID not found in context")
+		return goserver.Response(401, nil), nil
+	}
+
+	items, assets, changeID, err := s.db.GetSnapshot(userID)
+	if err != nil {
+		s.logger.Error("Sync operation failed", "syncOp", op, "userID", userID, "status", 500,
+			"error", err, "duration", time.Since(start))
+		return goserver.Response(500, nil), nil
+	}
+
+	response := goserver.SyncSnapshotResponse{
+		ChangeId: toChangeID(changeID),
+		Items:    make([]goserver.ItemsResponse, len(items)),
+		Assets:   make([]goserver.SyncAssetSnapshot, len(assets)),
+	}
+	for i, item := range items {
+		response.Items[i] = toItemsResponse(item)
+	}
+	for i, asset := range assets {
+		response.Assets[i] = asset.ToSyncResponse()
+	}
+
+	s.logger.Info("Sync completed",
+		"syncOp", op,
+		"userID", userID,
+		"items", len(items),
+		"assets", len(assets),
+		"changeId", response.ChangeId,
+		"status", 200,
+		"duration", time.Since(start),
+	)
+
+	return goserver.Response(200, response), nil
+}
+
+// StreamChanges - not implemented here; SyncStreamRouter handles /v1/sync/stream.
+func (s *SyncAPIServiceImpl) StreamChanges(
+	ctx context.Context,
+	since int64,
+	lastEventID string,
+) (goserver.ImplResponse, error) {
+	return goserver.Response(501, nil), nil
+}
+
+// toChangeID converts a change ID to the API format
+func toChangeID(id uint) int64 {
+	return int64(id) //nolint:gosec // change IDs fit into int64
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"os"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -182,7 +183,7 @@ var _ = Describe("SyncAPIService", func() {
 				Expect(syncResponse3.HasMore).To(BeFalse())
 
 				// Verify no duplicate changes
-				allIDs := make(map[int32]bool)
+				allIDs := make(map[int64]bool)
 				for _, change := range syncResponse1.Changes {
 					allIDs[change.Id] = true
 				}
@@ -257,4 +258,244 @@ var _ = Describe("SyncAPIService", func() {
 			})
 		})
 	})
+
+	Describe("PushChanges", func() {
+		push := func(request goserver.SyncPushRequest) goserver.SyncPushResponse {
+			response, err := service.PushChanges(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Code).To(Equal(200))
+			pushResponse, ok := response.Body.(goserver.SyncPushResponse)
+			Expect(ok).To(BeTrue())
+			return pushResponse
+		}
+		version := func(v int64) *int64 { return &v }
+
+		It("should return unauthorized without user ID", func() {
+			response, err := service.PushChanges(context.Background(), goserver.SyncPushRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Code).To(Equal(401))
+		})
+
+		It("should apply changes and return the latest change ID", func() {
+			Expect(storage.PutItem(userID, &models.Item{Date: "2024-01-14", Title: "To delete"})).To(Succeed())
+			baseID, err := storage.GetLatestChangeID(userID)
+			Expect(err).NotTo(HaveOccurred())
+
+			pushResponse := push(goserver.SyncPushRequest{
+				BaseChangeId: int64(baseID), //nolint:gosec // small test IDs
+				Changes: []goserver.SyncPushChange{
+					{ClientId: "c1", OperationType: "upsert", Date: "2024-01-15", Title: "Offline"},
+					{ClientId: "c2", OperationType: "upsert", Date: "2024-01-15", Title: "Offline again", Tags: []string{" trip "}},
+					{ClientId: "c3", OperationType: "delete", Date: "2024-01-14"},
+				},
+			})
+
+			Expect(pushResponse.Results).To(HaveLen(3))
+			for _, result := range pushResponse.Results {
+				Expect(result.Status).To(Equal("applied"))
+			}
+			Expect(pushResponse.Results[0].ClientId).To(Equal("c1"))
+			Expect(pushResponse.Results[1].Version).To(Equal(int64(2)))
+
+			latestID, err := storage.GetLatestChangeID(userID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pushResponse.LatestChangeId).To(BeNumerically("==", latestID))
+			Expect(latestID).To(Equal(baseID + 3))
+
+			item, err := storage.GetItem(userID, "2024-01-15")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(item.Title).To(Equal("Offline again"))
+			Expect(item.Tags).To(Equal(models.StringList{"trip"}))
+			_, err = storage.GetItem(userID, "2024-01-14")
+			Expect(err).To(MatchError(database.ErrNotFound))
+		})
+
+		It("should report conflicts with server changes after the base change ID", func() {
+			Expect(storage.PutItem(userID, &models.Item{Date: "2024-01-15", Title: "Server"})).To(Succeed())
+
+			pushResponse := push(goserver.SyncPushRequest{
+				BaseChangeId: 0,
+				Changes: []goserver.SyncPushChange{
+					{OperationType: "upsert", Date: "2024-01-15", Title: "Offline"},
+					{OperationType: "upsert", Date: "2024-01-16", Title: "New"},
+				},
+			})
+
+			Expect(pushResponse.Results[0].Status).To(Equal("conflict"))
+			Expect(pushResponse.Results[0].ServerSnapshot).NotTo(BeNil())
+			Expect(pushResponse.Results[0].ServerSnapshot.Title).To(Equal("Server"))
+			Expect(pushResponse.Results[1].Status).To(Equal("applied"))
+
+			item, err := storage.GetItem(userID, "2024-01-15")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(item.Title).To(Equal("Server"))
+		})
+
+		It("should detect conflicts by base version", func() {
+			Expect(storage.PutItem(userID, &models.Item{Date: "2024-01-15", Title: "v1"})).To(Succeed())
+			Expect(storage.PutItem(userID, &models.Item{Date: "2024-01-15", Title: "v2"})).To(Succeed())
+
+			pushResponse := push(goserver.SyncPushRequest{
+				Changes: []goserver.SyncPushChange{
+					{OperationType: "upsert", Date: "2024-01-15", Title: "stale", BaseVersion: version(1)},
+					{OperationType: "upsert", Date: "2024-01-15", Title: "current", BaseVersion: version(2)},
+					{OperationType: "upsert", Date: "2024-01-16", Title: "new", BaseVersion: version(0)},
+				},
+			})
+
+			Expect(pushResponse.Results[0].Status).To(Equal("conflict"))
+			Expect(pushResponse.Results[0].ServerSnapshot.Version).To(Equal(int64(2)))
+			Expect(pushResponse.Results[1].Status).To(Equal("applied"))
+			Expect(pushResponse.Results[1].Version).To(Equal(int64(3)))
+			Expect(pushResponse.Results[2].Status).To(Equal("applied"))
+		})
+
+		It("should reject invalid changes and apply the others", func() {
+			pushResponse := push(goserver.SyncPushRequest{
+				Changes: []goserver.SyncPushChange{
+					{OperationType: "upsert", Date: "15.01.2024"},
+					{OperationType: "rename", Date: "2024-01-15"},
+					{OperationType: "delete", Date: "2024-01-15"},
+					{OperationType: "upsert", Date: "2024-01-16", Title: "Valid"},
+				},
+			})
+
+			Expect(pushResponse.Results[0].Status).To(Equal("rejected"))
+			Expect(pushResponse.Results[0].Reason).To(Equal("invalid date"))
+			Expect(pushResponse.Results[1].Status).To(Equal("rejected"))
+			Expect(pushResponse.Results[2].Status).To(Equal("rejected"))
+			Expect(pushResponse.Results[2].Reason).To(Equal("item not found"))
+			Expect(pushResponse.Results[3].Status).To(Equal("applied"))
+		})
+
+		It("should return 400 for a negative base change ID", func() {
+			response, err := service.PushChanges(ctx, goserver.SyncPushRequest{BaseChangeId: -1})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Code).To(Equal(400))
+		})
+	})
+
+	Describe("Compaction", func() {
+		compactAll := func() {
+			_, err := storage.CompactChanges(time.Now().Add(time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			Expect(storage.PutItem(userID, &models.Item{Date: "2024-01-15", Title: "v1"})).To(Succeed())
+			Expect(storage.PutItem(userID, &models.Item{Date: "2024-01-15", Title: "v2"})).To(Succeed())
+			Expect(storage.PutItem(userID, &models.Item{Date: "2024-01-16", Title: "Deleted"})).To(Succeed())
+			Expect(storage.DeleteItem(userID, "2024-01-16")).To(Succeed())
+			Expect(storage.PutItem(userID, &models.Item{Date: "2024-01-17", Title: "Kept"})).To(Succeed())
+		})
+
+		It("should keep only the latest change of existing items", func() {
+			compactAll()
+
+			response, err := service.GetChanges(ctx, 0, 100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Code).To(Equal(200))
+			syncResponse, ok := response.Body.(goserver.SyncResponse)
+			Expect(ok).To(BeTrue())
+			Expect(syncResponse.Changes).To(HaveLen(2))
+			Expect(syncResponse.Changes[0].ItemSnapshot.Title).To(Equal("v2"))
+			Expect(syncResponse.Changes[1].ItemSnapshot.Title).To(Equal("Kept"))
+		})
+
+		It("should not compact changes within the retention window", func() {
+			removed, err := storage.CompactChanges(time.Now().Add(-time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed).To(BeZero())
+
+			response, err := service.GetChanges(ctx, 1, 100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Code).To(Equal(200))
+		})
+
+		It("should require a resync for clients behind the horizon", func() {
+			compactAll()
+
+			// The deletion of 2024-01-16 (change 4) was removed
+			response, err := service.GetChanges(ctx, 3, 100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Code).To(Equal(410))
+			resync, ok := response.Body.(goserver.SyncResyncRequiredResponse)
+			Expect(ok).To(BeTrue())
+			Expect(resync.HorizonId).To(Equal(int64(4)))
+
+			response, err = service.GetChanges(ctx, 4, 100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Code).To(Equal(200))
+		})
+
+		It("should not require a resync for removed changes that were superseded", func() {
+			// Change 5 is superseded by change 6, clients after the deletion (change 4) pull change 6 instead
+			Expect(storage.PutItem(userID, &models.Item{Date: "2024-01-17", Title: "Kept v2"})).To(Succeed())
+			compactAll()
+
+			response, err := service.GetChanges(ctx, 4, 100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Code).To(Equal(200))
+			syncResponse, ok := response.Body.(goserver.SyncResponse)
+			Expect(ok).To(BeTrue())
+			Expect(syncResponse.Changes).To(HaveLen(1))
+			Expect(syncResponse.Changes[0].ItemSnapshot.Title).To(Equal("Kept v2"))
+		})
+
+		It("should keep the latest change of every existing asset", func() {
+			kept := &models.Asset{Name: "kept.jpg", Size: 1, ContentType: "image/jpeg", Hash: "a"}
+			deleted := &models.Asset{Name: "deleted.jpg", Size: 2, ContentType: "image/jpeg", Hash: "b"}
+			Expect(storage.CreateAssets(userID, []*models.Asset{kept, deleted})).To(Succeed())
+			Expect(storage.TrashAssets(userID, []string{deleted.Name})).To(Succeed())
+			compactAll()
+
+			response, err := service.GetChanges(ctx, 0, 100)
+			Expect(err).NotTo(HaveOccurred())
+			syncResponse, ok := response.Body.(goserver.SyncResponse)
+			Expect(ok).To(BeTrue())
+			Expect(syncResponse.Changes).To(HaveLen(3))
+			Expect(syncResponse.Changes[2].EntityType).To(Equal("asset"))
+			Expect(syncResponse.Changes[2].AssetSnapshot.Name).To(Equal("kept.jpg"))
+		})
+	})
+
+	Describe("GetSnapshot", func() {
+		It("should return unauthorized without user ID", func() {
+			response, err := service.GetSnapshot(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Code).To(Equal(401))
+		})
+
+		It("should return all items with the latest change ID", func() {
+			Expect(storage.PutItem(userID, &models.Item{Date: "2024-01-16", Title: "Second"})).To(Succeed())
+			Expect(storage.PutItem(userID, &models.Item{Date: "2024-01-15", Title: "First"})).To(Succeed())
+			latestID, err := storage.GetLatestChangeID(userID)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err := service.GetSnapshot(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Code).To(Equal(200))
+			snapshot, ok := response.Body.(goserver.SyncSnapshotResponse)
+			Expect(ok).To(BeTrue())
+			Expect(snapshot.ChangeId).To(BeNumerically("==", latestID))
+			Expect(snapshot.Items).To(HaveLen(2))
+			Expect(snapshot.Items[0].Title).To(Equal("First"))
+			Expect(snapshot.Items[1].Version).To(Equal(int64(1)))
+			Expect(snapshot.Assets).To(BeEmpty())
+		})
+
+		It("should return the assets that were not deleted", func() {
+			first := &models.Asset{Name: "first.jpg", Size: 1, ContentType: "image/jpeg", Hash: "a"}
+			second := &models.Asset{Name: "second.png", Size: 2, ContentType: "image/png", Hash: "b"}
+			Expect(storage.CreateAssets(userID, []*models.Asset{second, first})).To(Succeed())
+			Expect(storage.TrashAssets(userID, []string{second.Name})).To(Succeed())
+
+			response, err := service.GetSnapshot(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			snapshot, ok := response.Body.(goserver.SyncSnapshotResponse)
+			Expect(ok).To(BeTrue())
+			Expect(snapshot.Assets).To(Equal([]goserver.SyncAssetSnapshot{first.ToAssetInfo().ToSyncResponse()}))
+			Expect(snapshot.ChangeId).To(Equal(int64(3)))
+		})
+	})
 })
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ya-breeze/diary.be/pkg/database"
+	"github.com/ya-breeze/diary.be/pkg/generated/goserver"
+	"github.com/ya-breeze/diary.be/pkg/server/common"
+)
+
+const (
+	// streamBatchSize is the number of changes read from the change log at once
+	streamBatchSize = 100
+	// streamKeepAliveInterval is how often a comment is sent to keep idle connections open
+	streamKeepAliveInterval = 30 * time.Second
+)
+
+// SyncStreamRouter streams changes of the user as server-sent events. Every change is sent as
+// a "change" event with the SyncChangeResponse as data and the change ID as event ID, so
+// reconnecting clients resume from the Last-Event-ID header.
+type SyncStreamRouter struct {
+	logger *slog.Logger
+	db     database.Storage
+	// done closes all open streams, e.g. when the server shuts down
+	done <-chan struct{}
+}
+
+func NewSyncStreamRouter(logger *slog.Logger, db database.Storage, done <-chan struct{}) *SyncStreamRouter {
+	return &SyncStreamRouter{logger: logger, db: db, done: done}
+}
+
+// Implement goserver.Router
+func (r *SyncStreamRouter) Routes() goserver.Routes {
+	return goserver.Routes{
+		"streamChanges": {Method: http.MethodGet, Pattern: "/v1/sync/stream", HandlerFunc: r.handleStream},
+	}
+}
+
+func (r *SyncStreamRouter) handleStream(w http.ResponseWriter, req *http.Request) {
+	userID, _ := req.Context().Value(common.UserIDKey).(string)
+	if userID == "" {
+		writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Subscribe before reading the change log, so no change committed in between is missed
+	notifications, unsubscribe := r.db.SubscribeChanges(userID)
+	defer unsubscribe()
+
+	lastID, code, err := r.resumeID(userID, req)
+	if err != nil {
+		if code == http.StatusInternalServerError {
+			r.logger.Error("Failed to start sync stream", "userID", userID, "error", err)
+		}
+		writeJSONError(w, code, err.Error())
+		return
+	}
+
+	horizon, err := r.db.GetSyncHorizon(userID)
+	if err != nil {
+		r.logger.Error("Failed to get sync horizon", "userID", userID, "error", err)
+		writeJSONError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if lastID > 0 && lastID < horizon {
+		r.logger.Warn("Sync resync required", "syncOp", "stream", "userID", userID, "since", lastID, "horizon", horizon)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusGone)
+		_ = json.NewEncoder(w).Encode(goserver.SyncResyncRequiredResponse{
+			Error:     "resync required",
+			HorizonId: toChangeID(horizon),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	rc := http.NewResponseController(w)
+	if err := rc.Flush(); err != nil {
+		r.logger.Error("Streaming is not supported", "userID", userID, "error", err)
+		return
+	}
+
+	r.logger.Info("Sync stream opened", "userID", userID, "since", lastID)
+	defer r.logger.Info("Sync stream closed", "userID", userID)
+
+	keepAlive := time.NewTicker(streamKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		if lastID, err = r.writeChanges(w, userID, lastID); err != nil {
+			r.logger.Warn("Failed to write sync stream", "userID", userID, "error", err)
+			return
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+
+		select {
+		case <-req.Context().Done():
+			return
+		case <-r.done:
+			return
+		case <-notifications:
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// resumeID returns the ID of the last change the client has. It's taken from the Last-Event-ID
+// header or the "since" parameter, a new client starts with the latest change.
+func (r *SyncStreamRouter) resumeID(userID string, req *http.Request) (uint, int, error) {
+	value := req.Header.Get("Last-Event-ID")
+	name := "Last-Event-ID"
+	if value == "" {
+		value = req.URL.Query().Get("since")
+		name = "since"
+	}
+	if value == "" {
+		latestID, err := r.db.GetLatestChangeID(userID)
+		if err != nil {
+			return 0, http.StatusInternalServerError, fmt.Errorf("failed to get latest change: %w", err)
+		}
+		return latestID, 0, nil
+	}
+
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, http.StatusBadRequest, fmt.Errorf("invalid %s %q", name, value)
+	}
+	return uint(id), 0, nil
+}
+
+// writeChanges sends all changes after lastID and returns the ID of the last sent change
+func (r *SyncStreamRouter) writeChanges(w http.ResponseWriter, userID string, lastID uint) (uint, error) {
+	for {
+		changes, err := r.db.GetChangesSince(userID, lastID, streamBatchSize)
+		if err != nil {
+			return lastID, err
+		}
+
+		for _, change := range changes {
+			data, err := json.Marshal(change.ToSyncResponse())
+			if err != nil {
+				return lastID, err
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: change\ndata: %s\n\n", change.ID, data); err != nil {
+				return lastID, err
+			}
+			lastID = change.ID
+		}
+
+		if len(changes) < streamBatchSize {
+			return lastID, nil
+		}
+	}
+}
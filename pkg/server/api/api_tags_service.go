@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/ya-breeze/diary.be/pkg/database"
+	"github.com/ya-breeze/diary.be/pkg/generated/goserver"
+	"github.com/ya-breeze/diary.be/pkg/server/common"
+)
+
+type TagsAPIServiceImpl struct {
+	logger *slog.Logger
+	db     database.Storage
+}
+
+func NewTagsAPIService(logger *slog.Logger, db database.Storage) goserver.TagsAPIService {
+	return &TagsAPIServiceImpl{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// GetTags - get all tags with usage statistics
+func (s *TagsAPIServiceImpl) GetTags(ctx context.Context) (goserver.ImplResponse, error) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := ctx.Value(common.UserIDKey).(string)
+	if !ok {
+		s.logger.Error("User ID not found in context")
+		return goserver.Response(401, nil), nil
+	}
+
+	tags, err := s.db.GetTags(userID)
+	if err != nil {
+		s.logger.Error("Failed to get tags", "error", err, "userID", userID)
+		return goserver.Response(500, nil), nil
+	}
+
+	response := make([]goserver.TagResponse, len(tags))
+	for i, tag := range tags {
+		response[i] = goserver.TagResponse{
+			Tag:       tag.Tag,
+			Count:     int32(tag.Count), //nolint:gosec // number of items fits into int32
+			FirstUsed: tag.FirstUsed,
+			LastUsed:  tag.LastUsed,
+		}
+	}
+
+	return goserver.Response(200, response), nil
+}
+
+// MergeTags - merge several tags into one in all diary items
+func (s *TagsAPIServiceImpl) MergeTags(
+	ctx context.Context,
+	tagMergeRequest goserver.TagMergeRequest,
+) (goserver.ImplResponse, error) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := ctx.Value(common.UserIDKey).(string)
+	if !ok {
+		s.logger.Error("User ID not found in context")
+		return goserver.Response(401, nil), nil
+	}
+
+	sources := make([]string, 0, len(tagMergeRequest.Sources))
+	for _, tag := range tagMergeRequest.Sources {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			sources = append(sources, tag)
+		}
+	}
+	target := strings.TrimSpace(tagMergeRequest.Target)
+	if len(sources) == 0 || target == "" {
+		s.logger.Warn("Invalid tag merge request", "userID", userID, "sources", tagMergeRequest.Sources,
+			"target", tagMergeRequest.Target)
+		return goserver.Response(400, nil), nil
+	}
+
+	s.logger.Info("Merging tags", "userID", userID, "sources", sources, "target", target)
+
+	updated, err := s.db.MergeTags(userID, sources, target)
+	if err != nil {
+		s.logger.Error("Failed to merge tags", "error", err, "userID", userID, "sources", sources, "target", target)
+		return goserver.Response(500, nil), nil
+	}
+
+	return goserver.Response(200, goserver.TagsUpdateResponse{
+		UpdatedItems: int32(updated), //nolint:gosec // number of items fits into int32
+	}), nil
+}
+
+// RenameTag - rename a tag in all diary items
+func (s *TagsAPIServiceImpl) RenameTag(
+	ctx context.Context,
+	tagRenameRequest goserver.TagRenameRequest,
+) (goserver.ImplResponse, error) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := ctx.Value(common.UserIDKey).(string)
+	if !ok {
+		s.logger.Error("User ID not found in context")
+		return goserver.Response(401, nil), nil
+	}
+
+	from := strings.TrimSpace(tagRenameRequest.From)
+	to := strings.TrimSpace(tagRenameRequest.To)
+	if from == "" || to == "" {
+		s.logger.Warn("Invalid tag rename request", "userID", userID, "from", tagRenameRequest.From,
+			"to", tagRenameRequest.To)
+		return goserver.Response(400, nil), nil
+	}
+
+	s.logger.Info("Renaming tag", "userID", userID, "from", from, "to", to)
+
+	updated, err := s.db.RenameTag(userID, from, to)
+	if err != nil {
+		s.logger.Error("Failed to rename tag", "error", err, "userID", userID, "from", from, "to", to)
+		return goserver.Response(500, nil), nil
+	}
+
+	return goserver.Response(200, goserver.TagsUpdateResponse{
+		UpdatedItems: int32(updated), //nolint:gosec // number of items fits into int32
+	}), nil
+}
@@ -0,0 +1,152 @@
+package api_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ya-breeze/diary.be/pkg/config"
+	"github.com/ya-breeze/diary.be/pkg/database"
+	"github.com/ya-breeze/diary.be/pkg/database/models"
+	"github.com/ya-breeze/diary.be/pkg/generated/goserver"
+	"github.com/ya-breeze/diary.be/pkg/server/api"
+	"github.com/ya-breeze/diary.be/pkg/server/common"
+)
+
+var _ = Describe("TagsAPIService", func() {
+	var (
+		service goserver.TagsAPIService
+		logger  *slog.Logger
+		storage database.Storage
+		ctx     context.Context
+		userID  string
+	)
+
+	// Create context outside of BeforeEach to avoid fatcontext linting issue
+	userID = "test-user-id"
+	ctx = context.WithValue(context.Background(), common.UserIDKey, userID)
+
+	putItem := func(date string, tags ...string) {
+		Expect(storage.PutItem(userID, &models.Item{
+			Date:  date,
+			Title: "Entry " + date,
+			Body:  "Body " + date,
+			Tags:  models.StringList(tags),
+		})).To(Succeed())
+	}
+
+	getTags := func() []goserver.TagResponse {
+		response, err := service.GetTags(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.Code).To(Equal(200))
+		tags, ok := response.Body.([]goserver.TagResponse)
+		Expect(ok).To(BeTrue())
+		return tags
+	}
+
+	BeforeEach(func() {
+		logger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		cfg := &config.Config{
+			DBPath: ":memory:",
+		}
+		storage = database.NewStorage(logger, cfg)
+		Expect(storage.Open()).To(Succeed())
+
+		service = api.NewTagsAPIService(logger, storage)
+
+		putItem("2024-01-10", "work", "travel")
+		putItem("2024-01-15", "trip", "personal")
+		putItem("2024-02-01", "travel", "trip")
+		putItem("2024-02-05", "health")
+	})
+
+	AfterEach(func() {
+		storage.Close()
+	})
+
+	It("should return unauthorized without user ID", func() {
+		response, err := service.GetTags(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.Code).To(Equal(401))
+	})
+
+	It("should list tags with usage statistics, most used first", func() {
+		Expect(getTags()).To(Equal([]goserver.TagResponse{
+			{Tag: "travel", Count: 2, FirstUsed: "2024-01-10", LastUsed: "2024-02-01"},
+			{Tag: "trip", Count: 2, FirstUsed: "2024-01-15", LastUsed: "2024-02-01"},
+			{Tag: "health", Count: 1, FirstUsed: "2024-02-05", LastUsed: "2024-02-05"},
+			{Tag: "personal", Count: 1, FirstUsed: "2024-01-15", LastUsed: "2024-01-15"},
+			{Tag: "work", Count: 1, FirstUsed: "2024-01-10", LastUsed: "2024-01-10"},
+		}))
+	})
+
+	It("should not list tags of other users", func() {
+		Expect(storage.PutItem("other-user", &models.Item{
+			Date: "2024-01-10", Title: "Other", Tags: models.StringList{"secret"},
+		})).To(Succeed())
+
+		for _, tag := range getTags() {
+			Expect(tag.Tag).NotTo(Equal("secret"))
+		}
+	})
+
+	It("should rename a tag and merge it with an existing one", func() {
+		latestID, err := storage.GetLatestChangeID(userID)
+		Expect(err).NotTo(HaveOccurred())
+
+		response, err := service.RenameTag(ctx, goserver.TagRenameRequest{From: "trip", To: "travel"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.Code).To(Equal(200))
+		Expect(response.Body).To(Equal(goserver.TagsUpdateResponse{UpdatedItems: 2}))
+
+		item, err := storage.GetItem(userID, "2024-01-15")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(item.Tags).To(Equal(models.StringList{"travel", "personal"}))
+		item, err = storage.GetItem(userID, "2024-02-01")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(item.Tags).To(Equal(models.StringList{"travel"}))
+
+		// Every updated item produces a change record for synchronization
+		changes, err := storage.GetChangesSince(userID, latestID, 100)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes).To(HaveLen(2))
+		for _, change := range changes {
+			Expect(change.OperationType).To(Equal(models.OperationTypeUpdated))
+			Expect(change.ItemSnapshot.Tags).To(ContainElement("travel"))
+			Expect(change.ItemSnapshot.Tags).NotTo(ContainElement("trip"))
+		}
+	})
+
+	It("should merge several tags into one", func() {
+		response, err := service.MergeTags(ctx, goserver.TagMergeRequest{
+			Sources: []string{"work", "personal", "health"},
+			Target:  "life",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.Code).To(Equal(200))
+		Expect(response.Body).To(Equal(goserver.TagsUpdateResponse{UpdatedItems: 3}))
+
+		Expect(getTags()).To(ContainElement(
+			goserver.TagResponse{Tag: "life", Count: 3, FirstUsed: "2024-01-10", LastUsed: "2024-02-05"}))
+	})
+
+	It("should not touch items without the renamed tag", func() {
+		response, err := service.RenameTag(ctx, goserver.TagRenameRequest{From: "unknown", To: "travel"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.Code).To(Equal(200))
+		Expect(response.Body).To(Equal(goserver.TagsUpdateResponse{UpdatedItems: 0}))
+	})
+
+	It("should reject empty tags", func() {
+		response, err := service.RenameTag(ctx, goserver.TagRenameRequest{From: " ", To: "travel"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.Code).To(Equal(400))
+
+		response, err = service.MergeTags(ctx, goserver.TagMergeRequest{Sources: []string{"trip"}, Target: ""})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.Code).To(Equal(400))
+	})
+})
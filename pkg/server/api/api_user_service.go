@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"net/http"
 
+	"github.com/ya-breeze/diary.be/pkg/config"
 	"github.com/ya-breeze/diary.be/pkg/database"
 	"github.com/ya-breeze/diary.be/pkg/generated/goserver"
 	"github.com/ya-breeze/diary.be/pkg/server/common"
@@ -12,12 +14,14 @@ import (
 
 type UserAPIServiceImpl struct {
 	logger *slog.Logger
+	cfg    *config.Config
 	db     database.Storage
 }
 
-func NewUserAPIService(logger *slog.Logger, db database.Storage) goserver.UserAPIService {
+func NewUserAPIService(logger *slog.Logger, cfg *config.Config, db database.Storage) goserver.UserAPIService {
 	return &UserAPIServiceImpl{
 		logger: logger,
+		cfg:    cfg,
 		db:     db,
 	}
 }
@@ -39,3 +43,43 @@ func (s *UserAPIServiceImpl) GetUser(ctx context.Context) (goserver.ImplResponse
 
 	return goserver.Response(200, user.FromDB()), nil
 }
+
+// GetUserUsage - return the storage used by the user and the remaining quota
+func (s *UserAPIServiceImpl) GetUserUsage(ctx context.Context) (goserver.ImplResponse, error) {
+	userID, ok := ctx.Value(common.UserIDKey).(string)
+	if !ok {
+		s.logger.Error("Failed to get user ID from context")
+		return goserver.Response(http.StatusUnauthorized, nil), nil
+	}
+
+	usage, err := s.db.GetUsage(userID)
+	if err != nil {
+		s.logger.Error("Failed to get usage", "error", err, "userID", userID)
+		return goserver.Response(http.StatusInternalServerError, nil), nil
+	}
+
+	return goserver.Response(http.StatusOK, usageResponse(usage, database.UserQuota(s.cfg))), nil
+}
+
+// usageResponse converts the usage of a user to the API response, remaining values don't go below zero
+func usageResponse(usage *database.Usage, quota database.Quota) goserver.UserUsage {
+	res := goserver.UserUsage{
+		AssetBytes:   usage.AssetBytes,
+		AssetFiles:   int32(usage.AssetFiles), //nolint:gosec // file counts are far below the int32 limit
+		TrashedBytes: usage.TrashedBytes,
+		Items:        int32(usage.Items), //nolint:gosec // item counts are far below the int32 limit
+		BodyBytes:    usage.BodyBytes,
+	}
+	if quota.Bytes > 0 {
+		remaining := max(quota.Bytes-usage.AssetBytes, 0)
+		res.QuotaBytes = &quota.Bytes
+		res.RemainingBytes = &remaining
+	}
+	if quota.Files > 0 {
+		files := int32(quota.Files) //nolint:gosec // quotas are far below the int32 limit
+		remaining := max(files-res.AssetFiles, 0)
+		res.QuotaFiles = &files
+		res.RemainingFiles = &remaining
+	}
+	return res
+}
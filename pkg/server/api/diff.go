@@ -0,0 +1,157 @@
+package api
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/ya-breeze/diary.be/pkg/generated/goserver"
+)
+
+const (
+	DiffOpEqual  = "equal"
+	DiffOpInsert = "insert"
+	DiffOpDelete = "delete"
+)
+
+// maxDiffCells caps the work of matching lines: if the number of changed lines of both texts
+// multiplied exceeds it, the changed lines are reported as replaced without looking for matches
+const maxDiffCells = 10_000_000
+
+// DiffLines returns a line-based diff that turns from into to. Lines are matched by their
+// longest common subsequence, removed lines are listed before the lines that replace them.
+// The subsequence is found with Hirschberg's algorithm, which needs memory linear in the
+// number of lines.
+func DiffLines(from, to string) []goserver.DiffLine {
+	a := splitLines(from)
+	b := splitLines(to)
+
+	res := make([]goserver.DiffLine, 0, max(len(a), len(b)))
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		res = append(res, goserver.DiffLine{Op: DiffOpEqual, Text: a[prefix]})
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	changedA, changedB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if len(changedA)*len(changedB) > maxDiffCells {
+		res = appendReplaced(res, changedA, changedB)
+	} else {
+		res = appendDiff(res, changedA, changedB)
+	}
+	for _, line := range a[len(a)-suffix:] {
+		res = append(res, goserver.DiffLine{Op: DiffOpEqual, Text: line})
+	}
+
+	return deletesFirst(res)
+}
+
+// appendDiff appends the diff of a and b, splitting a in halves and b where the longest common
+// subsequences of the halves meet
+func appendDiff(res []goserver.DiffLine, a, b []string) []goserver.DiffLine {
+	switch {
+	case len(a) == 0 || len(b) == 0:
+		return appendReplaced(res, a, b)
+	case len(a) == 1:
+		j := slices.Index(b, a[0])
+		if j < 0 {
+			return appendReplaced(res, a, b)
+		}
+		res = appendReplaced(res, nil, b[:j])
+		res = append(res, goserver.DiffLine{Op: DiffOpEqual, Text: a[0]})
+		return appendReplaced(res, nil, b[j+1:])
+	}
+
+	mid := len(a) / 2
+	forward := lcsLengths(a[:mid], b)
+	backward := lcsLengths(reversed(a[mid:]), reversed(b))
+	split, best := 0, -1
+	for k := range len(b) + 1 {
+		if length := forward[k] + backward[len(b)-k]; length > best {
+			split, best = k, length
+		}
+	}
+
+	res = appendDiff(res, a[:mid], b[:split])
+	return appendDiff(res, a[mid:], b[split:])
+}
+
+// lcsLengths returns the lengths of the longest common subsequences of a and every prefix of b
+func lcsLengths(a, b []string) []int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			if a[i] == b[j] {
+				cur[j+1] = prev[j] + 1
+			} else {
+				cur[j+1] = max(prev[j+1], cur[j])
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev
+}
+
+// appendReplaced appends the lines of a as deleted and the lines of b as inserted
+func appendReplaced(res []goserver.DiffLine, a, b []string) []goserver.DiffLine {
+	for _, line := range a {
+		res = append(res, goserver.DiffLine{Op: DiffOpDelete, Text: line})
+	}
+	for _, line := range b {
+		res = append(res, goserver.DiffLine{Op: DiffOpInsert, Text: line})
+	}
+	return res
+}
+
+// deletesFirst moves the deleted lines of every run of changed lines before the inserted ones
+func deletesFirst(lines []goserver.DiffLine) []goserver.DiffLine {
+	for start := 0; start < len(lines); {
+		if lines[start].Op == DiffOpEqual {
+			start++
+			continue
+		}
+		end := start
+		for end < len(lines) && lines[end].Op != DiffOpEqual {
+			end++
+		}
+		slices.SortStableFunc(lines[start:end], func(x, y goserver.DiffLine) int {
+			return strings.Compare(x.Op, y.Op) // "delete" sorts before "insert"
+		})
+		start = end
+	}
+	return lines
+}
+
+func reversed(lines []string) []string {
+	res := slices.Clone(lines)
+	slices.Reverse(res)
+	return res
+}
+
+// DiffTags returns the tags present only in to (added) and only in from (removed)
+func DiffTags(from, to []string) (added, removed []string) {
+	for _, tag := range to {
+		if !slices.Contains(from, tag) {
+			added = append(added, tag)
+		}
+	}
+	for _, tag := range from {
+		if !slices.Contains(to, tag) {
+			removed = append(removed, tag)
+		}
+	}
+	return added, removed
+}
+
+// splitLines splits text into lines, an empty text has no lines
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
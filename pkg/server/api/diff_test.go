@@ -0,0 +1,116 @@
+package api_test
+
+import (
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ya-breeze/diary.be/pkg/generated/goserver"
+	"github.com/ya-breeze/diary.be/pkg/server/api"
+)
+
+var _ = Describe("DiffLines", func() {
+	equal := func(text string) goserver.DiffLine { return goserver.DiffLine{Op: api.DiffOpEqual, Text: text} }
+	insert := func(text string) goserver.DiffLine { return goserver.DiffLine{Op: api.DiffOpInsert, Text: text} }
+	del := func(text string) goserver.DiffLine { return goserver.DiffLine{Op: api.DiffOpDelete, Text: text} }
+
+	DescribeTable("compares lines",
+		func(from, to string, expected []goserver.DiffLine) {
+			Expect(api.DiffLines(from, to)).To(Equal(expected))
+		},
+		Entry("identical", "a\nb", "a\nb", []goserver.DiffLine{equal("a"), equal("b")}),
+		Entry("both empty", "", "", []goserver.DiffLine{}),
+		Entry("inserted line", "a\nc", "a\nb\nc", []goserver.DiffLine{equal("a"), insert("b"), equal("c")}),
+		Entry("deleted line", "a\nb\nc", "a\nc", []goserver.DiffLine{equal("a"), del("b"), equal("c")}),
+		Entry("changed line", "a\nb\nc", "a\nB\nc", []goserver.DiffLine{equal("a"), del("b"), insert("B"), equal("c")}),
+		Entry("from empty", "", "a", []goserver.DiffLine{insert("a")}),
+		Entry("CRLF and trailing newline", "a\r\nb\r\n", "a\nb", []goserver.DiffLine{equal("a"), equal("b")}),
+		Entry("replaced block", "a\nb\nc\nd", "a\nx\ny\nd", []goserver.DiffLine{
+			equal("a"), del("b"), del("c"), insert("x"), insert("y"), equal("d"),
+		}),
+	)
+
+	// sides returns the texts the diff turns into each other and the number of unchanged lines
+	sides := func(diff []goserver.DiffLine) (string, string, int) {
+		var from, to []string
+		equalLines := 0
+		for _, line := range diff {
+			if line.Op != api.DiffOpInsert {
+				from = append(from, line.Text)
+			}
+			if line.Op != api.DiffOpDelete {
+				to = append(to, line.Text)
+			}
+			if line.Op == api.DiffOpEqual {
+				equalLines++
+			}
+		}
+		return strings.Join(from, "\n"), strings.Join(to, "\n"), equalLines
+	}
+
+	It("keeps the longest common subsequence of lines", func() {
+		rng := rand.New(rand.NewPCG(1, 2)) //nolint:gosec // deterministic test data
+		randomText := func() string {
+			lines := make([]string, rng.IntN(30))
+			for i := range lines {
+				lines[i] = strconv.Itoa(rng.IntN(5))
+			}
+			return strings.Join(lines, "\n")
+		}
+		lcsLength := func(a, b []string) int {
+			lcs := make([][]int, len(a)+1)
+			for i := range lcs {
+				lcs[i] = make([]int, len(b)+1)
+			}
+			for i := len(a) - 1; i >= 0; i-- {
+				for j := len(b) - 1; j >= 0; j-- {
+					if a[i] == b[j] {
+						lcs[i][j] = lcs[i+1][j+1] + 1
+					} else {
+						lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+					}
+				}
+			}
+			return lcs[0][0]
+		}
+
+		for range 200 {
+			from, to := randomText(), randomText()
+			gotFrom, gotTo, equalLines := sides(api.DiffLines(from, to))
+			Expect(gotFrom).To(Equal(from))
+			Expect(gotTo).To(Equal(to))
+			if from != "" && to != "" {
+				Expect(equalLines).To(Equal(lcsLength(strings.Split(from, "\n"), strings.Split(to, "\n"))))
+			}
+		}
+	})
+
+	It("diffs large texts", func() {
+		lines := func(prefix string) string {
+			res := make([]string, 20000)
+			for i := range res {
+				res[i] = prefix + strconv.Itoa(i)
+			}
+			return strings.Join(res, "\n")
+		}
+		from, to := "same\n"+lines("a"), "same\n"+lines("b")
+
+		diff := api.DiffLines(from, to)
+		Expect(diff).To(HaveLen(40001))
+		gotFrom, gotTo, equalLines := sides(diff)
+		Expect(gotFrom).To(Equal(from))
+		Expect(gotTo).To(Equal(to))
+		Expect(equalLines).To(Equal(1))
+	})
+})
+
+var _ = Describe("DiffTags", func() {
+	It("reports added and removed tags", func() {
+		added, removed := api.DiffTags([]string{"a", "b"}, []string{"b", "c"})
+		Expect(added).To(Equal([]string{"c"}))
+		Expect(removed).To(Equal([]string{"a"}))
+	})
+})
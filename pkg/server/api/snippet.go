@@ -0,0 +1,220 @@
+package api
+
+import (
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ya-breeze/diary.be/pkg/database"
+)
+
+const (
+	// snippetLength is the approximate number of characters of context returned around matches
+	snippetLength = 240
+	// snippetLead is how many characters are kept before the first match
+	snippetLead     = 60
+	snippetEllipsis = "…"
+)
+
+type textSpan struct {
+	start, end int
+}
+
+// BuildSnippet returns an excerpt of text around the matches of the search terms.
+// The excerpt is HTML-escaped and every match is wrapped in <mark></mark>, so it can be
+// rendered as is. If nothing matches, the beginning of the text is returned without marks.
+func BuildSnippet(text string, terms []database.SearchTerm) string {
+	snippet, _ := buildSnippet(text, terms)
+	return snippet
+}
+
+// BuildItemSnippet returns the snippet of the body of an item, or of its title if only the title
+// matches the search terms
+func BuildItemSnippet(title, body string, terms []database.SearchTerm) string {
+	snippet, matched := buildSnippet(body, terms)
+	if matched {
+		return snippet
+	}
+	if titleSnippet, titleMatched := buildSnippet(title, terms); titleMatched {
+		return titleSnippet
+	}
+	return snippet
+}
+
+// buildSnippet returns the snippet of the text and whether any of the terms matched
+func buildSnippet(text string, terms []database.SearchTerm) (string, bool) {
+	words := splitWords(text)
+	matches := findMatches(text, words, terms)
+
+	start := 0
+	if len(matches) > 0 {
+		start = max(0, matches[0].start-snippetLead)
+	}
+	start = alignToWordStart(text, words, start)
+	end := min(len(text), start+snippetLength)
+	end = alignToWordEnd(text, words, end)
+
+	var sb strings.Builder
+	if start > 0 {
+		sb.WriteString(snippetEllipsis)
+	}
+	pos := start
+	for _, m := range matches {
+		if m.start < pos || m.end > end {
+			continue
+		}
+		sb.WriteString(escapeSnippetText(text[pos:m.start]))
+		sb.WriteString("<mark>")
+		sb.WriteString(escapeSnippetText(text[m.start:m.end]))
+		sb.WriteString("</mark>")
+		pos = m.end
+	}
+	sb.WriteString(escapeSnippetText(text[pos:end]))
+	if end < len(text) {
+		sb.WriteString(snippetEllipsis)
+	}
+
+	return strings.TrimSpace(sb.String()), len(matches) > 0
+}
+
+// splitWords returns byte spans of the letter/digit runs of text
+func splitWords(text string) []textSpan {
+	var words []textSpan
+	start := -1
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case isWord && start < 0:
+			start = i
+		case !isWord && start >= 0:
+			words = append(words, textSpan{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		words = append(words, textSpan{start, len(text)})
+	}
+	return words
+}
+
+// findMatches returns sorted, non-overlapping spans of text matching any of the terms. Words are
+// compared normalized like by the search index. If no term matches whole words, words containing the
+// terms are matched instead, as found by the LIKE search that is used without the index.
+func findMatches(text string, words []textSpan, terms []database.SearchTerm) []textSpan {
+	normalized := make([]string, len(words))
+	for i, w := range words {
+		normalized[i] = database.NormalizeSearchWord(text[w.start:w.end])
+	}
+
+	matches := matchTerms(words, normalized, terms, termMatchesAt)
+	if len(matches) == 0 {
+		matches = matchTerms(words, normalized, terms, termContainedAt)
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i].start < matches[j].start })
+	res := matches[:0]
+	for _, m := range matches {
+		if len(res) > 0 && m.start < res[len(res)-1].end {
+			res[len(res)-1].end = max(res[len(res)-1].end, m.end)
+			continue
+		}
+		res = append(res, m)
+	}
+	return res
+}
+
+func matchTerms(words []textSpan, normalized []string, terms []database.SearchTerm,
+	matchesAt func(words []string, term database.SearchTerm) bool,
+) []textSpan {
+	var matches []textSpan
+	for _, term := range terms {
+		for i := 0; i+len(term.Words) <= len(words); i++ {
+			if matchesAt(normalized[i:], term) {
+				matches = append(matches, textSpan{words[i].start, words[i+len(term.Words)-1].end})
+			}
+		}
+	}
+	return matches
+}
+
+// termMatchesAt reports whether the normalized words start with the words of the term
+func termMatchesAt(words []string, term database.SearchTerm) bool {
+	for i, expected := range term.Words {
+		if term.Prefix && i == len(term.Words)-1 {
+			if !strings.HasPrefix(words[i], expected) {
+				return false
+			}
+			continue
+		}
+		if words[i] != expected {
+			return false
+		}
+	}
+	return true
+}
+
+// termContainedAt reports whether the text of the term is a substring of the normalized words
+func termContainedAt(words []string, term database.SearchTerm) bool {
+	last := len(term.Words) - 1
+	if last == 0 {
+		return strings.Contains(words[0], term.Words[0])
+	}
+	if !strings.HasSuffix(words[0], term.Words[0]) || !strings.HasPrefix(words[last], term.Words[last]) {
+		return false
+	}
+	for i := 1; i < last; i++ {
+		if words[i] != term.Words[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// alignToWordStart moves pos back to the beginning of the word it falls into
+func alignToWordStart(text string, words []textSpan, pos int) int {
+	for _, w := range words {
+		if w.start < pos && pos < w.end {
+			return w.start
+		}
+	}
+	for pos > 0 && !utf8.RuneStart(text[pos]) {
+		pos--
+	}
+	return pos
+}
+
+// alignToWordEnd moves pos forward to the end of the word it falls into
+func alignToWordEnd(text string, words []textSpan, pos int) int {
+	for _, w := range words {
+		if w.start < pos && pos < w.end {
+			return w.end
+		}
+	}
+	for pos < len(text) && !utf8.RuneStart(text[pos]) {
+		pos++
+	}
+	return pos
+}
+
+// escapeSnippetText collapses whitespace runs into single spaces so the snippet stays inline and escapes HTML
+func escapeSnippetText(s string) string {
+	var sb strings.Builder
+	space := false
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			space = true
+			continue
+		}
+		if space {
+			sb.WriteByte(' ')
+			space = false
+		}
+		sb.WriteRune(r)
+	}
+	if space {
+		sb.WriteByte(' ')
+	}
+	return html.EscapeString(sb.String())
+}
@@ -0,0 +1,48 @@
+package api_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ya-breeze/diary.be/pkg/database"
+	"github.com/ya-breeze/diary.be/pkg/server/api"
+)
+
+var _ = Describe("BuildSnippet", func() {
+	DescribeTable("highlights matches",
+		func(text, search, expected string) {
+			Expect(api.BuildSnippet(text, database.ParseSearchTerms(search))).To(Equal(expected))
+		},
+		Entry("single word, case insensitive", "A day at the Beach", "beach", "A day at the <mark>Beach</mark>"),
+		Entry("whole words only", "beaches and beach", "beach", "beaches and <mark>beach</mark>"),
+		Entry("prefix", "vacation plans", "vac*", "<mark>vacation</mark> plans"),
+		Entry("phrase", "a new day, a new\nbeginning", `"new beginning"`, "a new day, a <mark>new beginning</mark>"),
+		Entry("negated terms are not highlighted", "sun and rain", "sun NOT rain", "<mark>sun</mark> and rain"),
+		Entry("HTML is escaped", "<b>tag</b> & text", "tag", "&lt;b&gt;<mark>tag</mark>&lt;/b&gt; &amp; text"),
+		Entry("no match returns the beginning", "nothing to see", "beach", "nothing to see"),
+		Entry("diacritics", "Café in Zürich", "cafe zurich", "<mark>Café</mark> in <mark>Zürich</mark>"),
+		Entry("diacritics in the query", "a cafe", "Café", "a <mark>cafe</mark>"),
+		Entry("prefix with diacritics", "Élan vital", "ela*", "<mark>Élan</mark> vital"),
+		Entry("substrings found by LIKE search", "beaches and dunes", "beach", "<mark>beaches</mark> and dunes"),
+		Entry("phrases found by LIKE search", "summer vacation", `"mer vac"`, "<mark>summer vacation</mark>"),
+	)
+
+	It("falls back to the title if only the title matches", func() {
+		terms := database.ParseSearchTerms("beach")
+		Expect(api.BuildItemSnippet("Beach day", "We swam all day", terms)).To(Equal("<mark>Beach</mark> day"))
+		Expect(api.BuildItemSnippet("Beach day", "Back at the beach", terms)).To(Equal("Back at the <mark>beach</mark>"))
+		Expect(api.BuildItemSnippet("Home", "We swam all day", terms)).To(Equal("We swam all day"))
+	})
+
+	It("cuts long text around the first match", func() {
+		text := strings.Repeat("lorem ipsum ", 50) + "beach " + strings.Repeat("dolor sit ", 50)
+		snippet := api.BuildSnippet(text, database.ParseSearchTerms("beach"))
+
+		Expect(snippet).To(HavePrefix("…"))
+		Expect(snippet).To(HaveSuffix("…"))
+		Expect(snippet).To(ContainSubstring("<mark>beach</mark>"))
+		Expect(len(snippet)).To(BeNumerically("<", 300))
+	})
+})
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ya-breeze/diary.be/pkg/server/assets"
+)
+
+// assetCollectionInterval is how often orphaned assets are moved to the trash
+const assetCollectionInterval = 24 * time.Hour
+
+// runAssetCollection moves unreferenced assets older than the grace period to the trash,
+// on start and then every assetCollectionInterval until the context is done
+func runAssetCollection(
+	ctx context.Context, logger *slog.Logger, collector *assets.GarbageCollector, grace time.Duration,
+) {
+	ticker := time.NewTicker(assetCollectionInterval)
+	defer ticker.Stop()
+
+	for {
+		report, err := collector.Collect(ctx, time.Now().Add(-grace), false)
+		if err != nil {
+			logger.Error("Failed to collect orphaned assets", "error", err)
+		} else if len(report.Orphans) > 0 || len(report.TempFiles) > 0 {
+			logger.Info("Orphaned assets collected", "orphans", len(report.Orphans),
+				"bytes", report.OrphansSize(), "tempFiles", len(report.TempFiles), "grace", grace)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
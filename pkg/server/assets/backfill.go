@@ -0,0 +1,94 @@
+package assets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/ya-breeze/diary.be/pkg/database"
+	"github.com/ya-breeze/diary.be/pkg/database/models"
+)
+
+// BackfillAssets seeds an empty asset registry from the blobs of the users, so assets uploaded before
+// the registry was introduced can be listed. Variants, the trash and unfinished uploads are skipped.
+func BackfillAssets(ctx context.Context, logger *slog.Logger, db database.Storage, blobs BlobStore) error {
+	found, err := db.HasAssets()
+	if err != nil || found {
+		return err
+	}
+
+	list, err := blobs.List(ctx, "")
+	if err != nil {
+		return err
+	}
+	userBlobs := make(map[string][]BlobInfo)
+	for _, blob := range list {
+		// Skip files, service directories like the trash and files in the service directories of the users
+		userID, name, ok := strings.Cut(blob.Key, "/")
+		if !ok || strings.HasPrefix(userID, ".") || strings.Contains(name, "/") || strings.HasPrefix(name, ".") {
+			continue
+		}
+		userBlobs[userID] = append(userBlobs[userID], blob)
+	}
+
+	for _, userID := range slices.Sorted(maps.Keys(userBlobs)) {
+		assets := make([]*models.Asset, 0, len(userBlobs[userID]))
+		for _, blob := range userBlobs[userID] {
+			asset, err := scanBlob(ctx, blobs, blob)
+			if err != nil {
+				return err
+			}
+			assets = append(assets, asset)
+		}
+		if err := db.ImportAssets(userID, assets); err != nil {
+			return err
+		}
+		logger.Info("Assets restored from asset storage", "userID", userID, "count", len(assets))
+	}
+
+	return nil
+}
+
+// scanBlob hashes the content of the blob, its size is counted as the stored size may differ.
+// The header of the blob tells if it was stored encrypted, as the asset isn't registered yet.
+func scanBlob(ctx context.Context, blobs BlobStore, blob BlobInfo) (*models.Asset, error) {
+	encrypted := false
+	if store, ok := blobs.(*EncryptedStore); ok {
+		var err error
+		if encrypted, err = store.isEncrypted(ctx, blob.Key); err != nil {
+			return nil, fmt.Errorf("failed to read asset %q: %w", blob.Key, err)
+		}
+		if !encrypted {
+			blobs = store.store
+		}
+	}
+
+	r, err := blobs.Get(ctx, blob.Key, 0, -1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open asset %q: %w", blob.Key, err)
+	}
+	defer r.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read asset %q: %w", blob.Key, err)
+	}
+
+	name := blob.Key[strings.LastIndex(blob.Key, "/")+1:]
+	return &models.Asset{
+		Name:         name,
+		OriginalName: name,
+		Size:         size,
+		ContentType:  ContentTypeByName(name),
+		Hash:         hex.EncodeToString(hash.Sum(nil)),
+		UploadedAt:   blob.ModTime,
+		Encrypted:    encrypted,
+	}, nil
+}
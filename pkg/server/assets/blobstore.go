@@ -0,0 +1,195 @@
+package assets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ya-breeze/diary.be/pkg/config"
+)
+
+// Asset storage backends, see config.Config.AssetStorage
+const (
+	AssetStorageLocal = "local"
+	AssetStorageS3    = "s3"
+)
+
+var (
+	// ErrBlobNotFound is returned for keys no blob is stored under
+	ErrBlobNotFound = errors.New("blob not found")
+	// ErrInvalidKey is returned for keys that can't name a blob, like directories of the local store
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// BlobInfo describes a stored blob
+type BlobInfo struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// BlobStore keeps the asset files. Keys are slash separated paths: the assets of a user are stored
+// under <userID>/<name>, their image variants under <userID>/.variants/<size>/<name> and trashed
+// assets under .trash/<userID>/<name>.
+type BlobStore interface {
+	// Put stores size bytes of src under the key, replacing an existing blob. Readers never see a
+	// partially written blob.
+	Put(ctx context.Context, key string, src io.Reader, size int64) error
+	// Get reads length bytes of the blob starting at offset, a negative length reads to the end
+	Get(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+	Stat(ctx context.Context, key string) (BlobInfo, error)
+	// Delete removes the blob, removing a blob that doesn't exist is no error
+	Delete(ctx context.Context, key string) error
+	// List returns the blobs whose keys start with the prefix sorted by key
+	List(ctx context.Context, prefix string) ([]BlobInfo, error)
+}
+
+// blobMover is implemented by stores that move blobs without reading them, see moveBlob
+type blobMover interface {
+	Move(ctx context.Context, src, dst string) error
+}
+
+// NewBlobStore returns the asset storage backend selected by the config
+func NewBlobStore(cfg *config.Config) (BlobStore, error) {
+	switch cfg.AssetStorage {
+	case "", AssetStorageLocal:
+		return NewLocalStore(cfg.AssetPath), nil
+	case AssetStorageS3:
+		return NewS3Store(cfg)
+	default:
+		return nil, fmt.Errorf("unknown asset storage %q", cfg.AssetStorage)
+	}
+}
+
+// assetKey returns the key of an asset, the name is relative to the asset directory of the user
+func assetKey(userID, name string) string {
+	return userID + "/" + name
+}
+
+// trashKey returns the key of a trashed asset, see TrashDirName
+func trashKey(userID, name string) string {
+	return TrashDirName + "/" + userID + "/" + name
+}
+
+// moveBlob moves the blob to another key
+func moveBlob(ctx context.Context, store BlobStore, src, dst string) error {
+	if mover, ok := store.(blobMover); ok {
+		return mover.Move(ctx, src, dst)
+	}
+
+	info, err := store.Stat(ctx, src)
+	if err != nil {
+		return err
+	}
+	r, err := store.Get(ctx, src, 0, -1)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	if err := store.Put(ctx, dst, r, info.Size); err != nil {
+		return err
+	}
+	return store.Delete(ctx, src)
+}
+
+// Blob is an opened blob. It reads the content with ranged Gets from the offset it was seeked to,
+// so http.ServeContent only fetches the requested ranges.
+type Blob struct {
+	ctx  context.Context //nolint:containedctx // the context of the request the blob is served for
+	get  rangeReader
+	info BlobInfo
+
+	offset int64
+	body   io.ReadCloser
+}
+
+// blobOpener is implemented by stores that keep state for the reads of an opened blob, like the
+// encryption header of the EncryptedStore
+type blobOpener interface {
+	openBlob(ctx context.Context, key string) (BlobInfo, rangeReader, error)
+}
+
+// rangeReader reads a range of an opened blob like BlobStore.Get
+type rangeReader func(ctx context.Context, offset, length int64) (io.ReadCloser, error)
+
+// OpenBlob opens the blob stored under the key
+func OpenBlob(ctx context.Context, store BlobStore, key string) (*Blob, error) {
+	if opener, ok := store.(blobOpener); ok {
+		info, get, err := opener.openBlob(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		return &Blob{ctx: ctx, get: get, info: info}, nil
+	}
+
+	info, err := store.Stat(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	get := func(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+		return store.Get(ctx, key, offset, length)
+	}
+	return &Blob{ctx: ctx, get: get, info: info}, nil
+}
+
+// Name returns the key of the blob
+func (b *Blob) Name() string {
+	return b.info.Key
+}
+
+// Info returns the size and modification time of the blob
+func (b *Blob) Info() BlobInfo {
+	return b.info
+}
+
+func (b *Blob) Read(p []byte) (int, error) {
+	if b.offset >= b.info.Size {
+		return 0, io.EOF
+	}
+	if b.body == nil {
+		body, err := b.get(b.ctx, b.offset, -1)
+		if err != nil {
+			return 0, err
+		}
+		b.body = body
+	}
+	n, err := b.body.Read(p)
+	b.offset += int64(n)
+	return n, err
+}
+
+func (b *Blob) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += b.offset
+	case io.SeekEnd:
+		offset += b.info.Size
+	default:
+		return 0, fmt.Errorf("invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("negative offset %d", offset)
+	}
+
+	if offset != b.offset && b.body != nil {
+		err := b.body.Close()
+		b.body = nil
+		if err != nil {
+			return 0, err
+		}
+	}
+	b.offset = offset
+	return offset, nil
+}
+
+func (b *Blob) Close() error {
+	if b.body == nil {
+		return nil
+	}
+	err := b.body.Close()
+	b.body = nil
+	return err
+}
@@ -0,0 +1,353 @@
+package assets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/ya-breeze/diary.be/pkg/encryption"
+)
+
+// AssetRegistry records which assets are stored encrypted, see models.Asset.Encrypted
+type AssetRegistry interface {
+	// IsAssetEncrypted reports whether the asset is stored encrypted. Unregistered assets are reported
+	// as encrypted, as they were stored by an EncryptedStore.
+	IsAssetEncrypted(userID, name string) (bool, error)
+	// SetAssetsEncrypted records that the assets of the user were encrypted
+	SetAssetsEncrypted(userID string, names []string) error
+}
+
+// EncryptedStore encrypts the blobs of another store with the data keys of the users owning them,
+// see encryption.Cipher.EncryptReader. Assets stored before encryption was enabled are recorded in
+// the registry and are read as they are until EncryptBlobs rewrites them, their trashed copies and
+// variants follow them. List reports the stored sizes, which include the overhead of the encryption.
+type EncryptedStore struct {
+	store    BlobStore
+	keys     *encryption.Keyring
+	registry AssetRegistry
+}
+
+func NewEncryptedStore(store BlobStore, keys *encryption.Keyring, registry AssetRegistry) *EncryptedStore {
+	return &EncryptedStore{store: store, keys: keys, registry: registry}
+}
+
+// WithEncryption wraps the store into an EncryptedStore, the store is returned as it is if keys
+// is nil because encryption at rest is disabled
+func WithEncryption(store BlobStore, keys *encryption.Keyring, registry AssetRegistry) BlobStore {
+	if keys == nil {
+		return store
+	}
+	return NewEncryptedStore(store, keys, registry)
+}
+
+// Put encrypts the content, variants of assets stored before encryption was enabled are stored
+// as they are like their assets
+func (s *EncryptedStore) Put(ctx context.Context, key string, src io.Reader, size int64) error {
+	encrypted, err := s.encrypted(key)
+	if err != nil {
+		return err
+	}
+	if !encrypted {
+		return s.store.Put(ctx, key, src, size)
+	}
+	return s.put(ctx, key, src, size)
+}
+
+func (s *EncryptedStore) Get(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	encrypted, err := s.encrypted(key)
+	if err != nil {
+		return nil, err
+	}
+	if !encrypted {
+		return s.store.Get(ctx, key, offset, length)
+	}
+
+	info, err := s.store.Stat(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := newSealedBlob(key, info)
+	if err != nil {
+		return nil, err
+	}
+	return s.getRange(ctx, sealed, offset, length)
+}
+
+// Stat returns the size of the plaintext of the blob
+func (s *EncryptedStore) Stat(ctx context.Context, key string) (BlobInfo, error) {
+	encrypted, err := s.encrypted(key)
+	if err != nil {
+		return BlobInfo{}, err
+	}
+	info, err := s.store.Stat(ctx, key)
+	if err != nil || !encrypted {
+		return info, err
+	}
+	sealed, err := newSealedBlob(key, info)
+	if err != nil {
+		return BlobInfo{}, err
+	}
+	info.Size = sealed.size
+	return info, nil
+}
+
+func (s *EncryptedStore) Delete(ctx context.Context, key string) error {
+	return s.store.Delete(ctx, key)
+}
+
+func (s *EncryptedStore) List(ctx context.Context, prefix string) ([]BlobInfo, error) {
+	return s.store.List(ctx, prefix)
+}
+
+// Move moves the encrypted blob, trashing and restoring don't change the owner of the blob
+func (s *EncryptedStore) Move(ctx context.Context, src, dst string) error {
+	return moveBlob(ctx, s.store, src, dst)
+}
+
+// openBlob keeps the header and the size of an encrypted blob, so every read of the opened blob
+// is a single request to the store
+func (s *EncryptedStore) openBlob(ctx context.Context, key string) (BlobInfo, rangeReader, error) {
+	encrypted, err := s.encrypted(key)
+	if err != nil {
+		return BlobInfo{}, nil, err
+	}
+	info, err := s.store.Stat(ctx, key)
+	if err != nil {
+		return BlobInfo{}, nil, err
+	}
+	if !encrypted {
+		return info, func(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+			return s.store.Get(ctx, key, offset, length)
+		}, nil
+	}
+
+	sealed, err := newSealedBlob(key, info)
+	if err != nil {
+		return BlobInfo{}, nil, err
+	}
+	info.Size = sealed.size
+	return info, func(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+		return s.getRange(ctx, sealed, offset, length)
+	}, nil
+}
+
+// EncryptBlobs encrypts the assets stored before encryption was enabled and returns their keys.
+// The cached variants of the assets are removed, they are created again encrypted. Blobs found
+// encrypted, e.g. after an interrupted run, are only recorded in the registry.
+func (s *EncryptedStore) EncryptBlobs(ctx context.Context) ([]string, error) {
+	blobs, err := s.store.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var encrypted []string
+	for _, blob := range blobs {
+		userID, name, ok := assetOfKey(blob.Key)
+		if !ok || strings.HasPrefix(blob.Key, assetKey(userID, VariantsDirName+"/")) {
+			continue
+		}
+		isEncrypted, err := s.encrypted(blob.Key)
+		if err != nil {
+			return encrypted, err
+		}
+		if isEncrypted {
+			continue
+		}
+
+		if isEncrypted, err = s.isEncrypted(ctx, blob.Key); err != nil {
+			return encrypted, err
+		}
+		if !isEncrypted {
+			if err := s.encryptBlob(ctx, userID, blob); err != nil {
+				return encrypted, fmt.Errorf("failed to encrypt %q: %w", blob.Key, err)
+			}
+			encrypted = append(encrypted, blob.Key)
+		}
+		if err := s.removeVariants(ctx, userID, name); err != nil {
+			return encrypted, err
+		}
+		if err := s.registry.SetAssetsEncrypted(userID, []string{name}); err != nil {
+			return encrypted, err
+		}
+	}
+
+	return encrypted, nil
+}
+
+// encryptBlob replaces the blob by its encrypted copy, which is written next to it first
+func (s *EncryptedStore) encryptBlob(ctx context.Context, userID string, blob BlobInfo) error {
+	r, err := s.store.Get(ctx, blob.Key, 0, -1)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	tempKey := assetKey(userID, tempFilePrefix+uuid.NewString())
+	if err := s.put(ctx, tempKey, r, blob.Size); err != nil {
+		_ = s.store.Delete(ctx, tempKey)
+		return err
+	}
+	return moveBlob(ctx, s.store, tempKey, blob.Key)
+}
+
+// removeVariants removes the cached variants of the asset
+func (s *EncryptedStore) removeVariants(ctx context.Context, userID, name string) error {
+	variants, err := s.store.List(ctx, assetKey(userID, VariantsDirName+"/"))
+	if err != nil {
+		return err
+	}
+	for _, variant := range variants {
+		if _, variantName, _ := assetOfKey(variant.Key); variantName == name {
+			if err := s.store.Delete(ctx, variant.Key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isEncrypted reads the header of a blob whose state isn't known from the registry and reports
+// whether it was encrypted, see EncryptBlobs and BackfillAssets
+func (s *EncryptedStore) isEncrypted(ctx context.Context, key string) (bool, error) {
+	header, err := s.header(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	return encryption.IsEncryptedStream(header), nil
+}
+
+// encrypted reports whether the blob stored under the key is encrypted, only assets stored before
+// encryption was enabled and their variants aren't
+func (s *EncryptedStore) encrypted(key string) (bool, error) {
+	userID, name, ok := assetOfKey(key)
+	if !ok {
+		return true, nil
+	}
+	return s.registry.IsAssetEncrypted(userID, name)
+}
+
+// assetOfKey returns the user and the name of the asset the blob belongs to, trashed copies and
+// variants belong to their assets. Other blobs like unfinished uploads don't belong to an asset.
+func assetOfKey(key string) (string, string, bool) {
+	parts := strings.Split(key, "/")
+	switch {
+	case len(parts) == 3 && parts[0] == TrashDirName:
+		return parts[1], parts[2], true
+	case len(parts) == 4 && parts[1] == VariantsDirName:
+		return parts[0], parts[3], true
+	case len(parts) == 2 && !strings.HasPrefix(parts[0], ".") && !strings.HasPrefix(parts[1], "."):
+		return parts[0], parts[1], true
+	}
+	return "", "", false
+}
+
+// put encrypts the content with the data key of the owner of the blob
+func (s *EncryptedStore) put(ctx context.Context, key string, src io.Reader, size int64) error {
+	dataKey, err := s.dataKey(key)
+	if err != nil {
+		return err
+	}
+	if size >= 0 {
+		size = encryption.EncryptedSize(size)
+	}
+	return s.store.Put(ctx, key, dataKey.EncryptReader(src), size)
+}
+
+// sealedBlob is what reading ranges of an encrypted blob needs
+type sealedBlob struct {
+	key string
+	// header is read with the first range starting at the beginning of the blob, or on its own
+	header []byte
+	// stored is the size of the encrypted stream and size the size of the plaintext
+	stored int64
+	size   int64
+}
+
+func newSealedBlob(key string, info BlobInfo) (*sealedBlob, error) {
+	size, err := encryption.PlainSize(info.Size)
+	if err != nil {
+		return nil, fmt.Errorf("blob %q: %w", key, err)
+	}
+	return &sealedBlob{key: key, stored: info.Size, size: size}, nil
+}
+
+// getRange reads a range of the plaintext of the blob, only the chunks holding the range are read
+func (s *EncryptedStore) getRange(ctx context.Context, sealed *sealedBlob, offset, length int64) (io.ReadCloser, error) {
+	end := sealed.size
+	if length >= 0 {
+		end = min(offset+length, sealed.size)
+	}
+	if offset >= end {
+		return io.NopCloser(strings.NewReader("")), nil
+	}
+	dataKey, err := s.dataKey(sealed.key)
+	if err != nil {
+		return nil, err
+	}
+
+	first, last, streamOffset, streamLength := encryption.ChunkRange(offset, end-offset)
+	streamLength = min(streamLength, sealed.stored-streamOffset)
+	readHeader := sealed.header == nil && first == 0
+	if readHeader {
+		streamOffset, streamLength = 0, streamLength+streamOffset
+	} else if sealed.header == nil {
+		if sealed.header, err = s.header(ctx, sealed.key); err != nil {
+			return nil, err
+		}
+	}
+
+	chunks, err := s.store.Get(ctx, sealed.key, streamOffset, streamLength)
+	if err != nil {
+		return nil, err
+	}
+	if readHeader {
+		header := make([]byte, encryption.StreamHeaderSize)
+		if _, err := io.ReadFull(chunks, header); err != nil {
+			_ = chunks.Close()
+			return nil, err
+		}
+		sealed.header = header
+	}
+	plaintext, err := dataKey.DecryptReader(sealed.header, chunks, first, last, encryption.LastChunk(sealed.size))
+	if err != nil {
+		_ = chunks.Close()
+		return nil, err
+	}
+	if _, err := io.CopyN(io.Discard, plaintext, offset-first*encryption.ChunkSize); err != nil {
+		_ = chunks.Close()
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(plaintext, end-offset), chunks}, nil
+}
+
+// header reads the start of the blob, which holds the header of an encrypted stream
+func (s *EncryptedStore) header(ctx context.Context, key string) ([]byte, error) {
+	r, err := s.store.Get(ctx, key, 0, int64(encryption.StreamHeaderSize))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	header := make([]byte, encryption.StreamHeaderSize)
+	n, err := io.ReadFull(r, header)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	return header[:n], nil
+}
+
+// dataKey returns the data key of the user owning the blob
+func (s *EncryptedStore) dataKey(key string) (*encryption.Cipher, error) {
+	userID, rest, _ := strings.Cut(key, "/")
+	if userID == TrashDirName {
+		userID, _, _ = strings.Cut(rest, "/")
+	}
+	return s.keys.DataKey(userID)
+}
@@ -0,0 +1,166 @@
+package assets_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ya-breeze/diary.be/pkg/encryption"
+	"github.com/ya-breeze/diary.be/pkg/server/assets"
+)
+
+// plaintextAssets is an assets.AssetRegistry of the assets stored as plaintext, keyed by their blob keys
+type plaintextAssets map[string]bool
+
+func (p plaintextAssets) IsAssetEncrypted(userID, name string) (bool, error) {
+	return !p[userID+"/"+name], nil
+}
+
+func (p plaintextAssets) SetAssetsEncrypted(userID string, names []string) error {
+	for _, name := range names {
+		delete(p, userID+"/"+name)
+	}
+	return nil
+}
+
+// countingStore counts the requests to the store it wraps
+type countingStore struct {
+	assets.BlobStore
+	requests int
+}
+
+func (c *countingStore) Get(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	c.requests++
+	return c.BlobStore.Get(ctx, key, offset, length)
+}
+
+func (c *countingStore) Stat(ctx context.Context, key string) (assets.BlobInfo, error) {
+	c.requests++
+	return c.BlobStore.Stat(ctx, key)
+}
+
+var _ = Describe("EncryptedStore", func() {
+	var root string
+	var plain *countingStore
+	var registry plaintextAssets
+	var store *assets.EncryptedStore
+	ctx := context.Background()
+
+	// content spans several chunks of the encrypted stream
+	content := mp4Magic + strings.Repeat("0123456789", encryption.ChunkSize/4)
+
+	get := func(key string, offset, length int64) string {
+		r, err := store.Get(ctx, key, offset, length)
+		Expect(err).ToNot(HaveOccurred())
+		defer r.Close()
+		data, err := io.ReadAll(r)
+		Expect(err).ToNot(HaveOccurred())
+		return string(data)
+	}
+
+	BeforeEach(func() {
+		key, err := encryption.ParseKey(encryption.GenerateKey())
+		Expect(err).ToNot(HaveOccurred())
+		keys, err := encryption.NewKeyring(key)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(keys.AddDataKey("user", keys.NewDataKey("user"))).To(Succeed())
+
+		root = GinkgoT().TempDir()
+		plain = &countingStore{BlobStore: assets.NewLocalStore(root)}
+		registry = plaintextAssets{}
+		store = assets.NewEncryptedStore(plain, keys, registry)
+	})
+
+	It("should store blobs encrypted and read ranges of them", func() {
+		Expect(store.Put(ctx, "user/clip.mp4", strings.NewReader(content), int64(len(content)))).To(Succeed())
+
+		stored, err := os.ReadFile(filepath.Join(root, "user", "clip.mp4"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(encryption.IsEncryptedStream(stored)).To(BeTrue())
+		Expect(string(stored)).ToNot(ContainSubstring("0123456789"))
+
+		info, err := store.Stat(ctx, "user/clip.mp4")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Size).To(Equal(int64(len(content))))
+
+		Expect(get("user/clip.mp4", 0, -1)).To(Equal(content))
+		Expect(get("user/clip.mp4", 4, 8)).To(Equal(content[4:12]))
+		Expect(get("user/clip.mp4", encryption.ChunkSize-5, 10)).To(Equal(content[encryption.ChunkSize-5 : encryption.ChunkSize+5]))
+		Expect(get("user/clip.mp4", int64(len(content)-3), -1)).To(Equal(content[len(content)-3:]))
+		Expect(get("user/clip.mp4", int64(len(content)), -1)).To(BeEmpty())
+	})
+
+	It("should refuse blobs of users without a data key", func() {
+		Expect(store.Put(ctx, "other/clip.mp4", strings.NewReader(content), int64(len(content)))).
+			To(MatchError(encryption.ErrNoDataKey))
+	})
+
+	It("should read plaintext blobs until they are encrypted", func() {
+		registry["user/old.mp4"] = true
+		Expect(plain.Put(ctx, "user/old.mp4", strings.NewReader(content), int64(len(content)))).To(Succeed())
+		Expect(plain.Put(ctx, "user/.variants/thumb/old.mp4", strings.NewReader("thumb"), 5)).To(Succeed())
+		Expect(get("user/old.mp4", 2, 5)).To(Equal(content[2:7]))
+		Expect(get("user/.variants/thumb/old.mp4", 0, -1)).To(Equal("thumb"))
+
+		encrypted, err := store.EncryptBlobs(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(encrypted).To(Equal([]string{"user/old.mp4"}))
+		stored, err := os.ReadFile(filepath.Join(root, "user", "old.mp4"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(encryption.IsEncryptedStream(stored)).To(BeTrue())
+		Expect(registry).To(BeEmpty())
+		Expect(get("user/old.mp4", 0, -1)).To(Equal(content))
+		// The plaintext variant is created again
+		Expect(filepath.Join(root, "user", ".variants", "thumb", "old.mp4")).ToNot(BeAnExistingFile())
+
+		encrypted, err = store.EncryptBlobs(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(encrypted).To(BeEmpty())
+	})
+
+	It("should serve saved assets", func() {
+		saved, err := assets.SaveReaderAtomically(ctx, store, "user", "clip.mp4", strings.NewReader(content), false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(saved.Size).To(Equal(int64(len(content))))
+
+		blob, err := assets.OpenAsset(ctx, store, "user", saved.Name, assets.SizeOriginal, "")
+		Expect(err).ToNot(HaveOccurred())
+		defer blob.Close()
+		_, err = blob.Seek(10, io.SeekStart)
+		Expect(err).ToNot(HaveOccurred())
+		data := make([]byte, 20)
+		_, err = io.ReadFull(blob, data)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(content[10:30]))
+	})
+
+	It("should read opened blobs with a request per range", func() {
+		Expect(store.Put(ctx, "user/clip.mp4", strings.NewReader(content), int64(len(content)))).To(Succeed())
+		plain.requests = 0
+
+		blob, err := assets.OpenBlob(ctx, store, "user/clip.mp4")
+		Expect(err).ToNot(HaveOccurred())
+		defer blob.Close()
+		Expect(blob.Info().Size).To(Equal(int64(len(content))))
+		Expect(plain.requests).To(Equal(1))
+
+		// The header is read with the first chunk
+		data, err := io.ReadAll(blob)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(content))
+		Expect(plain.requests).To(Equal(2))
+
+		_, err = blob.Seek(encryption.ChunkSize+5, io.SeekStart)
+		Expect(err).ToNot(HaveOccurred())
+		data = make([]byte, 10)
+		_, err = io.ReadFull(blob, data)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(content[encryption.ChunkSize+5 : encryption.ChunkSize+15]))
+		Expect(plain.requests).To(Equal(3))
+	})
+})
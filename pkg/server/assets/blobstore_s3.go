@@ -0,0 +1,139 @@
+package assets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/ya-breeze/diary.be/pkg/config"
+)
+
+// s3StreamPartSize is the part size of uploads of unknown size. The client buffers a part in memory
+// and would pick parts of over 500 MB to reach the maximal object size otherwise.
+const s3StreamPartSize = 16 * 1024 * 1024
+
+// S3Store keeps blobs as objects in a bucket of an S3 compatible service like AWS S3 or MinIO. The
+// object names are the keys after the configured prefix, so several servers can share a bucket.
+type S3Store struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+// NewS3Store connects to the bucket configured by the S3 settings, the bucket must exist
+func NewS3Store(cfg *config.Config) (*S3Store, error) {
+	if cfg.S3Endpoint == "" || cfg.S3Bucket == "" {
+		return nil, errors.New("S3 endpoint and bucket are required")
+	}
+	client, err := minio.New(cfg.S3Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.S3AccessKey, cfg.S3SecretKey, ""),
+		Secure: cfg.S3UseSSL,
+		Region: cfg.S3Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	prefix := strings.Trim(cfg.S3Prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	return &S3Store{client: client, bucket: cfg.S3Bucket, prefix: prefix}, nil
+}
+
+// Put uploads the object, content of unknown size in parts of s3StreamPartSize that are checked by
+// their MD5 instead of a streaming signature. S3 never exposes partially uploaded objects.
+func (s *S3Store) Put(ctx context.Context, key string, src io.Reader, size int64) error {
+	opts := minio.PutObjectOptions{ContentType: ContentTypeByName(key)}
+	if size < 0 {
+		opts.PartSize = s3StreamPartSize
+		opts.SendContentMd5 = true
+		opts.DisableContentSha256 = true
+	}
+	_, err := s.client.PutObject(ctx, s.bucket, s.prefix+key, src, size, opts)
+	if err != nil {
+		return fmt.Errorf("failed to put %q: %w", key, err)
+	}
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	if length == 0 {
+		return io.NopCloser(strings.NewReader("")), nil
+	}
+	opts := minio.GetObjectOptions{}
+	var err error
+	switch {
+	case length > 0:
+		err = opts.SetRange(offset, offset+length-1)
+	case offset > 0:
+		err = opts.SetRange(offset, 0)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The low level API is used, the objects of the client request ranges of their own when they are read
+	core := minio.Core{Client: s.client}
+	body, _, _, err := core.GetObject(ctx, s.bucket, s.prefix+key, opts)
+	if err != nil {
+		return nil, s.mapError(key, err)
+	}
+	return body, nil
+}
+
+func (s *S3Store) Stat(ctx context.Context, key string) (BlobInfo, error) {
+	info, err := s.client.StatObject(ctx, s.bucket, s.prefix+key, minio.StatObjectOptions{})
+	if err != nil {
+		return BlobInfo{}, s.mapError(key, err)
+	}
+	return BlobInfo{Key: key, Size: info.Size, ModTime: info.LastModified}, nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	if err := s.client.RemoveObject(ctx, s.bucket, s.prefix+key, minio.RemoveObjectOptions{}); err != nil {
+		return s.mapError(key, err)
+	}
+	return nil
+}
+
+func (s *S3Store) List(ctx context.Context, prefix string) ([]BlobInfo, error) {
+	var blobs []BlobInfo
+	for obj := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{
+		Prefix:    s.prefix + prefix,
+		Recursive: true,
+	}) {
+		if obj.Err != nil {
+			return nil, fmt.Errorf("failed to list blobs: %w", obj.Err)
+		}
+		blobs = append(blobs, BlobInfo{
+			Key:     strings.TrimPrefix(obj.Key, s.prefix),
+			Size:    obj.Size,
+			ModTime: obj.LastModified,
+		})
+	}
+	return blobs, nil
+}
+
+// Move copies the object on the server then removes the source
+func (s *S3Store) Move(ctx context.Context, src, dst string) error {
+	_, err := s.client.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: s.bucket, Object: s.prefix + dst},
+		minio.CopySrcOptions{Bucket: s.bucket, Object: s.prefix + src})
+	if err != nil {
+		return s.mapError(src, err)
+	}
+	return s.Delete(ctx, src)
+}
+
+func (s *S3Store) mapError(key string, err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return fmt.Errorf("%w: %q", ErrBlobNotFound, key)
+	}
+	return err
+}
@@ -0,0 +1,296 @@
+package assets_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"image"
+	"image/png"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing/iotest"
+	"time"
+
+	"github.com/johannesboyne/gofakes3"
+	"github.com/johannesboyne/gofakes3/backend/s3mem"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ya-breeze/diary.be/pkg/config"
+	"github.com/ya-breeze/diary.be/pkg/database"
+	"github.com/ya-breeze/diary.be/pkg/database/models"
+	"github.com/ya-breeze/diary.be/pkg/server/assets"
+)
+
+// mp4Magic starts stored test content, so it is detected as an MP4 video
+const mp4Magic = "\x00\x00\x00\x18ftypmp42"
+
+func newLocalStore() (assets.BlobStore, *config.Config) {
+	cfg := &config.Config{AssetPath: GinkgoT().TempDir()}
+	store, err := assets.NewBlobStore(cfg)
+	Expect(err).ToNot(HaveOccurred())
+	return store, cfg
+}
+
+// newS3Store returns a store connected to an in-memory fake of S3
+func newS3Store() (assets.BlobStore, *config.Config) {
+	backend := s3mem.New()
+	Expect(backend.CreateBucket("diary")).To(Succeed())
+	server := httptest.NewServer(gofakes3.New(backend).Server())
+	DeferCleanup(server.Close)
+
+	cfg := &config.Config{
+		AssetPath:    GinkgoT().TempDir(),
+		AssetStorage: assets.AssetStorageS3,
+		S3Endpoint:   strings.TrimPrefix(server.URL, "http://"),
+		S3Bucket:     "diary",
+		S3Prefix:     "assets",
+		S3AccessKey:  "access",
+		S3SecretKey:  "secret",
+	}
+	store, err := assets.NewBlobStore(cfg)
+	Expect(err).ToNot(HaveOccurred())
+	return store, cfg
+}
+
+var _ = Describe("BlobStore", func() {
+	describeBlobStore("LocalStore", newLocalStore)
+	describeBlobStore("S3Store", newS3Store)
+
+	It("should reject unknown storage backends", func() {
+		_, err := assets.NewBlobStore(&config.Config{AssetStorage: "ftp"})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+func describeBlobStore(name string, newStore func() (assets.BlobStore, *config.Config)) {
+	Describe(name, func() {
+		var store assets.BlobStore
+		var cfg *config.Config
+		ctx := context.Background()
+
+		put := func(key, content string) {
+			Expect(store.Put(ctx, key, strings.NewReader(content), int64(len(content)))).To(Succeed())
+		}
+
+		get := func(key string, offset, length int64) string {
+			r, err := store.Get(ctx, key, offset, length)
+			Expect(err).ToNot(HaveOccurred())
+			defer r.Close()
+			data, err := io.ReadAll(r)
+			Expect(err).ToNot(HaveOccurred())
+			return string(data)
+		}
+
+		keys := func(prefix string) []string {
+			blobs, err := store.List(ctx, prefix)
+			Expect(err).ToNot(HaveOccurred())
+			result := make([]string, 0, len(blobs))
+			for _, blob := range blobs {
+				result = append(result, blob.Key)
+			}
+			return result
+		}
+
+		BeforeEach(func() {
+			store, cfg = newStore()
+		})
+
+		It("should store, read and replace blobs", func() {
+			put("user/photo.jpg", "0123456789")
+			Expect(get("user/photo.jpg", 0, -1)).To(Equal("0123456789"))
+
+			info, err := store.Stat(ctx, "user/photo.jpg")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Key).To(Equal("user/photo.jpg"))
+			Expect(info.Size).To(Equal(int64(10)))
+			Expect(info.ModTime).To(BeTemporally("~", time.Now(), time.Minute))
+
+			put("user/photo.jpg", "replaced")
+			Expect(get("user/photo.jpg", 0, -1)).To(Equal("replaced"))
+		})
+
+		It("should read ranges", func() {
+			put("user/clip.mp4", "0123456789")
+			Expect(get("user/clip.mp4", 2, 3)).To(Equal("234"))
+			Expect(get("user/clip.mp4", 7, -1)).To(Equal("789"))
+			Expect(get("user/clip.mp4", 4, 0)).To(BeEmpty())
+		})
+
+		It("should report missing blobs", func() {
+			_, err := store.Stat(ctx, "user/missing.jpg")
+			Expect(err).To(MatchError(assets.ErrBlobNotFound))
+			_, err = store.Get(ctx, "user/missing.jpg", 0, -1)
+			Expect(err).To(MatchError(assets.ErrBlobNotFound))
+			Expect(store.Delete(ctx, "user/missing.jpg")).To(Succeed())
+		})
+
+		It("should delete blobs", func() {
+			put("user/photo.jpg", "photo")
+			Expect(store.Delete(ctx, "user/photo.jpg")).To(Succeed())
+			_, err := store.Stat(ctx, "user/photo.jpg")
+			Expect(err).To(MatchError(assets.ErrBlobNotFound))
+		})
+
+		It("should list blobs by prefix", func() {
+			put("user/b.jpg", "b")
+			put("user/a.jpg", "a")
+			put("user/.variants/thumb/a.jpg", "thumb")
+			put("user/ab.jpg", "ab")
+			put("other/a.jpg", "other")
+
+			Expect(keys("user/")).To(Equal([]string{"user/.variants/thumb/a.jpg", "user/a.jpg", "user/ab.jpg", "user/b.jpg"}))
+			Expect(keys("user/a")).To(Equal([]string{"user/a.jpg", "user/ab.jpg"}))
+			Expect(keys("")).To(HaveLen(5))
+			Expect(keys("missing/")).To(BeEmpty())
+		})
+
+		It("should seek in opened blobs", func() {
+			put("user/clip.mp4", "0123456789")
+			blob, err := assets.OpenBlob(ctx, store, "user/clip.mp4")
+			Expect(err).ToNot(HaveOccurred())
+			defer blob.Close()
+
+			size, err := blob.Seek(0, io.SeekEnd)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(size).To(Equal(int64(10)))
+			_, err = blob.Seek(6, io.SeekStart)
+			Expect(err).ToNot(HaveOccurred())
+			data, err := io.ReadAll(blob)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal("6789"))
+		})
+
+		It("should save, deduplicate and serve assets", func() {
+			content := mp4Magic + strings.Repeat("frame", 100)
+			saved, err := assets.SaveReaderAtomically(ctx, store, "user", "clip.mp4", strings.NewReader(content), false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(saved.Name).To(Equal(saved.Hash + ".mp4"))
+			Expect(saved.Key).To(Equal("user/" + saved.Name))
+			Expect(get(saved.Key, 0, -1)).To(Equal(content))
+
+			again, err := assets.SaveReaderAtomically(ctx, store, "user", "copy.mp4", strings.NewReader(content), false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(again.Deduplicated).To(BeTrue())
+			Expect(again.Name).To(Equal(saved.Name))
+			Expect(keys("user/")).To(HaveLen(1))
+
+			blob, err := assets.OpenAsset(ctx, store, "user", saved.Name, assets.SizeOriginal, "")
+			Expect(err).ToNot(HaveOccurred())
+			defer blob.Close()
+			req := httptest.NewRequest(http.MethodGet, "/v1/assets", nil)
+			req.Header.Set("Range", "bytes=4-11")
+			w := httptest.NewRecorder()
+			assets.ServeFile(w, req, blob)
+			Expect(w.Code).To(Equal(http.StatusPartialContent))
+			Expect(w.Body.String()).To(Equal(content[4:12]))
+			Expect(w.Header().Get("ETag")).To(Equal(`"` + saved.Hash + `"`))
+
+			_, err = assets.OpenAsset(ctx, store, "user", "missing.mp4", assets.SizeOriginal, "")
+			Expect(err).To(MatchError(assets.ErrAssetNotFound))
+		})
+
+		It("should resume interrupted uploads", func() {
+			content := mp4Magic + strings.Repeat("frame", 100)
+			uploads := assets.NewUploads(store)
+			upload, err := uploads.Create(ctx, "user", "clip.mp4", int64(len(content)))
+			Expect(err).ToNot(HaveOccurred())
+
+			// Content received before the connection failed is kept
+			interrupted := io.MultiReader(strings.NewReader(content[:100]), iotest.ErrReader(io.ErrUnexpectedEOF))
+			upload, err = uploads.Append(ctx, "user", upload.ID, 0, interrupted)
+			Expect(err).To(MatchError(io.ErrUnexpectedEOF))
+			Expect(upload.Offset).To(Equal(int64(100)))
+			_, err = uploads.Append(ctx, "user", upload.ID, 100, strings.NewReader(content))
+			Expect(err).To(MatchError(assets.ErrUploadTooLarge))
+			upload, err = uploads.Append(ctx, "user", upload.ID, 100, strings.NewReader(content[100:]))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(upload.Offset).To(Equal(int64(len(content))))
+
+			files, size, err := uploads.Pending(ctx, "user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(files).To(Equal(1))
+			Expect(size).To(Equal(int64(len(content))))
+
+			hash := sha256.Sum256([]byte(content))
+			saved, err := uploads.Complete(ctx, "user", upload.ID, hex.EncodeToString(hash[:]), false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(get(saved.Key, 0, -1)).To(Equal(content))
+			Expect(keys("user/")).To(Equal([]string{saved.Key}))
+		})
+
+		It("should generate and cache image variants", func() {
+			var encoded bytes.Buffer
+			Expect(png.Encode(&encoded, image.NewRGBA(image.Rect(0, 0, 800, 400)))).To(Succeed())
+			put("user/photo.png", encoded.String())
+
+			key, err := assets.ResolveVariant(ctx, store, "user", "photo.png", assets.SizeThumb, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(key).To(Equal("user/.variants/thumb/photo.png"))
+			imgCfg, _, err := image.DecodeConfig(strings.NewReader(get(key, 0, -1)))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(imgCfg.Width).To(Equal(320))
+		})
+
+		It("should move orphaned assets to the trash and restore them", func() {
+			logger := slog.New(slog.NewTextHandler(GinkgoWriter, nil))
+			db := database.NewStorage(logger, &config.Config{DBPath: ":memory:"})
+			Expect(db.Open()).To(Succeed())
+			DeferCleanup(db.Close)
+			user, err := db.CreateUser("gc@test.com", "password")
+			Expect(err).ToNot(HaveOccurred())
+			userID := user.ID.String()
+
+			saved, err := assets.SaveReaderAtomically(ctx, store, userID, "clip.mp4", strings.NewReader(mp4Magic+"orphan"), false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(db.CreateAssets(userID, []*models.Asset{saved.Asset()})).To(Succeed())
+
+			collector := assets.NewGarbageCollector(logger, cfg, db, store)
+			report, err := collector.Collect(ctx, time.Now().Add(time.Minute), false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(report.Orphans).To(HaveLen(1))
+			Expect(report.Orphans[0].Name).To(Equal(saved.Name))
+			Expect(keys(userID + "/")).To(BeEmpty())
+			Expect(keys(assets.TrashDirName + "/")).To(Equal([]string{assets.TrashDirName + "/" + userID + "/" + saved.Name}))
+
+			restored, err := collector.Restore(ctx, userID, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(restored).To(Equal([]string{saved.Name}))
+			Expect(get(saved.Key, 0, -1)).To(Equal(mp4Magic + "orphan"))
+			Expect(keys(assets.TrashDirName + "/")).To(BeEmpty())
+		})
+
+		It("should restore trashed content uploaded again", func() {
+			logger := slog.New(slog.NewTextHandler(GinkgoWriter, nil))
+			db := database.NewStorage(logger, &config.Config{DBPath: ":memory:"})
+			Expect(db.Open()).To(Succeed())
+			DeferCleanup(db.Close)
+			user, err := db.CreateUser("gc@test.com", "password")
+			Expect(err).ToNot(HaveOccurred())
+			userID := user.ID.String()
+
+			saved, err := assets.SaveReaderAtomically(ctx, store, userID, "clip.mp4", strings.NewReader(mp4Magic+"orphan"), false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(db.CreateAssets(userID, []*models.Asset{saved.Asset()})).To(Succeed())
+			collector := assets.NewGarbageCollector(logger, cfg, db, store)
+			_, err = collector.Collect(ctx, time.Now().Add(time.Minute), false)
+			Expect(err).ToNot(HaveOccurred())
+
+			again, err := assets.SaveReaderAtomically(ctx, store, userID, "copy.mp4", strings.NewReader(mp4Magic+"orphan"), false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(again.Deduplicated).To(BeTrue())
+			Expect(again.Name).To(Equal(saved.Name))
+			Expect(keys(userID + "/")).To(Equal([]string{saved.Key}))
+			Expect(keys(assets.TrashDirName + "/")).To(BeEmpty())
+
+			Expect(db.CreateAssets(userID, []*models.Asset{again.Asset()})).To(Succeed())
+			registered, _, err := db.GetAssets(userID, database.AssetFilter{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(registered).To(HaveLen(1))
+		})
+	})
+}
@@ -0,0 +1,113 @@
+package assets
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ya-breeze/diary.be/pkg/utils"
+)
+
+// sniffLen is the number of leading bytes the content type is detected from
+const sniffLen = 512
+
+// ErrInvalidContent is returned for uploads of a type that isn't allowed or that doesn't match the
+// extension of the file
+var ErrInvalidContent = errors.New("invalid content")
+
+type allowedContentType struct {
+	contentType string
+	// extensions of files of the type, files are saved with the first one
+	extensions []string
+}
+
+// allowedContentTypes is the allow-list of uploaded content. QuickTime precedes MP4, so .mov files are
+// served as QuickTime, while MP4 content uploaded as .mov is saved as .mp4.
+//
+//nolint:gochecknoglobals
+var allowedContentTypes = []allowedContentType{
+	{"image/jpeg", []string{".jpg", ".jpeg"}},
+	{"image/png", []string{".png"}},
+	{"image/gif", []string{".gif"}},
+	{"image/bmp", []string{".bmp"}},
+	{"image/webp", []string{".webp"}},
+	{"video/quicktime", []string{".mov"}},
+	{"video/mp4", []string{".mp4", ".mov"}},
+	{"video/x-msvideo", []string{".avi"}},
+	{"video/x-ms-wmv", []string{".wmv"}},
+	{"video/x-flv", []string{".flv"}},
+	{"video/x-matroska", []string{".mkv"}},
+}
+
+//nolint:gochecknoglobals
+var (
+	asfHeader  = []byte{0x30, 0x26, 0xB2, 0x75, 0x8E, 0x66, 0xCF, 0x11}
+	ebmlHeader = []byte{0x1A, 0x45, 0xDF, 0xA3}
+)
+
+// DetectContentType returns the MIME type of the content by its magic bytes. It extends
+// http.DetectContentType by the video containers it doesn't recognize.
+func DetectContentType(head []byte) string {
+	switch {
+	case len(head) >= 12 && string(head[4:8]) == "ftyp":
+		switch string(head[8:12]) {
+		case "qt  ":
+			return "video/quicktime"
+		case "heic", "heix", "mif1", "msf1", "avif":
+			return "image/heif"
+		default:
+			return "video/mp4"
+		}
+	case bytes.HasPrefix(head, asfHeader):
+		return "video/x-ms-wmv"
+	case bytes.HasPrefix(head, []byte("FLV\x01")):
+		return "video/x-flv"
+	case bytes.HasPrefix(head, ebmlHeader):
+		return "video/x-matroska"
+	}
+
+	contentType := http.DetectContentType(head)
+	if contentType == "video/avi" {
+		return "video/x-msvideo"
+	}
+	return contentType
+}
+
+// ValidateContent checks the content of an upload against the allow-list and the extension of its
+// file name, a name without extension is accepted for any allowed content. It returns the content
+// type and the extension to save the file with.
+func ValidateContent(filename string, head []byte) (string, string, error) {
+	contentType := DetectContentType(head)
+	i := slices.IndexFunc(allowedContentTypes, func(t allowedContentType) bool {
+		return t.contentType == contentType
+	})
+	if i < 0 {
+		return "", "", fmt.Errorf("%w: content type %s is not allowed", ErrInvalidContent, contentType)
+	}
+
+	allowed := allowedContentTypes[i]
+	if ext := strings.ToLower(filepath.Ext(filename)); ext != "" && !slices.Contains(allowed.extensions, ext) {
+		return "", "", fmt.Errorf("%w: extension %s doesn't match content type %s", ErrInvalidContent, ext, contentType)
+	}
+	return contentType, allowed.extensions[0], nil
+}
+
+// ContentTypeByName returns the MIME type of a saved asset by its extension
+func ContentTypeByName(name string) string {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, allowed := range allowedContentTypes {
+		if allowed.extensions[0] == ext {
+			return allowed.contentType
+		}
+	}
+	for _, allowed := range allowedContentTypes {
+		if slices.Contains(allowed.extensions, ext) {
+			return allowed.contentType
+		}
+	}
+	return utils.ContentTypeByName(name)
+}
@@ -0,0 +1,324 @@
+package assets
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"strings"
+	"time"
+)
+
+const (
+	exifTagOrientation        = 0x0112
+	exifTagDateTime           = 0x0132
+	exifTagExifIFD            = 0x8769
+	exifTagGPSIFD             = 0x8825
+	exifTagDateTimeOriginal   = 0x9003
+	exifTagOffsetTimeOriginal = 0x9011
+
+	exifTypeShort = 3
+	exifTypeLong  = 4
+
+	exifDateLayout = "2006:01:02 15:04:05"
+	// rotatedJPEGQuality is used to re-encode photos that are rotated upright
+	rotatedJPEGQuality = 92
+)
+
+//nolint:gochecknoglobals
+var exifHeader = []byte("Exif\x00\x00")
+
+// exifTypeSizes are the sizes in bytes of the TIFF field types
+//
+//nolint:gochecknoglobals
+var exifTypeSizes = map[uint16]int{1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8}
+
+// ImageMetadata is the EXIF metadata of an uploaded photo
+type ImageMetadata struct {
+	// Orientation is the EXIF orientation, 1 if the photo is stored upright
+	Orientation int
+	// CapturedAt is when the photo was taken, nil if unknown
+	CapturedAt *time.Time
+	// HasLocation is true if the photo contains GPS data
+	HasLocation bool
+}
+
+// exifEntry is an entry of a TIFF image file directory
+type exifEntry struct {
+	tag uint16
+	typ uint16
+	// offset of the entry and of its value in the TIFF data
+	offset      int
+	valueOffset int
+	size        int
+}
+
+// exifData is the TIFF structure of an EXIF segment. Its data is a slice of the JPEG, so changes
+// are applied to the JPEG in place.
+type exifData struct {
+	data  []byte
+	order binary.ByteOrder
+	// orientation is the offset of the orientation value, 0 if there is none
+	orientation int
+	// gps is the offset of the GPS directory, 0 if there is none
+	gps int
+}
+
+// ProcessJPEG reads the EXIF metadata of a JPEG photo and returns the photo to store. A photo with
+// an orientation tag is rotated upright and re-encoded, and GPS data is removed if stripLocation is
+// set. The data is returned unchanged if it isn't a JPEG or has no EXIF metadata.
+func ProcessJPEG(data []byte, stripLocation bool) ([]byte, ImageMetadata) {
+	meta := ImageMetadata{Orientation: 1}
+	start, end := findEXIFSegment(data)
+	if start < 0 {
+		return data, meta
+	}
+
+	segment := bytes.Clone(data[start:end])
+	exif, ok := parseEXIF(segment[4+len(exifHeader):], &meta)
+	if !ok {
+		return data, meta
+	}
+
+	if stripLocation && meta.HasLocation {
+		exif.stripLocation()
+	}
+	if meta.Orientation > 1 && meta.Orientation <= 8 {
+		// The rotated photo is stored upright
+		exif.order.PutUint16(exif.data[exif.orientation:], 1)
+		if rotated, err := rotateJPEG(data, meta.Orientation, segment); err == nil {
+			return rotated, meta
+		}
+		exif.order.PutUint16(exif.data[exif.orientation:], uint16(meta.Orientation))
+	}
+
+	// Only the EXIF segment changed, it has the same length
+	result := bytes.Clone(data)
+	copy(result[start:end], segment)
+	return result, meta
+}
+
+// findEXIFSegment returns the bounds of the APP1 EXIF segment including its marker, -1 if there is none
+func findEXIFSegment(data []byte) (int, int) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return -1, -1
+	}
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return -1, -1
+		}
+		marker := data[pos+1]
+		if marker == 0xFF {
+			// fill byte
+			pos++
+			continue
+		}
+		if marker == 0xDA || marker == 0xD9 {
+			// the image data starts, metadata precedes it
+			return -1, -1
+		}
+		end := pos + 2 + int(binary.BigEndian.Uint16(data[pos+2:]))
+		if end > len(data) {
+			return -1, -1
+		}
+		if marker == 0xE1 && bytes.HasPrefix(data[pos+4:end], exifHeader) {
+			return pos, end
+		}
+		pos = end
+	}
+	return -1, -1
+}
+
+// parseEXIF reads the metadata from the TIFF data of an EXIF segment
+func parseEXIF(data []byte, meta *ImageMetadata) (*exifData, bool) {
+	if len(data) < 8 {
+		return nil, false
+	}
+	exif := &exifData{data: data}
+	switch string(data[:2]) {
+	case "II":
+		exif.order = binary.LittleEndian
+	case "MM":
+		exif.order = binary.BigEndian
+	default:
+		return nil, false
+	}
+	if exif.order.Uint16(data[2:]) != 42 {
+		return nil, false
+	}
+
+	ifd0, ok := exif.readIFD(int(exif.order.Uint32(data[4:])))
+	if !ok {
+		return nil, false
+	}
+	var captured, offset string
+	for _, entry := range ifd0 {
+		switch entry.tag {
+		case exifTagOrientation:
+			if entry.typ == exifTypeShort {
+				exif.orientation = entry.valueOffset
+				meta.Orientation = int(exif.order.Uint16(data[entry.valueOffset:]))
+			}
+		case exifTagDateTime:
+			if captured == "" {
+				captured = exif.readString(entry)
+			}
+		case exifTagExifIFD:
+			subIFD, ok := exif.readIFD(exif.readPointer(entry))
+			if !ok {
+				continue
+			}
+			for _, sub := range subIFD {
+				switch sub.tag {
+				case exifTagDateTimeOriginal:
+					captured = exif.readString(sub)
+				case exifTagOffsetTimeOriginal:
+					offset = exif.readString(sub)
+				}
+			}
+		case exifTagGPSIFD:
+			exif.gps = exif.readPointer(entry)
+			if gpsIFD, ok := exif.readIFD(exif.gps); ok && len(gpsIFD) > 0 {
+				meta.HasLocation = true
+			}
+		}
+	}
+	meta.CapturedAt = parseEXIFTime(captured, offset)
+	return exif, true
+}
+
+// readIFD returns the entries of the image file directory at the offset
+func (e *exifData) readIFD(offset int) ([]exifEntry, bool) {
+	if offset < 8 || offset+2 > len(e.data) {
+		return nil, false
+	}
+	count := int(e.order.Uint16(e.data[offset:]))
+	if offset+2+count*12 > len(e.data) {
+		return nil, false
+	}
+	entries := make([]exifEntry, 0, count)
+	for i := range count {
+		pos := offset + 2 + i*12
+		entry := exifEntry{
+			tag:         e.order.Uint16(e.data[pos:]),
+			typ:         e.order.Uint16(e.data[pos+2:]),
+			offset:      pos,
+			valueOffset: pos + 8,
+		}
+		typeSize, known := exifTypeSizes[entry.typ]
+		if !known {
+			continue
+		}
+		entry.size = typeSize * int(e.order.Uint32(e.data[pos+4:]))
+		if entry.size > 4 {
+			// larger values are stored outside of the directory
+			entry.valueOffset = int(e.order.Uint32(e.data[pos+8:]))
+		}
+		if entry.size < 0 || entry.valueOffset+entry.size > len(e.data) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, true
+}
+
+func (e *exifData) readPointer(entry exifEntry) int {
+	if entry.typ != exifTypeLong || entry.size != 4 {
+		return 0
+	}
+	return int(e.order.Uint32(e.data[entry.valueOffset:]))
+}
+
+func (e *exifData) readString(entry exifEntry) string {
+	value := e.data[entry.valueOffset : entry.valueOffset+entry.size]
+	return strings.TrimRight(string(value), "\x00 ")
+}
+
+// stripLocation clears the GPS directory, leaving an empty directory so no offsets change
+func (e *exifData) stripLocation() {
+	entries, ok := e.readIFD(e.gps)
+	if !ok {
+		return
+	}
+	for _, entry := range entries {
+		if entry.size > 4 {
+			clear(e.data[entry.valueOffset : entry.valueOffset+entry.size])
+		}
+	}
+	end := min(e.gps+2+len(entries)*12+4, len(e.data))
+	clear(e.data[e.gps:end])
+}
+
+// parseEXIFTime parses an EXIF date with an optional UTC offset like "+02:00". Without an offset
+// the time is the local time of the camera and kept as UTC, so its date is the date the photo was
+// taken at the place it was taken.
+func parseEXIFTime(value, offset string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	if offset != "" {
+		if t, err := time.Parse(exifDateLayout+"-07:00", value+offset); err == nil {
+			return &t
+		}
+	}
+	t, err := time.Parse(exifDateLayout, value)
+	if err != nil || t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// rotateJPEG decodes the photo, rotates it upright and re-encodes it with the given EXIF segment.
+// Orientation values follow the EXIF specification: 2-4 mirror and rotate by 180 degrees, 5-8
+// additionally swap width and height.
+func rotateJPEG(data []byte, orientation int, exifSegment []byte) ([]byte, error) {
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	src := image.NewRGBA(image.Rect(0, 0, img.Bounds().Dx(), img.Bounds().Dy()))
+	draw.Draw(src, src.Bounds(), img, img.Bounds().Min, draw.Src)
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		dstW, dstH = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := range h {
+		for x := range w {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			default:
+				dx, dy = y, w-1-x
+			}
+			copy(dst.Pix[dst.PixOffset(dx, dy):][:4], src.Pix[src.PixOffset(x, y):][:4])
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: rotatedJPEGQuality}); err != nil {
+		return nil, err
+	}
+	// Keep the metadata by inserting the EXIF segment after the start of image marker
+	encoded := buf.Bytes()
+	result := make([]byte, 0, len(encoded)+len(exifSegment))
+	result = append(result, encoded[:2]...)
+	result = append(result, exifSegment...)
+	result = append(result, encoded[2:]...)
+	return result, nil
+}
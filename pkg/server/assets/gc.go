@@ -0,0 +1,268 @@
+package assets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ya-breeze/diary.be/pkg/config"
+	"github.com/ya-breeze/diary.be/pkg/database"
+	"github.com/ya-breeze/diary.be/pkg/utils"
+)
+
+// TrashDirName is the directory in the asset storage the garbage collector moves orphaned assets to,
+// the trashed assets of a user are kept in TrashDirName/<userID>
+const TrashDirName = ".trash"
+
+// tempFilePrefix marks files that are still being written, like blobs put to the LocalStore
+const tempFilePrefix = ".tmp_"
+
+// CollectedFile is a file found by the garbage collector
+type CollectedFile struct {
+	UserID string
+	// Name is the path of the file relative to the asset directory of the user
+	Name    string
+	Size    int64
+	ModTime time.Time
+}
+
+// GCReport lists the files handled by a garbage collection run
+type GCReport struct {
+	// DryRun is true if the files were only reported
+	DryRun bool
+	// Orphans are the assets no item references, they are moved to the trash
+	Orphans []CollectedFile
+	// TempFiles are leftovers of interrupted and abandoned resumable uploads, they are removed
+	TempFiles []CollectedFile
+}
+
+// OrphansSize returns the total size of the orphaned assets in bytes
+func (r *GCReport) OrphansSize() int64 {
+	var size int64
+	for _, f := range r.Orphans {
+		size += f.Size
+	}
+	return size
+}
+
+// GarbageCollector moves asset files that no item references to the trash. Only files older than
+// a grace period are collected, so assets uploaded for an item that isn't saved yet are kept.
+type GarbageCollector struct {
+	logger *slog.Logger
+	cfg    *config.Config
+	db     database.Storage
+	blobs  BlobStore
+}
+
+func NewGarbageCollector(
+	logger *slog.Logger, cfg *config.Config, db database.Storage, blobs BlobStore,
+) *GarbageCollector {
+	return &GarbageCollector{logger: logger, cfg: cfg, db: db, blobs: blobs}
+}
+
+// Collect moves the unreferenced assets of all users modified before the given time to the trash
+// and removes temporary files of interrupted uploads. With dryRun the files are only reported.
+func (g *GarbageCollector) Collect(ctx context.Context, before time.Time, dryRun bool) (*GCReport, error) {
+	report := &GCReport{DryRun: dryRun}
+
+	blobs, err := g.blobs.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	userBlobs := make(map[string][]BlobInfo)
+	for _, blob := range blobs {
+		// Skip files and service directories like the trash
+		userID, _, ok := strings.Cut(blob.Key, "/")
+		if !ok || strings.HasPrefix(userID, ".") {
+			continue
+		}
+		userBlobs[userID] = append(userBlobs[userID], blob)
+	}
+
+	for _, userID := range slices.Sorted(maps.Keys(userBlobs)) {
+		if err := g.collectUser(ctx, userID, userBlobs[userID], before, report); err != nil {
+			return nil, fmt.Errorf("failed to collect assets of user %q: %w", userID, err)
+		}
+	}
+
+	return report, nil
+}
+
+func (g *GarbageCollector) collectUser(
+	ctx context.Context, userID string, blobs []BlobInfo, before time.Time, report *GCReport,
+) error {
+	referenced, err := g.referencedAssets(userID)
+	if err != nil {
+		return err
+	}
+
+	var orphans, tempFiles []CollectedFile
+	for _, blob := range blobs {
+		if strings.HasPrefix(blob.Key, uploadKey(userID, "")) || !blob.ModTime.Before(before) {
+			continue
+		}
+		name := strings.TrimPrefix(blob.Key, assetKey(userID, ""))
+		file := CollectedFile{UserID: userID, Name: name, Size: blob.Size, ModTime: blob.ModTime}
+
+		switch {
+		case inServiceDir(name):
+		case strings.HasPrefix(path.Base(name), tempFilePrefix):
+			tempFiles = append(tempFiles, file)
+		case strings.HasPrefix(path.Base(name), "."):
+		case !referenced[name]:
+			orphans = append(orphans, file)
+		}
+	}
+
+	// Abandoned resumable uploads are removed like temporary files
+	uploads := abandonedUploads(userID, blobs, before)
+
+	report.TempFiles = append(report.TempFiles, tempFiles...)
+	report.TempFiles = append(report.TempFiles, uploads...)
+	report.Orphans = append(report.Orphans, orphans...)
+	if report.DryRun {
+		return nil
+	}
+
+	for _, file := range tempFiles {
+		if err := g.blobs.Delete(ctx, assetKey(userID, file.Name)); err != nil {
+			return fmt.Errorf("failed to remove temporary file: %w", err)
+		}
+	}
+	for _, file := range uploads {
+		if err := g.blobs.Delete(ctx, assetKey(userID, file.Name)); err != nil {
+			return fmt.Errorf("failed to remove abandoned upload: %w", err)
+		}
+	}
+
+	return g.trash(ctx, userID, orphans)
+}
+
+// inServiceDir reports if the file is in a service directory like the variants, which are skipped
+func inServiceDir(name string) bool {
+	dir, _ := path.Split(name)
+	return strings.HasPrefix(dir, ".") || strings.Contains(dir, "/.")
+}
+
+// abandonedUploads returns the blobs of resumable uploads of the user which weren't changed since
+// the given time. The blobs of an upload are removed together, so it can't be partially collected.
+func abandonedUploads(userID string, blobs []BlobInfo, before time.Time) []CollectedFile {
+	uploads := make(map[string][]CollectedFile)
+	active := make(map[string]bool)
+	for _, blob := range blobs {
+		name, ok := strings.CutPrefix(blob.Key, uploadKey(userID, ""))
+		if !ok {
+			continue
+		}
+		id, _, _ := strings.Cut(name, ".")
+		active[id] = active[id] || !blob.ModTime.Before(before)
+		uploads[id] = append(uploads[id], CollectedFile{
+			UserID:  userID,
+			Name:    UploadsDirName + "/" + name,
+			Size:    blob.Size,
+			ModTime: blob.ModTime,
+		})
+	}
+
+	var files []CollectedFile
+	for _, id := range slices.Sorted(maps.Keys(uploads)) {
+		if !active[id] {
+			files = append(files, uploads[id]...)
+		}
+	}
+	return files
+}
+
+// referencedAssets returns the names of the assets referenced by the items of the user
+func (g *GarbageCollector) referencedAssets(userID string) (map[string]bool, error) {
+	items, _, err := g.db.GetItems(userID, database.SearchParams{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get items: %w", err)
+	}
+
+	referenced := make(map[string]bool)
+	for _, item := range items {
+		for _, name := range utils.AssetNamesFromMarkdown(item.Body) {
+			referenced[name] = true
+		}
+	}
+	return referenced, nil
+}
+
+// trash moves the files to the trash of the user and marks them as trashed in the asset registry
+func (g *GarbageCollector) trash(ctx context.Context, userID string, files []CollectedFile) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(files))
+	var moveErr error
+	for _, file := range files {
+		if moveErr = moveBlob(ctx, g.blobs, assetKey(userID, file.Name), trashKey(userID, file.Name)); moveErr != nil {
+			break
+		}
+		names = append(names, file.Name)
+	}
+
+	// Register the moved files even if a later move failed, so the registry matches the store
+	if err := g.db.TrashAssets(userID, names); err != nil {
+		for _, name := range names {
+			_ = moveBlob(ctx, g.blobs, trashKey(userID, name), assetKey(userID, name))
+		}
+		return errors.Join(moveErr, fmt.Errorf("failed to trash assets: %w", err))
+	}
+	if moveErr != nil {
+		return fmt.Errorf("failed to move asset to trash: %w", moveErr)
+	}
+
+	g.logger.Info("Orphaned assets moved to trash", "userID", userID, "count", len(names))
+	return nil
+}
+
+// Restore moves trashed assets of the user back to the assets of the user, all trashed assets are
+// restored if no names are given. It returns the names of the restored assets.
+func (g *GarbageCollector) Restore(ctx context.Context, userID string, names []string) ([]string, error) {
+	if len(names) == 0 {
+		trashed, err := g.blobs.List(ctx, trashKey(userID, ""))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read trash: %w", err)
+		}
+		for _, blob := range trashed {
+			names = append(names, strings.TrimPrefix(blob.Key, trashKey(userID, "")))
+		}
+	}
+
+	restored := make([]string, 0, len(names))
+	var moveErr error
+	for _, name := range names {
+		if !filepath.IsLocal(filepath.FromSlash(name)) {
+			moveErr = fmt.Errorf("invalid asset name %q", name)
+			break
+		}
+		if _, err := g.blobs.Stat(ctx, assetKey(userID, name)); err == nil {
+			moveErr = fmt.Errorf("asset %q already exists", name)
+			break
+		}
+		if moveErr = moveBlob(ctx, g.blobs, trashKey(userID, name), assetKey(userID, name)); moveErr != nil {
+			break
+		}
+		restored = append(restored, name)
+	}
+
+	if err := g.db.RestoreAssets(userID, restored); err != nil {
+		return restored, errors.Join(moveErr, fmt.Errorf("failed to restore assets: %w", err))
+	}
+	if moveErr != nil {
+		return restored, moveErr
+	}
+
+	g.logger.Info("Assets restored from trash", "userID", userID, "count", len(restored))
+	return restored, nil
+}
@@ -0,0 +1,29 @@
+package assets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ya-breeze/diary.be/pkg/config"
+	"github.com/ya-breeze/diary.be/pkg/database"
+)
+
+// CheckQuota checks the upload of files of the given total size against the quota of the user.
+// Resumable uploads in progress count as if they were completed.
+func CheckQuota(
+	ctx context.Context, cfg *config.Config, db database.Storage, blobs BlobStore, userID string, files int, bytes int64,
+) error {
+	quota := database.UserQuota(cfg)
+	if quota.Bytes == 0 && quota.Files == 0 {
+		return nil
+	}
+	usage, err := db.GetUsage(userID)
+	if err != nil {
+		return err
+	}
+	pendingFiles, pendingBytes, err := NewUploads(blobs).Pending(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to read uploads: %w", err)
+	}
+	return quota.Check(usage, files+pendingFiles, bytes+pendingBytes)
+}
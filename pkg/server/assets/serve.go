@@ -0,0 +1,107 @@
+package assets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidPath is returned for asset paths outside the asset directory of the user or of directories
+	ErrInvalidPath = errors.New("invalid asset path")
+	// ErrAssetNotFound is returned for assets that don't exist
+	ErrAssetNotFound = errors.New("asset not found")
+)
+
+const (
+	// immutableCacheControl is sent for content-named assets, their content never changes. They are
+	// private as every user has their own assets.
+	immutableCacheControl = "private, max-age=31536000, immutable"
+	// revalidateCacheControl is sent for other assets, clients revalidate them with conditional requests
+	revalidateCacheControl = "private, no-cache"
+)
+
+// OpenAsset opens the asset of the user in the given size, see ResolveVariant. The name must be
+// relative to the asset directory of the user.
+func OpenAsset(ctx context.Context, store BlobStore, userID, name string, size Size, format string) (*Blob, error) {
+	if strings.Contains(name, "..") || path.IsAbs(name) {
+		return nil, fmt.Errorf("%w %q", ErrInvalidPath, name)
+	}
+	name = path.Clean(name)
+	if name == "." {
+		return nil, fmt.Errorf("%w %q", ErrInvalidPath, name)
+	}
+
+	// The original is opened right away, a variant only once the original is known to exist
+	if size == SizeOriginal {
+		blob, err := OpenBlob(ctx, store, assetKey(userID, name))
+		return blob, assetError(name, err)
+	}
+	if _, err := store.Stat(ctx, assetKey(userID, name)); err != nil {
+		return nil, assetError(name, err)
+	}
+
+	key, err := ResolveVariant(ctx, store, userID, name, size, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve asset variant: %w", err)
+	}
+	return OpenBlob(ctx, store, key)
+}
+
+// assetError converts an error of the store reading the asset to the errors of OpenAsset
+func assetError(name string, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrBlobNotFound):
+		return fmt.Errorf("%w: %q", ErrAssetNotFound, name)
+	case errors.Is(err, ErrInvalidKey):
+		return fmt.Errorf("%w %q: %w", ErrInvalidPath, name, err)
+	}
+	return err
+}
+
+// ServeFile writes the asset opened by OpenAsset to the response. It supports Range requests and
+// conditional requests by ETag and modification time. The content type follows the extension, which was
+// detected from the content on upload, so clients must not sniff another type.
+func ServeFile(w http.ResponseWriter, req *http.Request, blob *Blob) {
+	info := blob.Info()
+
+	header := w.Header()
+	header.Set("Content-Type", ContentTypeByName(info.Key))
+	header.Set("X-Content-Type-Options", "nosniff")
+	header.Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", path.Base(info.Key)))
+	if hash, ok := contentHash(info.Key); ok {
+		header.Set("ETag", strconv.Quote(hash+variantTag(info.Key)))
+		header.Set("Cache-Control", immutableCacheControl)
+	} else {
+		// Files that aren't named after their content are identified by modification time and size
+		header.Set("ETag", fmt.Sprintf(`"%x-%x"`, info.ModTime.UnixNano(), info.Size))
+		header.Set("Cache-Control", revalidateCacheControl)
+	}
+
+	http.ServeContent(w, req, info.Key, info.ModTime, blob)
+}
+
+// contentHash returns the hash of the content a file is named after by SaveReaderAtomically
+func contentHash(key string) (string, bool) {
+	hash, _, _ := strings.Cut(path.Base(key), ".")
+	if len(hash) != 64 || strings.Trim(hash, "0123456789abcdef") != "" {
+		return "", false
+	}
+	return hash, true
+}
+
+// variantTag distinguishes the ETags of image variants from the ETag of their original, e.g.
+// "-thumb.webp" for a WebP encoded thumbnail
+func variantTag(key string) string {
+	dir := path.Dir(key)
+	if path.Base(path.Dir(dir)) != VariantsDirName {
+		return ""
+	}
+	return "-" + path.Base(dir) + path.Ext(key)
+}
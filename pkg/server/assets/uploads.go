@@ -0,0 +1,381 @@
+package assets
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UploadsDirName is the directory in the assets of a user where resumable uploads are kept until they
+// are completed. An upload consists of its description <id>.json and the chunks of its content, each
+// stored under <id>.<offset of the chunk>.
+const UploadsDirName = ".uploads"
+
+var (
+	ErrUploadNotFound   = errors.New("upload not found")
+	ErrUploadOffset     = errors.New("upload offset mismatch")
+	ErrUploadTooLarge   = errors.New("content exceeds the upload size")
+	ErrUploadIncomplete = errors.New("upload is incomplete")
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+)
+
+// Upload is a resumable upload of a single file
+type Upload struct {
+	ID       string `json:"id"`
+	Filename string `json:"filename"`
+	// Size is the size of the complete file in bytes
+	Size int64 `json:"size"`
+	// Offset is the number of bytes received so far
+	Offset    int64     `json:"offset"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Uploads stores resumable uploads in the blob store. The content is sent in chunks that are appended
+// at the offset the server reports, so a client continues an interrupted upload where it stopped.
+// A completed upload is checked against its SHA-256 and saved by SaveReaderAtomically like other uploads.
+type Uploads struct {
+	blobs BlobStore
+
+	mu sync.Mutex
+	// locks serialize the requests of each upload
+	locks map[string]*uploadLock
+}
+
+type uploadLock struct {
+	sync.Mutex
+	// refs is the number of requests holding or waiting for the lock
+	refs int
+}
+
+func NewUploads(blobs BlobStore) *Uploads {
+	return &Uploads{blobs: blobs, locks: make(map[string]*uploadLock)}
+}
+
+// Create starts an upload of a file of the given size
+func (u *Uploads) Create(ctx context.Context, userID, filename string, size int64) (*Upload, error) {
+	upload := &Upload{
+		ID:        uuid.NewString(),
+		Filename:  path.Base(filename),
+		Size:      size,
+		CreatedAt: time.Now(),
+	}
+	if err := u.write(ctx, userID, upload); err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
+
+// Get returns the upload with the number of bytes received so far
+func (u *Uploads) Get(ctx context.Context, userID, id string) (*Upload, error) {
+	unlock, err := u.lock(id)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return u.read(ctx, userID, id)
+}
+
+// Append stores the content of src as the chunk at the offset, which must be the number of bytes received
+// so far. Content received before src fails is kept, so the client can resume after it.
+func (u *Uploads) Append(ctx context.Context, userID, id string, offset int64, src io.Reader) (*Upload, error) {
+	unlock, err := u.lock(id)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	upload, err := u.read(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if offset != upload.Offset {
+		return upload, fmt.Errorf("%w: expected offset %d", ErrUploadOffset, upload.Offset)
+	}
+
+	// The chunk is stored even if the client goes away, a chunk left by an earlier attempt at the same
+	// offset is replaced. One byte more than missing is read to detect content exceeding the size.
+	ctx = context.WithoutCancel(ctx)
+	chunk := &chunkReader{r: io.LimitReader(src, upload.Size-upload.Offset+1)}
+	key := u.chunkKey(userID, id, upload.Offset)
+	if err := u.blobs.Put(ctx, key, chunk, -1); err != nil {
+		return nil, err
+	}
+	if chunk.n == 0 || upload.Offset+chunk.n > upload.Size {
+		if err := u.blobs.Delete(ctx, key); err != nil {
+			return nil, err
+		}
+		if chunk.n > 0 {
+			return upload, ErrUploadTooLarge
+		}
+		return upload, chunk.err
+	}
+
+	upload.Offset += chunk.n
+	if err := u.write(ctx, userID, upload); err != nil {
+		return nil, err
+	}
+	return upload, chunk.err
+}
+
+// Complete checks the received content against the hex-encoded SHA-256 and saves it to the assets
+// of the user. The upload is removed once it's saved or its content is rejected, after other errors
+// like a checksum mismatch it's kept, so the client can retry or abort it.
+func (u *Uploads) Complete(
+	ctx context.Context, userID, id, checksum string, stripLocation bool,
+) (saved SavedFile, err error) {
+	unlock, err := u.lock(id)
+	if err != nil {
+		return SavedFile{}, err
+	}
+	defer unlock()
+
+	upload, err := u.read(ctx, userID, id)
+	if err != nil {
+		return SavedFile{}, err
+	}
+	if upload.Offset != upload.Size {
+		return SavedFile{}, fmt.Errorf("%w: received %d of %d bytes", ErrUploadIncomplete, upload.Offset, upload.Size)
+	}
+	defer func() {
+		if err == nil || errors.Is(err, ErrInvalidContent) {
+			_ = u.remove(context.WithoutCancel(ctx), userID, id)
+		}
+	}()
+
+	content, err := u.content(ctx, userID, upload)
+	if err != nil {
+		return SavedFile{}, err
+	}
+	hash := sha256.New()
+	_, err = io.Copy(hash, content)
+	if closeErr := content.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return SavedFile{}, err
+	}
+	if actual := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(actual, checksum) {
+		return SavedFile{}, fmt.Errorf("%w: content has SHA-256 %s", ErrChecksumMismatch, actual)
+	}
+
+	if content, err = u.content(ctx, userID, upload); err != nil {
+		return SavedFile{}, err
+	}
+	defer content.Close()
+	saved, err = SaveReaderAtomically(ctx, u.blobs, userID, upload.Filename, content, stripLocation)
+	saved.OriginalName = upload.Filename
+	return saved, err
+}
+
+// Pending returns the number of uploads of the user in progress and their total size. The size of an
+// upload is reserved when it's created, so it counts in full whatever was received so far.
+func (u *Uploads) Pending(ctx context.Context, userID string) (int, int64, error) {
+	blobs, err := u.blobs.List(ctx, uploadKey(userID, ""))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var files int
+	var size int64
+	for _, blob := range blobs {
+		id, ok := strings.CutSuffix(strings.TrimPrefix(blob.Key, uploadKey(userID, "")), ".json")
+		if !ok || uuid.Validate(id) != nil {
+			continue
+		}
+		upload, err := u.read(ctx, userID, id)
+		if err != nil {
+			if errors.Is(err, ErrUploadNotFound) {
+				continue
+			}
+			return 0, 0, err
+		}
+		files++
+		size += upload.Size
+	}
+	return files, size, nil
+}
+
+// Abort removes the upload and its content
+func (u *Uploads) Abort(ctx context.Context, userID, id string) error {
+	unlock, err := u.lock(id)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if _, err := u.read(ctx, userID, id); err != nil {
+		return err
+	}
+	return u.remove(ctx, userID, id)
+}
+
+// uploadKey returns the key of a blob of the uploads of the user
+func uploadKey(userID, name string) string {
+	return assetKey(userID, UploadsDirName+"/"+name)
+}
+
+// chunkKey returns the key of the chunk at the offset, the offset is padded so the chunks are listed in order
+func (u *Uploads) chunkKey(userID, id string, offset int64) string {
+	return uploadKey(userID, fmt.Sprintf("%s.%020d", id, offset))
+}
+
+// lock serializes the requests of the upload, the ID is validated as it's used in keys
+func (u *Uploads) lock(id string) (func(), error) {
+	if err := uuid.Validate(id); err != nil {
+		return nil, ErrUploadNotFound
+	}
+
+	u.mu.Lock()
+	l, ok := u.locks[id]
+	if !ok {
+		l = &uploadLock{}
+		u.locks[id] = l
+	}
+	l.refs++
+	u.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		u.mu.Lock()
+		defer u.mu.Unlock()
+		if l.refs--; l.refs == 0 {
+			delete(u.locks, id)
+		}
+	}, nil
+}
+
+func (u *Uploads) read(ctx context.Context, userID, id string) (*Upload, error) {
+	r, err := u.blobs.Get(ctx, uploadKey(userID, id+".json"), 0, -1)
+	if err != nil {
+		if errors.Is(err, ErrBlobNotFound) {
+			return nil, ErrUploadNotFound
+		}
+		return nil, err
+	}
+	defer r.Close()
+
+	var upload Upload
+	if err := json.NewDecoder(r).Decode(&upload); err != nil {
+		return nil, fmt.Errorf("invalid upload %q: %w", id, err)
+	}
+	return &upload, nil
+}
+
+// write stores the description of the upload, which also marks the upload as active for the garbage collector
+func (u *Uploads) write(ctx context.Context, userID string, upload *Upload) error {
+	data, err := json.Marshal(upload)
+	if err != nil {
+		return err
+	}
+	return u.blobs.Put(ctx, uploadKey(userID, upload.ID+".json"), bytes.NewReader(data), int64(len(data)))
+}
+
+// content returns the content received so far, chunks beyond the offset are left by failed attempts
+func (u *Uploads) content(ctx context.Context, userID string, upload *Upload) (io.ReadCloser, error) {
+	blobs, err := u.blobs.List(ctx, uploadKey(userID, upload.ID+"."))
+	if err != nil {
+		return nil, err
+	}
+	chunks := &chunksReader{ctx: ctx, blobs: u.blobs}
+	for _, blob := range blobs {
+		offset, err := strconv.ParseInt(strings.TrimPrefix(blob.Key, uploadKey(userID, upload.ID+".")), 10, 64)
+		if err == nil && offset < upload.Offset {
+			chunks.keys = append(chunks.keys, blob.Key)
+		}
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(chunks, upload.Offset), chunks}, nil
+}
+
+func (u *Uploads) remove(ctx context.Context, userID, id string) error {
+	blobs, err := u.blobs.List(ctx, uploadKey(userID, id+"."))
+	if err != nil {
+		return err
+	}
+	// The description goes last, so the garbage collector finds the chunks of a partially removed upload
+	var errs []error
+	for _, blob := range slices.Backward(blobs) {
+		if !strings.HasSuffix(blob.Key, ".json") {
+			errs = append(errs, u.blobs.Delete(ctx, blob.Key))
+		}
+	}
+	errs = append(errs, u.blobs.Delete(ctx, uploadKey(userID, id+".json")))
+	return errors.Join(errs...)
+}
+
+// chunkReader ends the content of a chunk at the first error of the request body, so the content
+// received before the client was interrupted is stored
+type chunkReader struct {
+	r   io.Reader
+	n   int64
+	err error
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	if err != nil && !errors.Is(err, io.EOF) {
+		c.err = err
+		err = io.EOF
+	}
+	return n, err
+}
+
+// chunksReader reads the chunks of an upload one after another
+type chunksReader struct {
+	ctx   context.Context //nolint:containedctx // the context of the request completing the upload
+	blobs BlobStore
+	keys  []string
+	body  io.ReadCloser
+}
+
+func (c *chunksReader) Read(p []byte) (int, error) {
+	for {
+		if c.body == nil {
+			if len(c.keys) == 0 {
+				return 0, io.EOF
+			}
+			body, err := c.blobs.Get(c.ctx, c.keys[0], 0, -1)
+			if err != nil {
+				return 0, err
+			}
+			c.body = body
+			c.keys = c.keys[1:]
+		}
+		n, err := c.body.Read(p)
+		if errors.Is(err, io.EOF) {
+			err = c.Close()
+			if n > 0 || err != nil {
+				return n, err
+			}
+			continue
+		}
+		return n, err
+	}
+}
+
+func (c *chunksReader) Close() error {
+	if c.body == nil {
+		return nil
+	}
+	err := c.body.Close()
+	c.body = nil
+	return err
+}
@@ -1,17 +1,25 @@
 package assets
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
-	"os"
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
+
 	"github.com/ya-breeze/diary.be/pkg/config"
+	"github.com/ya-breeze/diary.be/pkg/database/models"
 )
 
 // AllowedExtensions is the unified list of file extensions allowed for upload.
@@ -38,44 +46,179 @@ func EnforceBodySize(w http.ResponseWriter, r *http.Request, maxBytes int64) {
 	}
 }
 
-// SaveFileAtomically saves the uploaded part to the destination directory with a generated UUID filename
-// preserving original extension. It writes to a temporary file then renames to final path.
+// SavedFile describes a file saved by SaveFileAtomically
+type SavedFile struct {
+	Name string
+	// Key is the key of the blob holding the content
+	Key  string
+	Size int64
+	// Hash is the hex-encoded SHA-256 of the file content
+	Hash string
+	// OriginalName is the name of the uploaded file
+	OriginalName string
+	// ContentType is the MIME type detected from the content
+	ContentType string
+	// Deduplicated is true if the content was already stored, Name is then the existing file
+	Deduplicated bool
+	// CapturedAt is when the photo was taken according to its EXIF data, nil if unknown
+	CapturedAt *time.Time
+	// Encrypted is true if the content was stored by an EncryptedStore
+	Encrypted bool
+}
+
+// Asset returns the asset to register in the database
+func (f SavedFile) Asset() *models.Asset {
+	return &models.Asset{
+		Name:         f.Name,
+		OriginalName: f.OriginalName,
+		Size:         f.Size,
+		ContentType:  f.ContentType,
+		Hash:         f.Hash,
+		UploadedAt:   time.Now(),
+		CapturedAt:   f.CapturedAt,
+		Encrypted:    f.Encrypted,
+	}
+}
+
+// SaveFileAtomically saves the uploaded part to the assets of the user under the hash of its content,
+// see SaveReaderAtomically.
 func SaveFileAtomically(
-	dstDir string,
+	ctx context.Context,
+	store BlobStore,
+	userID string,
 	header *multipart.FileHeader,
 	src multipart.File,
-	prefix string,
-) (string, string, error) {
-	if err := os.MkdirAll(dstDir, 0o755); err != nil {
-		return "", "", err
+	stripLocation bool,
+) (SavedFile, error) {
+	saved, err := SaveReaderAtomically(ctx, store, userID, header.Filename, src, stripLocation)
+	saved.OriginalName = header.Filename
+	return saved, err
+}
+
+// SaveReaderAtomically saves the content of src to the assets of the user, the file is named after the
+// hex-encoded SHA-256 of the content with the extension of its detected type. Content that isn't allowed
+// or doesn't match the extension of filename is rejected with ErrInvalidContent. The content is streamed
+// to a temporary blob of the user while its size and hash are computed, the blob is then moved to its
+// final key. If the user already has the same content, the temporary blob is dropped and the existing
+// file is returned as deduplicated. JPEG photos are processed by ProcessJPEG before they are hashed, so
+// the stored content is upright and free of location data if stripLocation is set.
+func SaveReaderAtomically(
+	ctx context.Context, store BlobStore, userID, filename string, src io.Reader, stripLocation bool,
+) (SavedFile, error) {
+	buffered := bufio.NewReader(src)
+	head, err := buffered.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return SavedFile{}, err
+	}
+	contentType, ext, err := ValidateContent(filename, head)
+	if err != nil {
+		return SavedFile{}, err
+	}
+
+	src = buffered
+	var meta ImageMetadata
+	if contentType == "image/jpeg" {
+		if src, meta, err = readJPEG(src, stripLocation); err != nil {
+			return SavedFile{}, err
+		}
 	}
 
-	finalName := uuid.New().String() + strings.ToLower(filepath.Ext(header.Filename))
-	tmpPath := filepath.Join(dstDir, ".tmp_"+finalName)
-	finalPath := filepath.Join(dstDir, finalName)
+	// The final name is only known once the content is hashed
+	tempKey := assetKey(userID, tempFilePrefix+uuid.NewString()+ext)
+	hash := sha256.New()
+	counter := &countingReader{r: io.TeeReader(src, hash)}
+	if err := store.Put(ctx, tempKey, counter, -1); err != nil {
+		return SavedFile{}, err
+	}
+	moved := false
+	defer func() {
+		if !moved {
+			_ = store.Delete(context.WithoutCancel(ctx), tempKey)
+		}
+	}()
 
-	f, err := os.Create(tmpPath)
+	saved := SavedFile{
+		Size:        counter.n,
+		Hash:        hex.EncodeToString(hash.Sum(nil)),
+		ContentType: contentType,
+		CapturedAt:  meta.CapturedAt,
+	}
+	_, saved.Encrypted = store.(*EncryptedStore)
+	existing, err := findByHash(ctx, store, userID, saved.Hash)
 	if err != nil {
-		return "", "", err
+		return SavedFile{}, err
+	}
+	if existing != "" {
+		saved.Name = existing
+		saved.Key = assetKey(userID, existing)
+		saved.Deduplicated = true
+		return saved, nil
 	}
-	defer func() { _ = f.Close() }()
 
-	if _, err = io.Copy(f, src); err != nil {
-		_ = os.Remove(tmpPath)
-		return "", "", err
+	saved.Name = saved.Hash + ext
+	saved.Key = assetKey(userID, saved.Name)
+	if err := moveBlob(ctx, store, tempKey, saved.Key); err != nil {
+		return SavedFile{}, err
 	}
-	// ensure data is flushed
-	if err = f.Sync(); err != nil {
-		_ = os.Remove(tmpPath)
-		return "", "", err
+	moved = true
+
+	return saved, nil
+}
+
+// countingReader counts the bytes read from r
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// readJPEG reads the photo into memory and processes it with ProcessJPEG
+func readJPEG(src io.Reader, stripLocation bool) (io.Reader, ImageMetadata, error) {
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, ImageMetadata{}, err
 	}
+	data, meta := ProcessJPEG(data, stripLocation)
+	return bytes.NewReader(data), meta, nil
+}
 
-	if err = os.Rename(tmpPath, finalPath); err != nil {
-		_ = os.Remove(tmpPath)
-		return "", "", err
+// findByHash returns the name of the asset of the user that is named after the hash, whatever its
+// extension is, or an empty string if there is none. An asset in the trash is moved back, so the
+// content isn't stored twice and the trashed copy can't conflict with the new one on restore.
+func findByHash(ctx context.Context, store BlobStore, userID, hash string) (string, error) {
+	name, err := findBlobByHash(ctx, store, assetKey(userID, ""), hash)
+	if err != nil || name != "" {
+		return name, err
 	}
 
-	return finalName, finalPath, nil
+	name, err = findBlobByHash(ctx, store, trashKey(userID, ""), hash)
+	if err != nil || name == "" {
+		return name, err
+	}
+	if err := moveBlob(ctx, store, trashKey(userID, name), assetKey(userID, name)); err != nil {
+		return "", fmt.Errorf("failed to restore asset from trash: %w", err)
+	}
+	return name, nil
+}
+
+// findBlobByHash returns the name of the blob under the prefix that is named after the hash
+func findBlobByHash(ctx context.Context, store BlobStore, prefix, hash string) (string, error) {
+	blobs, err := store.List(ctx, prefix+hash)
+	if err != nil {
+		return "", err
+	}
+	for _, blob := range blobs {
+		name := strings.TrimPrefix(blob.Key, prefix)
+		if name == hash || strings.HasPrefix(name, hash+".") {
+			return name, nil
+		}
+	}
+	return "", nil
 }
 
 // BatchLimits contains computed absolute byte limits for enforcement.
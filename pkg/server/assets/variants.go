@@ -0,0 +1,184 @@
+package assets
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // register the GIF decoder
+	"image/jpeg"
+	"image/png"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+// Size selects a variant of an image asset
+type Size string
+
+const (
+	SizeOriginal Size = ""
+	SizeThumb    Size = "thumb"
+	SizeMedium   Size = "medium"
+)
+
+// VariantFormatWebP makes image variants WebP encoded, see config.Config.AssetVariantFormat
+const VariantFormatWebP = "webp"
+
+// VariantsDirName is the directory in the asset directory of a user where image variants are cached,
+// the variants of a size are kept in VariantsDirName/<size>
+const VariantsDirName = ".variants"
+
+const (
+	// maxVariantSourcePixels limits the size of images variants are generated for
+	maxVariantSourcePixels = 100_000_000
+	variantJPEGQuality     = 85
+)
+
+// VariantWidths are the maximal widths of the image variants in pixels
+//
+//nolint:gochecknoglobals
+var VariantWidths = map[Size]int{
+	SizeThumb:  320,
+	SizeMedium: 1280,
+}
+
+// ErrInvalidSize is returned for unknown image variant sizes
+var ErrInvalidSize = errors.New("invalid size")
+
+// ParseSize returns the variant size of its name, an empty name selects the original
+func ParseSize(name string) (Size, error) {
+	size := Size(name)
+	if size == SizeOriginal {
+		return size, nil
+	}
+	if _, ok := VariantWidths[size]; !ok {
+		return "", fmt.Errorf("%w %q", ErrInvalidSize, name)
+	}
+	return size, nil
+}
+
+// ResolveVariant returns the key of the blob to serve for the asset in the given size. Variants are
+// generated on first request and cached in the variants directory of the user. The original is
+// returned for assets that are no JPEG, PNG, GIF or WebP images and for images that aren't wider than
+// the variant. With format VariantFormatWebP variants are WebP encoded, otherwise photos are encoded as
+// JPEG and other images as PNG.
+func ResolveVariant(ctx context.Context, store BlobStore, userID, name string, size Size, format string) (string, error) {
+	original := assetKey(userID, name)
+	if size == SizeOriginal {
+		return original, nil
+	}
+	inputExt := strings.ToLower(path.Ext(name))
+	switch inputExt {
+	case ".jpg", ".jpeg", ".png", ".gif", ".webp":
+	default:
+		return original, nil
+	}
+
+	outputExt := variantExt(inputExt, format)
+	variantName := name
+	if inputExt != outputExt && !(inputExt == ".jpeg" && outputExt == ".jpg") {
+		variantName += outputExt
+	}
+	variant := assetKey(userID, path.Join(VariantsDirName, string(size), variantName))
+	if _, err := store.Stat(ctx, variant); err == nil {
+		return variant, nil
+	}
+
+	img, err := decodeForVariant(ctx, store, original, inputExt, VariantWidths[size])
+	if err != nil {
+		return "", err
+	}
+	if img == nil {
+		return original, nil
+	}
+
+	if err := writeVariant(ctx, store, variant, outputExt, scaleToWidth(img, VariantWidths[size])); err != nil {
+		return "", fmt.Errorf("failed to write image variant: %w", err)
+	}
+	return variant, nil
+}
+
+func variantExt(inputExt, format string) string {
+	switch {
+	case format == VariantFormatWebP:
+		return ".webp"
+	case inputExt == ".png" || inputExt == ".gif":
+		return ".png"
+	default:
+		return ".jpg"
+	}
+}
+
+// decodeForVariant decodes the image, nil is returned if it isn't wider than width or too large
+func decodeForVariant(ctx context.Context, store BlobStore, key, ext string, width int) (image.Image, error) {
+	decodeConfig, decode := image.DecodeConfig, image.Decode
+	if ext == ".webp" {
+		decodeConfig = func(r io.Reader) (image.Config, string, error) {
+			cfg, err := webp.DecodeConfig(r)
+			return cfg, "webp", err
+		}
+		decode = func(r io.Reader) (image.Image, string, error) {
+			img, err := webp.Decode(r)
+			return img, "webp", err
+		}
+	}
+
+	// The header is checked before the whole image is read
+	f, err := store.Get(ctx, key, 0, -1)
+	if err != nil {
+		return nil, err
+	}
+	cfg, _, err := decodeConfig(f)
+	_ = f.Close()
+	if err != nil {
+		// Not an image after all, serve the file as it is
+		return nil, nil //nolint:nilerr // undecodable files are served unchanged
+	}
+	if cfg.Width <= width || cfg.Width*cfg.Height > maxVariantSourcePixels {
+		return nil, nil
+	}
+
+	if f, err = store.Get(ctx, key, 0, -1); err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, _, err := decode(f)
+	if err != nil {
+		return nil, nil //nolint:nilerr // undecodable files are served unchanged
+	}
+	return img, nil
+}
+
+func scaleToWidth(img image.Image, width int) image.Image {
+	bounds := img.Bounds()
+	height := max(1, bounds.Dy()*width/bounds.Dx())
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+// writeVariant encodes the image and puts it to the store, which never serves a partial variant to
+// concurrent requests
+func writeVariant(ctx context.Context, store BlobStore, key, ext string, img image.Image) error {
+	var encoded bytes.Buffer
+	var err error
+	switch ext {
+	case ".webp":
+		err = nativewebp.Encode(&encoded, img, nil)
+	case ".png":
+		err = png.Encode(&encoded, img)
+	default:
+		err = jpeg.Encode(&encoded, img, &jpeg.Options{Quality: variantJPEGQuality})
+	}
+	if err != nil {
+		return err
+	}
+
+	return store.Put(ctx, key, &encoded, int64(encoded.Len()))
+}
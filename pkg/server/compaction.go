@@ -0,0 +1,34 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ya-breeze/diary.be/pkg/database"
+)
+
+// compactionInterval is how often the sync change log is compacted
+const compactionInterval = time.Hour
+
+// runChangeLogCompaction removes superseded sync changes older than the retention window,
+// on start and then every compactionInterval until the context is done
+func runChangeLogCompaction(ctx context.Context, logger *slog.Logger, storage database.Storage, retention time.Duration) {
+	ticker := time.NewTicker(compactionInterval)
+	defer ticker.Stop()
+
+	for {
+		removed, err := storage.CompactChanges(time.Now().Add(-retention))
+		if err != nil {
+			logger.Error("Failed to compact change log", "error", err)
+		} else if removed > 0 {
+			logger.Info("Change log compacted", "removed", removed, "retention", retention)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
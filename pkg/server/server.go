@@ -11,6 +11,7 @@ import (
 	"runtime/debug"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/ya-breeze/diary.be/pkg/auth"
@@ -19,6 +20,7 @@ import (
 	"github.com/ya-breeze/diary.be/pkg/database/models"
 	"github.com/ya-breeze/diary.be/pkg/generated/goserver"
 	"github.com/ya-breeze/diary.be/pkg/server/api"
+	"github.com/ya-breeze/diary.be/pkg/server/assets"
 	"github.com/ya-breeze/diary.be/pkg/server/webapp"
 )
 
@@ -48,13 +50,16 @@ func Server(logger *slog.Logger, cfg *config.Config) error {
 	return nil
 }
 
-func createControllers(logger *slog.Logger, cfg *config.Config, db database.Storage) goserver.CustomControllers {
+func createControllers(
+	logger *slog.Logger, cfg *config.Config, db database.Storage, blobs assets.BlobStore,
+) goserver.CustomControllers {
 	return goserver.CustomControllers{
 		AuthAPIService:   api.NewAuthAPIService(logger, db, cfg),
-		UserAPIService:   api.NewUserAPIService(logger, db),
-		AssetsAPIService: api.NewAssetsAPIService(logger, cfg),
+		UserAPIService:   api.NewUserAPIService(logger, cfg, db),
+		AssetsAPIService: api.NewAssetsAPIService(logger, cfg, db, blobs),
 		ItemsAPIService:  api.NewItemsAPIService(logger, db),
 		SyncAPIService:   api.NewSyncAPIService(logger, db),
+		TagsAPIService:   api.NewTagsAPIService(logger, db),
 	}
 }
 
@@ -99,14 +104,36 @@ func Serve(
 		logger.Info("No users defined in configuration")
 	}
 
-	// Create controllers
-	controllers := createControllers(logger, cfg, storage)
+	blobs, err := assets.NewBlobStore(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create asset storage: %w", err)
+	}
+	blobs = assets.WithEncryption(blobs, storage.Keyring(), storage)
+	if err := assets.BackfillAssets(ctx, logger, storage, blobs); err != nil {
+		return nil, nil, fmt.Errorf("failed to backfill assets: %w", err)
+	}
 
-	// Add extra routers (webapp + manual batch upload route + custom auth controller with cookie support)
-	extraRouters := []goserver.Router{webapp.NewWebAppRouter(controllers, commit, logger, cfg, storage)}
-	extraRouters = append(extraRouters, api.NewAssetsBatchRouter(logger, cfg))
+	if cfg.SyncRetentionDays > 0 {
+		go runChangeLogCompaction(ctx, logger, storage, time.Duration(cfg.SyncRetentionDays)*24*time.Hour)
+	}
+	if cfg.AssetGCGraceDays > 0 {
+		go runAssetCollection(ctx, logger, assets.NewGarbageCollector(logger, cfg, storage, blobs),
+			time.Duration(cfg.AssetGCGraceDays)*24*time.Hour)
+	}
+
+	// Create controllers
+	controllers := createControllers(logger, cfg, storage, blobs)
+
+	// Add extra routers (webapp + manual batch upload, resumable upload and sync stream routes + custom auth
+	// controller with cookie support + custom assets controller)
+	extraRouters := []goserver.Router{webapp.NewWebAppRouter(controllers, commit, logger, cfg, storage, blobs)}
+	extraRouters = append(extraRouters, api.NewAssetsBatchRouter(logger, cfg, storage, blobs))
+	extraRouters = append(extraRouters, api.NewAssetsUploadsRouter(logger, cfg, storage, blobs))
+	extraRouters = append(extraRouters, api.NewSyncStreamRouter(logger, storage, ctx.Done()))
 	// Add custom auth controller that sets cookies on login
 	extraRouters = append(extraRouters, api.NewCustomAuthAPIController(controllers.AuthAPIService, logger, cfg))
+	// Add custom assets controller that answers Range and conditional requests for assets
+	extraRouters = append(extraRouters, api.NewCustomAssetsAPIController(controllers.AssetsAPIService, logger))
 
 	return goserver.Serve(ctx, logger, cfg,
 		controllers,
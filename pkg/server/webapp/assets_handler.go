@@ -1,9 +1,11 @@
 package webapp
 
 import (
+	"errors"
 	"net/http"
-	"path/filepath"
 	"strings"
+
+	"github.com/ya-breeze/diary.be/pkg/server/assets"
 )
 
 func (r *WebAppRouter) assetsHandler(w http.ResponseWriter, req *http.Request) {
@@ -14,7 +16,28 @@ func (r *WebAppRouter) assetsHandler(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	userAsset := filepath.Join(r.cfg.AssetPath, userID, strings.TrimPrefix(req.URL.Path, "/web/assets/"))
-	r.logger.Info("Serving asset", "path", userAsset)
-	http.ServeFile(w, req, userAsset)
+	size, err := assets.ParseSize(req.URL.Query().Get("size"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	name := strings.TrimPrefix(req.URL.Path, "/web/assets/")
+	blob, err := assets.OpenAsset(req.Context(), r.blobs, userID, name, size, r.cfg.AssetVariantFormat)
+	switch {
+	case errors.Is(err, assets.ErrInvalidPath):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case errors.Is(err, assets.ErrAssetNotFound):
+		http.NotFound(w, req)
+		return
+	case err != nil:
+		r.logger.Error("Failed to open asset", "error", err, "path", name, "size", size)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	defer blob.Close()
+
+	r.logger.Info("Serving asset", "key", blob.Name())
+	assets.ServeFile(w, req, blob)
 }
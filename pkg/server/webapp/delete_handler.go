@@ -0,0 +1,87 @@
+package webapp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/ya-breeze/diary.be/pkg/database"
+	"github.com/ya-breeze/diary.be/pkg/server/common"
+	"github.com/ya-breeze/diary.be/pkg/utils"
+)
+
+// deleteConfirmHandler asks the user to confirm deletion of the item
+func (r *WebAppRouter) deleteConfirmHandler(w http.ResponseWriter, req *http.Request) {
+	tmpl, err := r.loadTemplates()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data := utils.CreateTemplateData(req, "edit")
+
+	userID, err := r.ValidateUserID(tmpl, w, req)
+	if err != nil {
+		r.logger.Error("Failed to get user ID from session", "error", err)
+		return
+	}
+	data["UserID"] = userID
+	r.addUsageTemplateData(data, req, userID)
+
+	date := req.URL.Query().Get("date")
+	item, err := r.db.GetItem(userID, date)
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			http.Error(w, "Item not found", http.StatusNotFound)
+			return
+		}
+		r.logger.Error("Failed to get item", "error", err, "date", date, "userID", userID)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data["item"] = item
+
+	templateName := "delete.tpl"
+	if err := tmpl.ExecuteTemplate(w, templateName, data); err != nil {
+		r.logger.Warn("failed to execute template", "error", err, "template", templateName)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (r *WebAppRouter) deleteHandler(w http.ResponseWriter, req *http.Request) {
+	tmpl, err := r.loadTemplates()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	userID, err := r.ValidateUserID(tmpl, w, req)
+	if err != nil {
+		r.logger.Error("Failed to get user ID from session", "error", err)
+		return
+	}
+
+	date := req.FormValue("date")
+	if date == "" {
+		http.Error(w, "Date is required", http.StatusBadRequest)
+		return
+	}
+
+	// Ensure the service can read the user ID from context (the API service expects it there)
+	ctx := context.WithValue(req.Context(), common.UserIDKey, userID)
+
+	implResp, svcErr := r.itemsService.DeleteItems(ctx, date)
+	if svcErr != nil {
+		r.logger.Error("Items service returned error", "error", svcErr)
+		http.Error(w, svcErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Handle non-OK response codes from the service
+	if implResp.Code >= 400 {
+		r.logger.Error("Items service returned non-OK code", "code", implResp.Code)
+		http.Error(w, http.StatusText(implResp.Code), implResp.Code)
+		return
+	}
+
+	http.Redirect(w, req, "/", http.StatusSeeOther)
+}
@@ -3,6 +3,7 @@ package webapp
 import (
 	"context"
 	"errors"
+	"html/template"
 	"net/http"
 	"strings"
 
@@ -27,12 +28,14 @@ func (r *WebAppRouter) editHandler(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 	data["UserID"] = userID
+	r.addUsageTemplateData(data, req, userID)
 
 	date := req.URL.Query().Get("date")
 	if date == "" {
 		date = utils.GetCurrentDate()
 	}
 	item, err := r.db.GetItem(userID, date)
+	data["exists"] = err == nil
 	if err != nil {
 		if !errors.Is(err, database.ErrNotFound) {
 			r.logger.Error("Failed to get item", "error", err, "date", date, "userID", userID)
@@ -48,6 +51,17 @@ func (r *WebAppRouter) editHandler(w http.ResponseWriter, req *http.Request) {
 	data["item"] = item
 	data["assets"] = utils.GetAssetsFromMarkdown(item.Body)
 
+	// Known tags are offered as suggestions while editing tags
+	tags, err := r.db.GetTags(userID)
+	if err != nil {
+		r.logger.Warn("Failed to get tags", "error", err, "userID", userID)
+	}
+	knownTags := make([]string, len(tags))
+	for i, tag := range tags {
+		knownTags[i] = tag.Tag
+	}
+	data["knownTags"] = knownTags
+
 	templateName := "edit.tpl"
 	if err := tmpl.ExecuteTemplate(w, templateName, data); err != nil {
 		r.logger.Warn("failed to execute template", "error", err, "template", templateName)
@@ -69,6 +83,7 @@ func (r *WebAppRouter) saveHandler(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 	data["UserID"] = userID
+	r.addUsageTemplateData(data, req, userID)
 
 	date := req.FormValue("date")
 	if date == "" {
@@ -87,13 +102,19 @@ func (r *WebAppRouter) saveHandler(w http.ResponseWriter, req *http.Request) {
 	// Ensure the service can read the user ID from context (the API service expects it there)
 	ctx := context.WithValue(req.Context(), common.UserIDKey, userID)
 
-	implResp, svcErr := r.itemsService.PutItems(ctx, itemsRequest)
+	// The version the page was loaded with, so edits from other devices are not overwritten
+	implResp, svcErr := r.itemsService.PutItems(ctx, itemsRequest, req.FormValue("version"))
 	if svcErr != nil {
 		r.logger.Error("Items service returned error", "error", svcErr)
 		http.Error(w, svcErr.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	if implResp.Code == http.StatusConflict {
+		r.renderConflict(w, tmpl, data, itemsRequest, implResp.Body)
+		return
+	}
+
 	// Handle non-OK response codes from the service
 	if implResp.Code >= 400 {
 		r.logger.Error("Items service returned non-OK code", "code", implResp.Code)
@@ -104,3 +125,25 @@ func (r *WebAppRouter) saveHandler(w http.ResponseWriter, req *http.Request) {
 	// On success redirect to the saved date
 	http.Redirect(w, req, "/?date="+date, http.StatusSeeOther)
 }
+
+// renderConflict shows the saved item next to the rejected changes, so the user can decide
+// whether to overwrite the saved item or to discard the changes
+func (r *WebAppRouter) renderConflict(
+	w http.ResponseWriter, tmpl *template.Template, data map[string]any,
+	mine goserver.ItemsRequest, serverCopy any,
+) {
+	data["mine"] = mine
+	data["mineTags"] = strings.Join(mine.Tags, ",")
+	// Saving over a deleted item requires that it doesn't exist yet
+	data["version"] = int64(0)
+	if server, ok := serverCopy.(goserver.ItemsResponse); ok {
+		data["server"] = server
+		data["version"] = server.Version
+	}
+
+	w.WriteHeader(http.StatusConflict)
+	templateName := "conflict.tpl"
+	if err := tmpl.ExecuteTemplate(w, templateName, data); err != nil {
+		r.logger.Warn("failed to execute template", "error", err, "template", templateName)
+	}
+}
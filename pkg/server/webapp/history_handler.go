@@ -0,0 +1,153 @@
+package webapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ya-breeze/diary.be/pkg/generated/goserver"
+	"github.com/ya-breeze/diary.be/pkg/server/common"
+	"github.com/ya-breeze/diary.be/pkg/utils"
+)
+
+// historyHandler shows the saved versions of the item. The selected revision ("rev" parameter,
+// the newest by default) is compared with the revision saved before it.
+func (r *WebAppRouter) historyHandler(w http.ResponseWriter, req *http.Request) {
+	tmpl, err := r.loadTemplates()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data := utils.CreateTemplateData(req, "edit")
+
+	userID, err := r.ValidateUserID(tmpl, w, req)
+	if err != nil {
+		r.logger.Error("Failed to get user ID from session", "error", err)
+		return
+	}
+	data["UserID"] = userID
+	r.addUsageTemplateData(data, req, userID)
+
+	date := req.URL.Query().Get("date")
+	data["date"] = date
+
+	// Ensure the service can read the user ID from context (the API service expects it there)
+	ctx := context.WithValue(req.Context(), common.UserIDKey, userID)
+
+	revisions, err := r.getItemRevisions(ctx, date)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data["revisions"] = revisions
+
+	if len(revisions) > 0 {
+		selected := 0
+		if rev := req.URL.Query().Get("rev"); rev != "" {
+			for i, revision := range revisions {
+				if strconv.FormatInt(revision.Id, 10) == rev {
+					selected = i
+				}
+			}
+		}
+		data["selected"] = revisions[selected]
+
+		// Revisions are sorted newest first, so the previous version follows the selected one
+		if selected+1 < len(revisions) {
+			diff, err := r.getItemRevisionsDiff(ctx, date, revisions[selected+1].Id, revisions[selected].Id)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			data["diff"] = diff
+		}
+	}
+
+	templateName := "history.tpl"
+	if err := tmpl.ExecuteTemplate(w, templateName, data); err != nil {
+		r.logger.Warn("failed to execute template", "error", err, "template", templateName)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (r *WebAppRouter) getItemRevisions(ctx context.Context, date string) ([]goserver.ItemRevisionResponse, error) {
+	response, err := r.itemsService.GetItemRevisions(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	if response.Code != http.StatusOK {
+		return nil, fmt.Errorf("failed to get revisions: %s", http.StatusText(response.Code))
+	}
+
+	revisions, ok := response.Body.([]goserver.ItemRevisionResponse)
+	if !ok {
+		r.logger.Error("Failed to cast response body to []ItemRevisionResponse")
+		return nil, errors.New("internal server error")
+	}
+
+	return revisions, nil
+}
+
+func (r *WebAppRouter) getItemRevisionsDiff(
+	ctx context.Context, date string, from, to int64,
+) (*goserver.ItemRevisionDiffResponse, error) {
+	response, err := r.itemsService.GetItemRevisionsDiff(ctx, date, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if response.Code != http.StatusOK {
+		return nil, fmt.Errorf("failed to compare revisions: %s", http.StatusText(response.Code))
+	}
+
+	diff, ok := response.Body.(goserver.ItemRevisionDiffResponse)
+	if !ok {
+		r.logger.Error("Failed to cast response body to ItemRevisionDiffResponse")
+		return nil, errors.New("internal server error")
+	}
+
+	return &diff, nil
+}
+
+// restoreHandler writes the selected revision back as the current version of the item
+func (r *WebAppRouter) restoreHandler(w http.ResponseWriter, req *http.Request) {
+	tmpl, err := r.loadTemplates()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	userID, err := r.ValidateUserID(tmpl, w, req)
+	if err != nil {
+		r.logger.Error("Failed to get user ID from session", "error", err)
+		return
+	}
+
+	date := req.FormValue("date")
+	id, err := strconv.ParseInt(req.FormValue("id"), 10, 64)
+	if date == "" || err != nil {
+		http.Error(w, "Date and revision are required", http.StatusBadRequest)
+		return
+	}
+
+	// Ensure the service can read the user ID from context (the API service expects it there)
+	ctx := context.WithValue(req.Context(), common.UserIDKey, userID)
+
+	implResp, svcErr := r.itemsService.RestoreItemRevision(ctx, date, id)
+	if svcErr != nil {
+		r.logger.Error("Items service returned error", "error", svcErr)
+		http.Error(w, svcErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Handle non-OK response codes from the service
+	if implResp.Code >= 400 {
+		r.logger.Error("Items service returned non-OK code", "code", implResp.Code)
+		http.Error(w, http.StatusText(implResp.Code), implResp.Code)
+		return
+	}
+
+	// On success redirect to the restored date
+	http.Redirect(w, req, "/?date="+date, http.StatusSeeOther)
+}
@@ -32,6 +32,7 @@ func (r *WebAppRouter) homeHandler(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 	data["UserID"] = userID
+	r.addUsageTemplateData(data, req, userID)
 
 	// Determine target date: use query parameter or default to current date
 	date := req.URL.Query().Get("date")
@@ -59,7 +60,7 @@ func (r *WebAppRouter) populateItemsData(data map[string]any, userID, date strin
 
 	// Use the items service to get items (new API signature with search parameters)
 	// For home page, we use date filter for backward compatibility
-	response, err := r.itemsService.GetItems(ctx, date, "", "")
+	response, err := r.itemsService.GetItems(ctx, date, "", "", "", "", "", "", 0, 0, "", "")
 	if err != nil {
 		r.logger.Error("Failed to get items from service", "error", err, "date", date, "userID", userID)
 		return err
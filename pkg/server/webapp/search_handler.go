@@ -3,15 +3,154 @@ package webapp
 import (
 	"context"
 	"errors"
+	"fmt"
 	"html/template"
+	"math"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ya-breeze/diary.be/pkg/generated/goserver"
 	"github.com/ya-breeze/diary.be/pkg/server/common"
 	"github.com/ya-breeze/diary.be/pkg/utils"
 )
 
+// searchPageSize is the number of search results shown per page
+const searchPageSize = 20
+
+// searchItemFields are the item fields shown on the search page, navigation dates are not needed
+const searchItemFields = "title,body,tags,snippet"
+
+// searchRequest holds the parameters of the search page
+type searchRequest struct {
+	Query    string
+	Tags     []string
+	TagsAll  []string
+	TagsNone []string
+	Date     string
+	DateFrom string
+	DateTo   string
+	Sort     string
+	Page     int
+}
+
+// dateShortcut is a quick link to search within a predefined date range
+type dateShortcut struct {
+	Label  string
+	URL    string
+	Active bool
+}
+
+// pageURL returns the URL of the given page of the same search
+func (s searchRequest) pageURL(page int) string {
+	values := url.Values{}
+	if s.Query != "" {
+		values.Set("search", s.Query)
+	}
+	if len(s.Tags) > 0 {
+		values.Set("tags", strings.Join(s.Tags, ","))
+	}
+	if len(s.TagsAll) > 0 {
+		values.Set("tagsAll", strings.Join(s.TagsAll, ","))
+	}
+	if len(s.TagsNone) > 0 {
+		values.Set("tagsNone", strings.Join(s.TagsNone, ","))
+	}
+	if s.Date != "" {
+		values.Set("date", s.Date)
+	}
+	if s.DateFrom != "" {
+		values.Set("dateFrom", s.DateFrom)
+	}
+	if s.DateTo != "" {
+		values.Set("dateTo", s.DateTo)
+	}
+	if s.Sort != "" {
+		values.Set("sort", s.Sort)
+	}
+	if page > 1 {
+		values.Set("page", strconv.Itoa(page))
+	}
+	return "/web/search?" + values.Encode()
+}
+
+// withDateRange returns the URL of the first page of the same search limited to the given dates
+func (s searchRequest) withDateRange(from, to time.Time) string {
+	s.DateFrom = from.Format(time.DateOnly)
+	s.DateTo = to.Format(time.DateOnly)
+	return s.pageURL(1)
+}
+
+// parseSearchDateRange returns the date range requested by the query. Besides explicit
+// dateFrom/dateTo it accepts the shortcuts month=YYYY-MM, year=YYYY and days=N (last N days).
+func parseSearchDateRange(query url.Values, now time.Time) (string, string, error) {
+	if month := strings.TrimSpace(query.Get("month")); month != "" {
+		date, err := time.Parse("2006-01", month)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid month %q: %w", month, err)
+		}
+		from, to := utils.DateRange(date, utils.GranularityMonth)
+		return from.Format(time.DateOnly), to.Format(time.DateOnly), nil
+	}
+	if year := strings.TrimSpace(query.Get("year")); year != "" {
+		date, err := time.Parse("2006", year)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid year %q: %w", year, err)
+		}
+		from, to := utils.DateRange(date, utils.GranularityYear)
+		return from.Format(time.DateOnly), to.Format(time.DateOnly), nil
+	}
+	if daysParam := strings.TrimSpace(query.Get("days")); daysParam != "" {
+		days, err := strconv.Atoi(daysParam)
+		if err != nil || days < 1 {
+			return "", "", fmt.Errorf("invalid number of days %q", daysParam)
+		}
+		return now.AddDate(0, 0, 1-days).Format(time.DateOnly), now.Format(time.DateOnly), nil
+	}
+
+	return strings.TrimSpace(query.Get("dateFrom")), strings.TrimSpace(query.Get("dateTo")), nil
+}
+
+// parseTagsParam splits a comma-separated list of tags, skipping empty values
+func parseTagsParam(param string) []string {
+	var tags []string
+	for tag := range strings.SplitSeq(param, ",") {
+		if t := strings.TrimSpace(tag); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// dateShortcuts returns quick links to common date ranges for the current search
+func (s searchRequest) dateShortcuts(now time.Time) []dateShortcut {
+	thisMonthFrom, thisMonthTo := utils.DateRange(now, utils.GranularityMonth)
+	lastMonthFrom, lastMonthTo := utils.DateRange(thisMonthFrom.AddDate(0, 0, -1), utils.GranularityMonth)
+	thisYearFrom, thisYearTo := utils.DateRange(now, utils.GranularityYear)
+
+	ranges := []struct {
+		label    string
+		from, to time.Time
+	}{
+		{"Last 30 days", now.AddDate(0, 0, -29), now},
+		{"This month", thisMonthFrom, thisMonthTo},
+		{"Last month", lastMonthFrom, lastMonthTo},
+		{"This year", thisYearFrom, thisYearTo},
+	}
+
+	shortcuts := make([]dateShortcut, 0, len(ranges))
+	for _, r := range ranges {
+		shortcuts = append(shortcuts, dateShortcut{
+			Label:  r.label,
+			URL:    s.withDateRange(r.from, r.to),
+			Active: s.DateFrom == r.from.Format(time.DateOnly) && s.DateTo == r.to.Format(time.DateOnly),
+		})
+	}
+	return shortcuts
+}
+
 func (r *WebAppRouter) searchHandler(w http.ResponseWriter, req *http.Request) {
 	// Load Go templates with custom functions and template inheritance
 	tmpl, err := r.loadTemplates()
@@ -31,25 +170,31 @@ func (r *WebAppRouter) searchHandler(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 	data["UserID"] = userID
+	r.addUsageTemplateData(data, req, userID)
 
 	// Extract search parameters from query string
-	searchQuery := strings.TrimSpace(req.URL.Query().Get("search"))
-	tagsParam := strings.TrimSpace(req.URL.Query().Get("tags"))
-	dateParam := strings.TrimSpace(req.URL.Query().Get("date"))
-
-	// Parse tags parameter (comma-separated)
-	var searchTags []string
-	if tagsParam != "" {
-		for tag := range strings.SplitSeq(tagsParam, ",") {
-			t := strings.TrimSpace(tag)
-			if t != "" {
-				searchTags = append(searchTags, t)
-			}
-		}
+	search := searchRequest{
+		Query: strings.TrimSpace(req.URL.Query().Get("search")),
+		Date:  strings.TrimSpace(req.URL.Query().Get("date")),
+		Sort:  strings.TrimSpace(req.URL.Query().Get("sort")),
+		Page:  1,
+	}
+	search.DateFrom, search.DateTo, err = parseSearchDateRange(req.URL.Query(), time.Now())
+	if err != nil {
+		r.logger.Warn("Invalid search date range", "error", err, "userID", userID)
+		data["searchError"] = "Invalid date range"
+	}
+	if page, err := strconv.Atoi(req.URL.Query().Get("page")); err == nil && page > 1 {
+		search.Page = min(page, math.MaxInt32/searchPageSize)
 	}
 
+	// Parse tag parameters (comma-separated)
+	search.Tags = parseTagsParam(req.URL.Query().Get("tags"))
+	search.TagsAll = parseTagsParam(req.URL.Query().Get("tagsAll"))
+	search.TagsNone = parseTagsParam(req.URL.Query().Get("tagsNone"))
+
 	// Fetch search results and populate template with content
-	if err := r.populateSearchData(data, userID, searchQuery, searchTags, dateParam, req); err != nil {
+	if err := r.populateSearchData(data, userID, search, req); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -70,19 +215,48 @@ func (r *WebAppRouter) searchHandler(w http.ResponseWriter, req *http.Request) {
 func (r *WebAppRouter) populateSearchData(
 	data map[string]any,
 	userID string,
-	searchQuery string,
-	searchTags []string,
-	dateParam string,
+	search searchRequest,
 	req *http.Request,
 ) error {
 	// Create context with user ID for the items service
 	ctx := context.WithValue(req.Context(), common.UserIDKey, userID)
 
 	// Prepare search parameters
+	searchQuery := search.Query
+	searchTags := search.Tags
 	tagsParam := strings.Join(searchTags, ",")
+	tagsAllParam := strings.Join(search.TagsAll, ",")
+	tagsNoneParam := strings.Join(search.TagsNone, ",")
+	offset := (search.Page - 1) * searchPageSize
 
-	// Use the items service to get search results
-	response, err := r.itemsService.GetItems(ctx, dateParam, searchQuery, tagsParam)
+	// Add search parameters to template data
+	data["searchQuery"] = searchQuery
+	data["searchTags"] = searchTags
+	data["searchTagsParam"] = tagsParam
+	data["searchTagsAll"] = search.TagsAll
+	data["searchTagsAllParam"] = tagsAllParam
+	data["searchTagsNone"] = search.TagsNone
+	data["searchTagsNoneParam"] = tagsNoneParam
+	data["searchDate"] = search.Date
+	data["dateFrom"] = search.DateFrom
+	data["dateTo"] = search.DateTo
+	data["sort"] = search.Sort
+	data["dateShortcuts"] = search.dateShortcuts(time.Now())
+	if search.DateFrom != "" || search.DateTo != "" {
+		data["hasDateRange"] = true
+		allDates := search
+		allDates.DateFrom, allDates.DateTo = "", ""
+		data["clearDateRangeURL"] = allDates.pageURL(1)
+	}
+	if _, invalid := data["searchError"]; invalid {
+		return nil
+	}
+
+	// Use the items service to get the requested page of search results
+	response, err := r.itemsService.GetItems(
+		ctx, search.Date, search.DateFrom, search.DateTo, searchQuery, tagsParam, tagsAllParam, tagsNoneParam,
+		searchPageSize, int32(offset), search.Sort, searchItemFields, //nolint:gosec // page is capped when parsed
+	)
 	if err != nil {
 		r.logger.Error(
 			"Failed to get search results from service",
@@ -94,6 +268,13 @@ func (r *WebAppRouter) populateSearchData(
 		return err
 	}
 
+	if response.Code == http.StatusBadRequest {
+		r.logger.Warn("Invalid search parameters", "searchQuery", searchQuery, "sort", search.Sort,
+			"dateFrom", search.DateFrom, "dateTo", search.DateTo, "userID", userID)
+		data["searchError"] = "Invalid search query"
+		return nil
+	}
+
 	if response.Code != 200 {
 		r.logger.Error(
 			"Items service returned non-200 status",
@@ -120,19 +301,32 @@ func (r *WebAppRouter) populateSearchData(
 			"Body":  item.Body, // Keep original for truncation logic in template
 			"Tags":  item.Tags,
 		}
+		if item.Snippet != "" {
+			// The snippet is HTML-escaped by the service, only <mark> tags are left unescaped
+			items[i]["Snippet"] = template.HTML(item.Snippet) //nolint:gosec // escaped by api.BuildSnippet
+		}
 	}
 
 	// Add search results to template data
 	data["items"] = items
 	data["totalCount"] = int(itemsListResponse.TotalCount)
-	data["searchQuery"] = searchQuery
-	data["searchTags"] = searchTags
+
+	// Add pagination information
+	totalPages := (int(itemsListResponse.TotalCount) + searchPageSize - 1) / searchPageSize
+	data["page"] = search.Page
+	data["totalPages"] = totalPages
+	if search.Page > 1 {
+		data["prevPageURL"] = search.pageURL(min(search.Page-1, max(totalPages, 1)))
+	}
+	if search.Page < totalPages {
+		data["nextPageURL"] = search.pageURL(search.Page + 1)
+	}
 
 	// Add search context information
 	if searchQuery != "" {
 		data["hasSearchQuery"] = true
 	}
-	if len(searchTags) > 0 {
+	if len(searchTags) > 0 || len(search.TagsAll) > 0 || len(search.TagsNone) > 0 {
 		data["hasSearchTags"] = true
 	}
 
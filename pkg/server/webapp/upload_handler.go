@@ -1,17 +1,22 @@
 package webapp
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"mime/multipart"
 	"net/http"
-	"os"
-	"path/filepath"
+	"time"
 
+	"github.com/ya-breeze/diary.be/pkg/database"
+	"github.com/ya-breeze/diary.be/pkg/database/models"
 	"github.com/ya-breeze/diary.be/pkg/server/assets"
 )
 
+// capturedDateHeader is set on single uploads to the date the photo was taken
+const capturedDateHeader = "X-Captured-Date"
+
 func (r *WebAppRouter) uploadHandler(w http.ResponseWriter, req *http.Request) {
 	userID, code, err := r.GetUserIDFromSession(req)
 	if err != nil {
@@ -32,30 +37,47 @@ func (r *WebAppRouter) uploadHandler(w http.ResponseWriter, req *http.Request) {
 	}
 	defer asset.Close()
 
-	// Save the file to the server
-	userAssetPath := filepath.Join(r.cfg.AssetPath, userID)
-	if err = os.MkdirAll(userAssetPath, 0o755); err != nil {
-		r.logger.Error("Failed to create directory", "error", err, "path", userAssetPath)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
 	// Validate extension
 	if extErr := assets.ValidateExtension(header.Filename); extErr != nil {
 		http.Error(w, extErr.Error(), http.StatusBadRequest)
 		return
 	}
+	if statusCode, qErr := r.checkQuota(req.Context(), userID, 1, header.Size); qErr != nil {
+		http.Error(w, qErr.Error(), statusCode)
+		return
+	}
 
 	// Save atomically using shared util
-	name, _, err := assets.SaveFileAtomically(userAssetPath, header, asset, "")
+	saved, err := assets.SaveFileAtomically(req.Context(), r.blobs, userID, header, asset, r.cfg.StripPhotoLocation)
+	if errors.Is(err, assets.ErrInvalidContent) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		r.logger.Error("Failed to save file", "error", err)
 		http.Error(w, "Could not save the file", http.StatusInternalServerError)
 		return
 	}
 
-	// Respond with the saved file name
-	fmt.Fprint(w, name)
+	// Register the new asset, which also makes it visible to synchronized clients
+	if err = r.db.CreateAssets(userID, []*models.Asset{saved.Asset()}); err != nil {
+		r.logger.Error("Failed to register asset", "error", err)
+		if !saved.Deduplicated {
+			_ = r.blobs.Delete(req.Context(), saved.Key)
+		}
+		if errors.Is(err, database.ErrQuotaExceeded) {
+			http.Error(w, err.Error(), http.StatusInsufficientStorage)
+			return
+		}
+		http.Error(w, "Could not save the file", http.StatusInternalServerError)
+		return
+	}
+
+	// Respond with the saved file name, the capture date lets the edit page suggest the entry of the photo
+	if saved.CapturedAt != nil {
+		w.Header().Set(capturedDateHeader, saved.CapturedAt.Format(time.DateOnly))
+	}
+	fmt.Fprint(w, saved.Name)
 }
 
 func (r *WebAppRouter) uploadBatchHandler(w http.ResponseWriter, req *http.Request) {
@@ -74,20 +96,13 @@ func (r *WebAppRouter) uploadBatchHandler(w http.ResponseWriter, req *http.Reque
 	}
 
 	files := req.MultipartForm.File["assets"]
-	statusCode, vErr := r.prevalidateFiles(files, limits)
+	statusCode, vErr := r.prevalidateFiles(req.Context(), userID, files, limits)
 	if vErr != nil {
 		http.Error(w, vErr.Error(), statusCode)
 		return
 	}
 
-	userAssetPath := filepath.Join(r.cfg.AssetPath, userID)
-	if err = os.MkdirAll(userAssetPath, 0o755); err != nil {
-		r.logger.Error("Failed to create directory", "error", err, "path", userAssetPath)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	resp, code, err := r.processBatch(userAssetPath, files)
+	resp, code, err := r.processBatch(req.Context(), userID, files)
 	if err != nil {
 		http.Error(w, err.Error(), code)
 		return
@@ -101,7 +116,9 @@ func (r *WebAppRouter) uploadBatchHandler(w http.ResponseWriter, req *http.Reque
 }
 
 // prevalidateFiles performs basic checks similar to API handler
-func (r *WebAppRouter) prevalidateFiles(files []*multipart.FileHeader, limits assets.BatchLimits) (int, error) {
+func (r *WebAppRouter) prevalidateFiles(
+	ctx context.Context, userID string, files []*multipart.FileHeader, limits assets.BatchLimits,
+) (int, error) {
 	if len(files) == 0 {
 		return http.StatusBadRequest, errors.New("no files provided")
 	}
@@ -118,6 +135,18 @@ func (r *WebAppRouter) prevalidateFiles(files []*multipart.FileHeader, limits as
 	if limits.MaxBatchTotalBytes > 0 && total > limits.MaxBatchTotalBytes {
 		return http.StatusRequestEntityTooLarge, errors.New("batch total size exceeded")
 	}
+	return r.checkQuota(ctx, userID, len(files), total)
+}
+
+// checkQuota checks the upload against the storage quota of the user
+func (r *WebAppRouter) checkQuota(ctx context.Context, userID string, files int, bytes int64) (int, error) {
+	if err := assets.CheckQuota(ctx, r.cfg, r.db, r.blobs, userID, files, bytes); err != nil {
+		if errors.Is(err, database.ErrQuotaExceeded) {
+			return http.StatusInsufficientStorage, err
+		}
+		r.logger.Error("Failed to check quota", "error", err, "userID", userID)
+		return http.StatusInternalServerError, errors.New("failed to check quota")
+	}
 	return 0, nil
 }
 
@@ -133,39 +162,62 @@ func validateFileHeaderBasic(fh *multipart.FileHeader, limits assets.BatchLimits
 
 // processBatch saves files atomically; on any error rolls back
 func (r *WebAppRouter) processBatch(
-	userAssetPath string,
+	ctx context.Context,
+	userID string,
 	files []*multipart.FileHeader,
 ) (respJSON, int, error) {
 	resp := respJSON{Files: make([]string, 0, len(files))}
-	createdPaths := make([]string, 0, len(files))
+	createdKeys := make([]string, 0, len(files))
+	registered := make([]*models.Asset, 0, len(files))
 	for _, fh := range files {
 		src, err := fh.Open()
 		if err != nil {
-			rollbackFiles(createdPaths)
+			r.rollbackFiles(ctx, createdKeys)
 			return respJSON{}, http.StatusBadRequest, fmt.Errorf("open: %w", err)
 		}
-		name, path, err := func() (string, string, error) {
+		saved, err := func() (assets.SavedFile, error) {
 			defer src.Close()
-			return assets.SaveFileAtomically(userAssetPath, fh, src, "")
+			return assets.SaveFileAtomically(ctx, r.blobs, userID, fh, src, r.cfg.StripPhotoLocation)
 		}()
 		if err != nil {
-			rollbackFiles(createdPaths)
+			r.rollbackFiles(ctx, createdKeys)
+			if errors.Is(err, assets.ErrInvalidContent) {
+				return respJSON{}, http.StatusBadRequest, fmt.Errorf("%s: %w", fh.Filename, err)
+			}
 			return respJSON{}, http.StatusInternalServerError, fmt.Errorf("save: %w", err)
 		}
-		createdPaths = append(createdPaths, path)
-		resp.Files = append(resp.Files, name)
+		if !saved.Deduplicated {
+			createdKeys = append(createdKeys, saved.Key)
+		}
+		registered = append(registered, saved.Asset())
+		resp.Files = append(resp.Files, saved.Name)
+		if saved.CapturedAt != nil {
+			if resp.CapturedDates == nil {
+				resp.CapturedDates = make(map[string]string)
+			}
+			resp.CapturedDates[saved.Name] = saved.CapturedAt.Format(time.DateOnly)
+		}
+	}
+	if err := r.db.CreateAssets(userID, registered); err != nil {
+		r.rollbackFiles(ctx, createdKeys)
+		if errors.Is(err, database.ErrQuotaExceeded) {
+			return respJSON{}, http.StatusInsufficientStorage, err
+		}
+		return respJSON{}, http.StatusInternalServerError, fmt.Errorf("record: %w", err)
 	}
 	resp.Count = len(resp.Files)
 	return resp, http.StatusOK, nil
 }
 
-func rollbackFiles(paths []string) {
-	for i := len(paths) - 1; i >= 0; i-- {
-		_ = os.Remove(paths[i])
+func (r *WebAppRouter) rollbackFiles(ctx context.Context, keys []string) {
+	for i := len(keys) - 1; i >= 0; i-- {
+		_ = r.blobs.Delete(ctx, keys[i])
 	}
 }
 
 type respJSON struct {
 	Files []string `json:"files"`
 	Count int      `json:"count"`
+	// CapturedDates maps the saved names of photos to the dates they were taken
+	CapturedDates map[string]string `json:"capturedDates,omitempty"`
 }
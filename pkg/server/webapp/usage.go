@@ -0,0 +1,37 @@
+package webapp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ya-breeze/diary.be/pkg/generated/goserver"
+	"github.com/ya-breeze/diary.be/pkg/server/common"
+)
+
+// addUsageTemplateData adds the storage used by the user to the header, it's left out on failures
+func (r *WebAppRouter) addUsageTemplateData(data map[string]any, req *http.Request, userID string) {
+	ctx := context.WithValue(req.Context(), common.UserIDKey, userID)
+	response, err := r.userService.GetUserUsage(ctx)
+	if err != nil || response.Code != http.StatusOK {
+		r.logger.Warn("Failed to get usage", "error", err, "code", response.Code, "userID", userID)
+		return
+	}
+	usage, ok := response.Body.(goserver.UserUsage)
+	if !ok {
+		r.logger.Error("Failed to cast response body to UserUsage")
+		return
+	}
+
+	view := map[string]any{
+		"Bytes": usage.AssetBytes,
+		"Files": usage.AssetFiles,
+	}
+	if usage.QuotaBytes != nil {
+		view["QuotaBytes"] = *usage.QuotaBytes
+		view["Percent"] = min(usage.AssetBytes*100 / *usage.QuotaBytes, 100)
+	}
+	if usage.QuotaFiles != nil {
+		view["QuotaFiles"] = *usage.QuotaFiles
+	}
+	data["Usage"] = view
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/ya-breeze/diary.be/pkg/config"
 	"github.com/ya-breeze/diary.be/pkg/database"
 	"github.com/ya-breeze/diary.be/pkg/generated/goserver"
+	"github.com/ya-breeze/diary.be/pkg/server/assets"
 	"github.com/ya-breeze/diary.be/pkg/utils"
 )
 
@@ -23,22 +24,27 @@ type WebAppRouter struct {
 	logger       *slog.Logger
 	cfg          *config.Config
 	db           database.Storage
+	blobs        assets.BlobStore
 	cookies      *sessions.CookieStore
 	authService  goserver.AuthAPIService
 	itemsService goserver.ItemsAPIService
+	userService  goserver.UserAPIService
 }
 
 func NewWebAppRouter(
 	controllers goserver.CustomControllers, commit string, logger *slog.Logger, cfg *config.Config, db database.Storage,
+	blobs assets.BlobStore,
 ) *WebAppRouter {
 	return &WebAppRouter{
 		commit:       commit,
 		logger:       logger,
 		cfg:          cfg,
 		db:           db,
+		blobs:        blobs,
 		cookies:      sessions.NewCookieStore([]byte("SESSION_KEY")),
 		authService:  controllers.AuthAPIService,
 		itemsService: controllers.ItemsAPIService,
+		userService:  controllers.UserAPIService,
 	}
 }
 
@@ -53,13 +59,17 @@ func (r *WebAppRouter) Routes() goserver.Routes {
 
 func (r *WebAppRouter) routesCore() goserver.Routes {
 	return goserver.Routes{
-		"RootPath":  {Method: "GET", Pattern: "/", HandlerFunc: r.homeHandler},
-		"Login":     {Method: "POST", Pattern: "/web/login", HandlerFunc: r.loginHandler},
-		"Logout":    {Method: "GET", Pattern: "/web/logout", HandlerFunc: r.logoutHandler},
-		"AboutPath": {Method: "GET", Pattern: "/web/about", HandlerFunc: r.aboutHandler},
-		"Search":    {Method: "GET", Pattern: "/web/search", HandlerFunc: r.searchHandler},
-		"Edit":      {Method: "GET", Pattern: "/web/edit", HandlerFunc: r.editHandler},
-		"Save":      {Method: "POST", Pattern: "/web/edit", HandlerFunc: r.saveHandler},
+		"RootPath":      {Method: "GET", Pattern: "/", HandlerFunc: r.homeHandler},
+		"Login":         {Method: "POST", Pattern: "/web/login", HandlerFunc: r.loginHandler},
+		"Logout":        {Method: "GET", Pattern: "/web/logout", HandlerFunc: r.logoutHandler},
+		"AboutPath":     {Method: "GET", Pattern: "/web/about", HandlerFunc: r.aboutHandler},
+		"Search":        {Method: "GET", Pattern: "/web/search", HandlerFunc: r.searchHandler},
+		"Edit":          {Method: "GET", Pattern: "/web/edit", HandlerFunc: r.editHandler},
+		"Save":          {Method: "POST", Pattern: "/web/edit", HandlerFunc: r.saveHandler},
+		"DeleteConfirm": {Method: "GET", Pattern: "/web/delete", HandlerFunc: r.deleteConfirmHandler},
+		"Delete":        {Method: "POST", Pattern: "/web/delete", HandlerFunc: r.deleteHandler},
+		"History":       {Method: "GET", Pattern: "/web/history", HandlerFunc: r.historyHandler},
+		"Restore":       {Method: "POST", Pattern: "/web/history/restore", HandlerFunc: r.restoreHandler},
 	}
 }
 
@@ -79,7 +89,8 @@ func (r *WebAppRouter) routesStatic() goserver.Routes {
 
 func (r *WebAppRouter) loadTemplates() (*template.Template, error) {
 	tmpl, err := template.New("").Funcs(template.FuncMap{
-		"formatTime": utils.FormatTime,
+		"formatTime":  utils.FormatTime,
+		"formatBytes": utils.FormatBytes,
 		"decrease": func(i int) int {
 			return i - 1
 		},
@@ -2,6 +2,12 @@ package utils
 
 import (
 	"io"
+	"mime"
+	"net/url"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/gomarkdown/markdown"
 	"github.com/gomarkdown/markdown/ast"
@@ -15,6 +21,47 @@ func GetAssetsFromMarkdown(md string) []string {
 	return lister.Assets
 }
 
+// AssetNamesFromMarkdown returns the sorted names of the assets referenced as images in the markdown.
+// Destinations are asset names, a query like "?size=thumb" is ignored. Links to the asset endpoints,
+// "/web/assets/<name>" and "/v1/assets?path=<name>", count as well; other absolute paths and
+// external URLs are skipped.
+func AssetNamesFromMarkdown(md string) []string {
+	var names []string
+	for _, dest := range GetAssetsFromMarkdown(md) {
+		if name, ok := assetNameFromDestination(dest); ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	return slices.Compact(names)
+}
+
+func assetNameFromDestination(dest string) (string, bool) {
+	name := dest
+	if u, err := url.Parse(dest); err == nil {
+		if u.Scheme != "" || u.Host != "" {
+			return "", false
+		}
+		switch {
+		case strings.HasPrefix(u.Path, "/web/assets/"):
+			name = strings.TrimPrefix(u.Path, "/web/assets/")
+		case u.Path == "/v1/assets":
+			name = u.Query().Get("path")
+		case path.IsAbs(u.Path):
+			return "", false
+		default:
+			name = u.Path
+		}
+	}
+
+	name = path.Clean(name)
+	if name == "." || name == ".." || strings.HasPrefix(name, "../") || path.IsAbs(name) {
+		return "", false
+	}
+	return name, true
+}
+
 type assetLister struct {
 	html.Renderer
 	Assets []string
@@ -30,3 +77,11 @@ func (r *assetLister) RenderNode(w io.Writer, node ast.Node, entering bool) ast.
 
 	return r.Renderer.RenderNode(w, node, entering)
 }
+
+// ContentTypeByName returns the MIME type of the asset based on its extension
+func ContentTypeByName(name string) string {
+	if ct := mime.TypeByExtension(strings.ToLower(filepath.Ext(name))); ct != "" {
+		return ct
+	}
+	return "application/octet-stream"
+}
@@ -26,6 +26,12 @@ func RoundToGranularity(date time.Time, granularity Granularity, roundUp bool,
 	return date
 }
 
+// DateRange returns the first and the last day of the month or year containing date
+func DateRange(date time.Time, granularity Granularity) (time.Time, time.Time) {
+	return RoundToGranularity(date, granularity, false),
+		RoundToGranularity(date, granularity, true).AddDate(0, 0, -1)
+}
+
 func GetCurrentDate() string {
 	return time.Now().Format("2006-01-02")
 }
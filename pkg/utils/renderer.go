@@ -46,6 +46,16 @@ func videoMimeType(ext string) string {
 	}
 }
 
+// hasImageVariants reports if the server can scale images with the extension, see assets.ResolveVariant
+func hasImageVariants(ext string) bool {
+	switch ext {
+	case ".jpg", ".jpeg", ".png", ".gif", ".webp":
+		return true
+	default:
+		return false
+	}
+}
+
 func (r *imagePrefixRenderer) RenderNode(w io.Writer, node ast.Node, entering bool) ast.WalkStatus {
 	if img, ok := node.(*ast.Image); ok {
 		dest := string(img.Destination)
@@ -66,6 +76,11 @@ func (r *imagePrefixRenderer) RenderNode(w io.Writer, node ast.Node, entering bo
 				} else {
 					_, _ = fmt.Fprintf(w, `<source src="%s">`, newSrc)
 				}
+			} else if hasImageVariants(ext) {
+				// Browsers pick the smallest sufficient variant, the link opens the original
+				_, _ = fmt.Fprintf(w, `<br><a href="%s"><img src="%s?size=medium"`+
+					` srcset="%s?size=thumb 320w, %s?size=medium 1280w" sizes="(max-width: 768px) 100vw, 50vw"`+
+					` alt="%s" class="diary-image"`, newSrc, newSrc, newSrc, newSrc, img.Title)
 			} else {
 				_, _ = fmt.Fprintf(w, `<br><a href="%s"><img src="%s" alt="%s" class="diary-image"`, newSrc, newSrc, img.Title)
 			}
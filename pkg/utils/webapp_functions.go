@@ -1,9 +1,24 @@
 package utils
 
 import (
+	"fmt"
 	"time"
 )
 
 func FormatTime(t time.Time, format string) string {
 	return t.Format(format)
 }
+
+// FormatBytes formats a size in bytes with a binary unit, e.g. 1.5 MB
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
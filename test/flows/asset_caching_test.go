@@ -0,0 +1,137 @@
+package flows_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Asset Caching Flow", func() {
+	var setup *SharedTestSetup
+	var token string
+
+	BeforeEach(func() {
+		setup = SetupTestEnvironment()
+		token = setup.LoginAndGetToken()
+	})
+
+	AfterEach(func() {
+		setup.TeardownTestEnvironment()
+	})
+
+	upload := func(name string, content []byte) string {
+		f, err := os.Create(filepath.Join(setup.TempDir, name))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = f.Write(content)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = f.Seek(0, 0)
+		Expect(err).ToNot(HaveOccurred())
+
+		resp, _, err := setup.APIClient.AssetsAPI.UploadAssetsBatch(context.Background()).
+			Assets([]*os.File{f}).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.Files).To(HaveLen(1))
+		return resp.Files[0].GetSavedName()
+	}
+
+	get := func(query url.Values, header map[string]string) (*http.Response, []byte) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet,
+			setup.ServerAddr+"/v1/assets?"+query.Encode(), nil)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set("Authorization", "Bearer "+token)
+		for key, value := range header {
+			req.Header.Set(key, value)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		return resp, data
+	}
+
+	It("should serve content-named assets with validators and immutable caching", func() {
+		content := append([]byte(mp4Magic), bytes.Repeat([]byte("video frame "), 100)...)
+		name := upload("clip.mp4", content)
+		hash := strings.TrimSuffix(name, ".mp4")
+
+		resp, data := get(url.Values{"path": {name}}, nil)
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(data).To(Equal(content))
+		Expect(resp.Header.Get("Content-Type")).To(Equal("video/mp4"))
+		Expect(resp.Header.Get("ETag")).To(Equal(`"` + hash + `"`))
+		Expect(resp.Header.Get("Cache-Control")).To(ContainSubstring("immutable"))
+		Expect(resp.Header.Get("Accept-Ranges")).To(Equal("bytes"))
+		lastModified := resp.Header.Get("Last-Modified")
+		Expect(lastModified).ToNot(BeEmpty())
+
+		By("requesting a range")
+		resp, data = get(url.Values{"path": {name}}, map[string]string{"Range": "bytes=4-11"})
+		Expect(resp.StatusCode).To(Equal(http.StatusPartialContent))
+		Expect(data).To(Equal(content[4:12]))
+		Expect(resp.Header.Get("Content-Range")).To(Equal("bytes 4-11/" + strconv.Itoa(len(content))))
+
+		By("resuming with a stale validator")
+		resp, data = get(url.Values{"path": {name}}, map[string]string{"Range": "bytes=4-11", "If-Range": `"stale"`})
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(data).To(Equal(content))
+
+		By("revalidating")
+		resp, data = get(url.Values{"path": {name}}, map[string]string{"If-None-Match": `"` + hash + `"`})
+		Expect(resp.StatusCode).To(Equal(http.StatusNotModified))
+		Expect(data).To(BeEmpty())
+		resp, _ = get(url.Values{"path": {name}}, map[string]string{"If-Modified-Since": lastModified})
+		Expect(resp.StatusCode).To(Equal(http.StatusNotModified))
+		resp, _ = get(url.Values{"path": {name}}, map[string]string{"If-None-Match": `"other"`})
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	It("should give image variants their own ETag", func() {
+		var encoded bytes.Buffer
+		Expect(png.Encode(&encoded, image.NewRGBA(image.Rect(0, 0, 800, 400)))).To(Succeed())
+		name := upload("photo.png", encoded.Bytes())
+
+		original, _ := get(url.Values{"path": {name}}, nil)
+		thumb, _ := get(url.Values{"path": {name}, "size": {"thumb"}}, nil)
+		Expect(thumb.StatusCode).To(Equal(http.StatusOK))
+		Expect(thumb.Header.Get("ETag")).To(Equal(`"` + strings.TrimSuffix(name, ".png") + `-thumb.png"`))
+		Expect(thumb.Header.Get("ETag")).ToNot(Equal(original.Header.Get("ETag")))
+		Expect(thumb.Header.Get("Cache-Control")).To(ContainSubstring("immutable"))
+	})
+
+	It("should revalidate assets that aren't named after their content", func() {
+		userID, err := setup.Storage.GetUserID(setup.TestEmail)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.MkdirAll(filepath.Join(setup.TempDir, userID), 0o755)).To(Succeed())
+		path := filepath.Join(setup.TempDir, userID, "legacy.jpg")
+		Expect(os.WriteFile(path, []byte(jpegMagic+"legacy photo"), 0o600)).To(Succeed())
+
+		resp, _ := get(url.Values{"path": {"legacy.jpg"}}, nil)
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Header.Get("Cache-Control")).To(Equal("private, no-cache"))
+		etag := resp.Header.Get("ETag")
+		Expect(etag).To(HavePrefix(`"`))
+
+		resp, _ = get(url.Values{"path": {"legacy.jpg"}}, map[string]string{"If-None-Match": etag})
+		Expect(resp.StatusCode).To(Equal(http.StatusNotModified))
+
+		// Replacing the file changes its ETag
+		Expect(os.WriteFile(path, []byte(jpegMagic+"edited photo!"), 0o600)).To(Succeed())
+		Expect(os.Chtimes(path, time.Now(), time.Now().Add(time.Hour))).To(Succeed())
+		resp, _ = get(url.Values{"path": {"legacy.jpg"}}, map[string]string{"If-None-Match": etag})
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Header.Get("ETag")).ToNot(Equal(etag))
+	})
+})
@@ -0,0 +1,152 @@
+package flows_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ya-breeze/diary.be/pkg/generated/goclient"
+	"github.com/ya-breeze/diary.be/pkg/server/assets"
+)
+
+var _ = Describe("Asset Garbage Collection Flow", func() {
+	var setup *SharedTestSetup
+	var collector *assets.GarbageCollector
+	var userDir, trashDir string
+	var referenced, orphan string
+
+	upload := func(content string) string {
+		f, err := os.CreateTemp("", "gc_*.jpg")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(os.Remove, f.Name())
+		defer f.Close()
+		_, err = f.WriteString(jpegMagic + content)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = f.Seek(0, 0)
+		Expect(err).ToNot(HaveOccurred())
+
+		uploaded, _, err := setup.APIClient.AssetsAPI.UploadAssetsBatch(context.Background()).
+			Assets([]*os.File{f}).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		return uploaded.Files[0].GetSavedName()
+	}
+
+	listed := func() []string {
+		list, _, err := setup.APIClient.AssetsAPI.ListAssets(context.Background()).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		names := make([]string, 0, len(list.Assets))
+		for _, asset := range list.Assets {
+			names = append(names, asset.Name)
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		setup = SetupTestEnvironment()
+		setup.LoginAndGetToken()
+		collector = assets.NewGarbageCollector(setup.Logger, setup.Cfg, setup.Storage, assets.NewLocalStore(setup.TempDir))
+
+		userID, err := setup.Storage.GetUserID(setup.TestEmail)
+		Expect(err).ToNot(HaveOccurred())
+		userDir = filepath.Join(setup.TempDir, userID)
+		trashDir = filepath.Join(setup.TempDir, assets.TrashDirName, userID)
+
+		referenced = upload("referenced")
+		orphan = upload("orphan")
+		_, _, err = setup.APIClient.ItemsAPI.PutItems(context.Background()).
+			ItemsRequest(*goclient.NewItemsRequest("2024-12-01", "Entry", "![]("+referenced+")")).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.WriteFile(filepath.Join(userDir, ".tmp_interrupted.jpg"), []byte("partial"), 0o600)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		setup.TeardownTestEnvironment()
+	})
+
+	It("should only report files in a dry run", func() {
+		report, err := collector.Collect(context.Background(), time.Now().Add(time.Minute), true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Orphans).To(HaveLen(1))
+		Expect(report.Orphans[0].Name).To(Equal(orphan))
+		Expect(report.Orphans[0].Size).To(Equal(int64(len(jpegMagic + "orphan"))))
+		Expect(report.TempFiles).To(HaveLen(1))
+		Expect(report.TempFiles[0].Name).To(Equal(".tmp_interrupted.jpg"))
+
+		Expect(filepath.Join(userDir, orphan)).To(BeAnExistingFile())
+		Expect(filepath.Join(userDir, ".tmp_interrupted.jpg")).To(BeAnExistingFile())
+		Expect(listed()).To(ConsistOf(referenced, orphan))
+	})
+
+	It("should keep assets referenced with a query or by their URL", func() {
+		for date, body := range map[string]string{
+			"2024-12-02": "![](" + orphan + "?size=thumb)",
+			"2024-12-03": "![](/web/assets/" + orphan + ")",
+		} {
+			_, _, err := setup.APIClient.ItemsAPI.PutItems(context.Background()).
+				ItemsRequest(*goclient.NewItemsRequest(date, "Entry", body)).Execute()
+			Expect(err).ToNot(HaveOccurred())
+
+			report, err := collector.Collect(context.Background(), time.Now().Add(time.Minute), true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(report.Orphans).To(BeEmpty())
+
+			list, _, err := setup.APIClient.AssetsAPI.ListAssets(context.Background()).Unreferenced(true).Execute()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(list.Assets).To(BeEmpty())
+
+			_, err = setup.APIClient.ItemsAPI.DeleteItems(context.Background()).Date(date).Execute()
+			Expect(err).ToNot(HaveOccurred())
+		}
+	})
+
+	It("should keep files within the grace period", func() {
+		report, err := collector.Collect(context.Background(), time.Now().Add(-time.Hour), false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Orphans).To(BeEmpty())
+		Expect(report.TempFiles).To(BeEmpty())
+		Expect(filepath.Join(userDir, orphan)).To(BeAnExistingFile())
+	})
+
+	It("should move orphans to the trash and restore them", func() {
+		_, err := collector.Collect(context.Background(), time.Now().Add(time.Minute), false)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(filepath.Join(userDir, orphan)).ToNot(BeAnExistingFile())
+		Expect(filepath.Join(trashDir, orphan)).To(BeAnExistingFile())
+		Expect(filepath.Join(userDir, referenced)).To(BeAnExistingFile())
+		Expect(filepath.Join(userDir, ".tmp_interrupted.jpg")).ToNot(BeAnExistingFile())
+		Expect(listed()).To(ConsistOf(referenced))
+
+		changes, _, err := setup.APIClient.SyncAPI.GetChanges(context.Background()).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		last := changes.Changes[len(changes.Changes)-1]
+		Expect(last.OperationType).To(Equal("deleted"))
+		Expect(last.AssetSnapshot.Get().Name).To(Equal(orphan))
+
+		restored, err := collector.Restore(context.Background(), filepath.Base(userDir), nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(restored).To(Equal([]string{orphan}))
+		Expect(filepath.Join(userDir, orphan)).To(BeAnExistingFile())
+		Expect(listed()).To(ConsistOf(referenced, orphan))
+
+		changes, _, err = setup.APIClient.SyncAPI.GetChanges(context.Background()).Since(last.Id).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes.Changes).To(HaveLen(1))
+		Expect(changes.Changes[0].OperationType).To(Equal("created"))
+	})
+
+	It("should refuse to restore over an existing asset", func() {
+		_, err := collector.Collect(context.Background(), time.Now().Add(time.Minute), false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.WriteFile(filepath.Join(userDir, orphan), []byte("new"), 0o600)).To(Succeed())
+
+		restored, err := collector.Restore(context.Background(), filepath.Base(userDir), []string{orphan})
+		Expect(err).To(HaveOccurred())
+		Expect(restored).To(BeEmpty())
+		Expect(filepath.Join(trashDir, orphan)).To(BeAnExistingFile())
+	})
+})
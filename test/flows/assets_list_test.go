@@ -0,0 +1,83 @@
+package flows_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ya-breeze/diary.be/pkg/generated/goclient"
+)
+
+var _ = Describe("Assets List Flow", func() {
+	var setup *SharedTestSetup
+
+	BeforeEach(func() {
+		setup = SetupTestEnvironment()
+		setup.LoginAndGetToken()
+	})
+
+	AfterEach(func() {
+		setup.TeardownTestEnvironment()
+	})
+
+	upload := func(pattern, content string) string {
+		f, err := os.CreateTemp("", pattern)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(os.Remove, f.Name())
+		defer f.Close()
+		_, err = f.WriteString(content)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = f.Seek(0, 0)
+		Expect(err).ToNot(HaveOccurred())
+
+		uploaded, _, err := setup.APIClient.AssetsAPI.UploadAssetsBatch(context.Background()).
+			Assets([]*os.File{f}).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(uploaded.Files).To(HaveLen(1))
+		return uploaded.Files[0].GetSavedName()
+	}
+
+	It("should list uploaded assets with the items referencing them", func() {
+		photo := upload("photo_*.jpg", jpegMagic+"jpg content")
+		video := upload("clip_*.mp4", mp4Magic+"mp4 content")
+
+		_, _, err := setup.APIClient.ItemsAPI.PutItems(context.Background()).
+			ItemsRequest(*goclient.NewItemsRequest("2024-12-01", "Entry", "![]("+photo+")")).Execute()
+		Expect(err).ToNot(HaveOccurred())
+
+		list, httpResp, err := setup.APIClient.AssetsAPI.ListAssets(context.Background()).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(httpResp.StatusCode).To(Equal(http.StatusOK))
+		Expect(list.TotalCount).To(Equal(int32(2)))
+		Expect(list.Assets).To(HaveLen(2))
+
+		byName := map[string]goclient.AssetResponse{}
+		for _, asset := range list.Assets {
+			byName[asset.Name] = asset
+		}
+		Expect(byName[photo].OriginalName).To(HavePrefix("photo_"))
+		Expect(byName[photo].ContentType).To(Equal("image/jpeg"))
+		Expect(byName[photo].Size).To(Equal(int64(len(jpegMagic + "jpg content"))))
+		Expect(byName[photo].Dates).To(Equal([]string{"2024-12-01"}))
+		Expect(byName[video].Dates).To(BeEmpty())
+
+		list, _, err = setup.APIClient.AssetsAPI.ListAssets(context.Background()).Unreferenced(true).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list.Assets).To(HaveLen(1))
+		Expect(list.Assets[0].Name).To(Equal(video))
+
+		list, _, err = setup.APIClient.AssetsAPI.ListAssets(context.Background()).ContentType("image/").Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list.Assets).To(HaveLen(1))
+		Expect(list.Assets[0].Name).To(Equal(photo))
+	})
+
+	It("should reject an invalid limit", func() {
+		_, httpResp, err := setup.APIClient.AssetsAPI.ListAssets(context.Background()).Limit(0).Execute()
+		Expect(err).To(HaveOccurred())
+		Expect(httpResp.StatusCode).To(Equal(http.StatusBadRequest))
+	})
+})
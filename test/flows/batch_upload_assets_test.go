@@ -37,13 +37,13 @@ var _ = Describe("Batch Upload Assets Flow", func() {
 				return f
 			}
 
-			f1 := mkFile("jpg", []byte("one"))
+			f1 := mkFile("jpg", []byte(jpegMagic+"one"))
 			defer os.Remove(f1.Name())
 			defer f1.Close()
-			f2 := mkFile("jpg", []byte("two"))
+			f2 := mkFile("jpg", []byte(jpegMagic+"two"))
 			defer os.Remove(f2.Name())
 			defer f2.Close()
-			f3 := mkFile("jpg", []byte("three"))
+			f3 := mkFile("jpg", []byte(jpegMagic+"three"))
 			defer os.Remove(f3.Name())
 			defer f3.Close()
 
@@ -68,6 +68,79 @@ var _ = Describe("Batch Upload Assets Flow", func() {
 		})
 	})
 
+	Context("when the same content is uploaded again", func() {
+		It("should return the existing asset and report it as deduplicated", func() {
+			setup.LoginAndGetToken()
+
+			mkFile := func(pattern string) *os.File {
+				f, err := os.CreateTemp("", pattern)
+				Expect(err).ToNot(HaveOccurred())
+				DeferCleanup(os.Remove, f.Name())
+				_, err = f.WriteString(jpegMagic + "same photo")
+				Expect(err).ToNot(HaveOccurred())
+				_, err = f.Seek(0, 0)
+				Expect(err).ToNot(HaveOccurred())
+				return f
+			}
+
+			first, _, err := setup.APIClient.AssetsAPI.UploadAssetsBatch(context.Background()).
+				Assets([]*os.File{mkFile("phone_*.jpg")}).Execute()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(first.Files[0].GetDeduplicated()).To(BeFalse())
+
+			// Another device uploads the same photo, within one batch it is stored only once too
+			second, _, err := setup.APIClient.AssetsAPI.UploadAssetsBatch(context.Background()).
+				Assets([]*os.File{mkFile("laptop_*.jpg"), mkFile("laptop_*.jpeg")}).Execute()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(second.Files).To(HaveLen(2))
+			for _, file := range second.Files {
+				Expect(file.GetDeduplicated()).To(BeTrue())
+				Expect(file.GetSavedName()).To(Equal(first.Files[0].GetSavedName()))
+			}
+
+			list, _, err := setup.APIClient.AssetsAPI.ListAssets(context.Background()).Execute()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(list.Assets).To(HaveLen(1))
+			Expect(list.Assets[0].RefCount).To(BeZero())
+		})
+	})
+
+	Context("when the content doesn't match the file name", func() {
+		It("should reject the batch and serve stored assets with their detected type", func() {
+			setup.LoginAndGetToken()
+
+			mkFile := func(pattern, content string) *os.File {
+				f, err := os.CreateTemp("", pattern)
+				Expect(err).ToNot(HaveOccurred())
+				DeferCleanup(os.Remove, f.Name())
+				_, err = f.WriteString(content)
+				Expect(err).ToNot(HaveOccurred())
+				_, err = f.Seek(0, 0)
+				Expect(err).ToNot(HaveOccurred())
+				return f
+			}
+
+			_, httpResp, err := setup.APIClient.AssetsAPI.UploadAssetsBatch(context.Background()).
+				Assets([]*os.File{mkFile("photo_*.jpg", jpegMagic+"ok"), mkFile("photo_*.jpg", pngMagic+"png")}).Execute()
+			Expect(err).To(HaveOccurred())
+			Expect(httpResp.StatusCode).To(Equal(http.StatusBadRequest))
+			list, _, err := setup.APIClient.AssetsAPI.ListAssets(context.Background()).Execute()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(list.Assets).To(BeEmpty())
+
+			resp, _, err := setup.APIClient.AssetsAPI.UploadAssetsBatch(context.Background()).
+				Assets([]*os.File{mkFile("chart_*.png", pngMagic+"png")}).Execute()
+			Expect(err).ToNot(HaveOccurred())
+			name := resp.Files[0].GetSavedName()
+			Expect(name).To(HaveSuffix(".png"))
+
+			_, httpResp, err = setup.APIClient.AssetsAPI.GetAsset(context.Background()).Path(name).Execute()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(httpResp.Header.Get("Content-Type")).To(Equal("image/png"))
+			Expect(httpResp.Header.Get("X-Content-Type-Options")).To(Equal("nosniff"))
+		})
+	})
+
 	Context("when one of the files is invalid", func() {
 		It("should return 4xx and not upload anything", func() {
 			setup.LoginAndGetToken()
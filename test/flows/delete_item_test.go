@@ -0,0 +1,53 @@
+package flows_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ya-breeze/diary.be/pkg/generated/goclient"
+)
+
+var _ = Describe("Delete Item Flow", func() {
+	var setup *SharedTestSetup
+
+	BeforeEach(func() {
+		setup = SetupTestEnvironment()
+		setup.LoginAndGetToken()
+	})
+
+	AfterEach(func() {
+		setup.TeardownTestEnvironment()
+	})
+
+	It("should delete an existing item", func() {
+		const date = "2024-05-01"
+		itemsReq := *goclient.NewItemsRequest(date, "To be deleted", "Body")
+		_, httpResp, err := setup.APIClient.ItemsAPI.PutItems(context.Background()).ItemsRequest(itemsReq).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(httpResp.StatusCode).To(Equal(http.StatusOK))
+
+		httpResp, err = setup.APIClient.ItemsAPI.DeleteItems(context.Background()).Date(date).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(httpResp.StatusCode).To(Equal(http.StatusNoContent))
+
+		fetched, _, err := setup.APIClient.ItemsAPI.GetItems(context.Background()).Date(date).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetched.Items).To(BeEmpty())
+
+		changes, _, err := setup.APIClient.SyncAPI.GetChanges(context.Background()).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes.Changes).ToNot(BeEmpty())
+		last := changes.Changes[len(changes.Changes)-1]
+		Expect(last.OperationType).To(Equal("deleted"))
+		Expect(last.Date).To(Equal(date))
+	})
+
+	It("should return 404 for a missing date", func() {
+		httpResp, err := setup.APIClient.ItemsAPI.DeleteItems(context.Background()).Date("2024-05-02").Execute()
+		Expect(err).To(HaveOccurred())
+		Expect(httpResp.StatusCode).To(Equal(http.StatusNotFound))
+	})
+})
@@ -0,0 +1,206 @@
+package flows_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/ya-breeze/diary.be/pkg/config"
+	"github.com/ya-breeze/diary.be/pkg/database"
+	"github.com/ya-breeze/diary.be/pkg/database/models"
+	"github.com/ya-breeze/diary.be/pkg/encryption"
+	"github.com/ya-breeze/diary.be/pkg/generated/goclient"
+	"github.com/ya-breeze/diary.be/pkg/server/assets"
+)
+
+// storedValues returns the raw values of the query from the database file
+func storedValues(dbPath, query string) []string {
+	db, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{})
+	Expect(err).ToNot(HaveOccurred())
+	sqlDB, err := db.DB()
+	Expect(err).ToNot(HaveOccurred())
+	defer sqlDB.Close()
+
+	var values []string
+	Expect(db.Raw(query).Scan(&values).Error).To(Succeed())
+	return values
+}
+
+func expectEncrypted(values []string) {
+	Expect(values).ToNot(BeEmpty())
+	for _, value := range values {
+		Expect(encryption.IsEncrypted(value)).To(BeTrue(), value)
+		Expect(value).ToNot(ContainSubstring("secret"))
+	}
+}
+
+var _ = Describe("Encryption at rest flow", func() {
+	Context("with a running server", func() {
+		var setup *SharedTestSetup
+		var dbPath string
+
+		BeforeEach(func() {
+			dbPath = filepath.Join(GinkgoT().TempDir(), "diary.db")
+			setup = SetupTestEnvironmentWith(func(cfg *config.Config) {
+				cfg.DBPath = dbPath
+				cfg.EncryptionKey = encryption.GenerateKey()
+			})
+			setup.LoginAndGetToken()
+		})
+
+		AfterEach(func() {
+			setup.TeardownTestEnvironment()
+		})
+
+		It("should encrypt entries in the database and keep them searchable", func() {
+			for date, tags := range map[string][]string{
+				"2024-05-01": {"secret-tag", "trip"},
+				"2024-05-02": {"work"},
+			} {
+				req := *goclient.NewItemsRequest(date, "My secret "+date, "A secret body of "+date)
+				req.SetTags(tags)
+				_, _, err := setup.APIClient.ItemsAPI.PutItems(context.Background()).ItemsRequest(req).Execute()
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			for _, column := range []string{"title", "body", "tags"} {
+				expectEncrypted(storedValues(dbPath, "SELECT "+column+" FROM items"))
+				expectEncrypted(storedValues(dbPath, "SELECT "+column+" FROM item_revisions"))
+				expectEncrypted(storedValues(dbPath, "SELECT item_"+column+" FROM item_changes WHERE item_"+column+" IS NOT NULL"))
+			}
+
+			item, _, err := setup.APIClient.ItemsAPI.GetItems(context.Background()).Date("2024-05-01").Execute()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(item.Items).To(HaveLen(1))
+			Expect(item.Items[0].Title).To(Equal("My secret 2024-05-01"))
+			Expect(item.Items[0].Tags).To(Equal([]string{"secret-tag", "trip"}))
+
+			found, _, err := setup.APIClient.ItemsAPI.GetItems(context.Background()).Search("BODY OF 2024-05-02").Execute()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found.TotalCount).To(Equal(int32(1)))
+			Expect(found.Items[0].Date).To(Equal("2024-05-02"))
+
+			found, _, err = setup.APIClient.ItemsAPI.GetItems(context.Background()).Search("secret").TagsNone("work").Execute()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found.TotalCount).To(Equal(int32(1)))
+			Expect(found.Items[0].Date).To(Equal("2024-05-01"))
+
+			tags, _, err := setup.APIClient.TagsAPI.GetTags(context.Background()).Execute()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tags).To(HaveLen(3))
+
+			renamed, _, err := setup.APIClient.TagsAPI.RenameTag(context.Background()).
+				TagRenameRequest(*goclient.NewTagRenameRequest("trip", "travel")).Execute()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(renamed.UpdatedItems).To(Equal(int32(1)))
+
+			changes, _, err := setup.APIClient.SyncAPI.GetChanges(context.Background()).Execute()
+			Expect(err).ToNot(HaveOccurred())
+			last := changes.Changes[len(changes.Changes)-1]
+			Expect(last.ItemSnapshot.Get().Tags).To(Equal([]string{"secret-tag", "travel"}))
+		})
+
+		It("should encrypt asset files and serve ranges of them", func() {
+			content := append([]byte(mp4Magic), bytes.Repeat([]byte("secret frame "), 10000)...)
+			f, err := os.Create(filepath.Join(GinkgoT().TempDir(), "clip.mp4"))
+			Expect(err).ToNot(HaveOccurred())
+			_, err = f.Write(content)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = f.Seek(0, 0)
+			Expect(err).ToNot(HaveOccurred())
+			resp, _, err := setup.APIClient.AssetsAPI.UploadAssetsBatch(context.Background()).Assets([]*os.File{f}).Execute()
+			Expect(err).ToNot(HaveOccurred())
+			name := resp.Files[0].GetSavedName()
+
+			userID, err := setup.Storage.GetUserID(setup.TestEmail)
+			Expect(err).ToNot(HaveOccurred())
+			stored, err := os.ReadFile(filepath.Join(setup.TempDir, userID, name))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(encryption.IsEncryptedStream(stored)).To(BeTrue())
+			Expect(string(stored)).ToNot(ContainSubstring("secret"))
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet,
+				setup.ServerAddr+"/v1/assets?path="+name, nil)
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set("Authorization", setup.APIClient.GetConfig().DefaultHeader["Authorization"])
+			req.Header.Set("Range", "bytes=70000-70099")
+			httpResp, err := http.DefaultClient.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			defer httpResp.Body.Close()
+			data, err := io.ReadAll(httpResp.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(httpResp.StatusCode).To(Equal(http.StatusPartialContent))
+			Expect(data).To(Equal(content[70000:70100]))
+		})
+	})
+
+	It("should encrypt existing data and rotate the master key", func() {
+		logger := slog.New(slog.NewTextHandler(GinkgoWriter, nil))
+		cfg := config.Config{DBPath: filepath.Join(GinkgoT().TempDir(), "diary.db"), AssetPath: GinkgoT().TempDir()}
+		open := func(key string) (database.Storage, error) {
+			keyCfg := cfg
+			keyCfg.EncryptionKey = key
+			storage := database.NewStorage(logger, &keyCfg)
+			return storage, storage.Open()
+		}
+
+		// Data stored before encryption was enabled
+		storage, err := open("")
+		Expect(err).ToNot(HaveOccurred())
+		user, err := storage.CreateUser("encryption@test.com", "password")
+		Expect(err).ToNot(HaveOccurred())
+		userID := user.ID.String()
+		Expect(storage.PutItem(userID, &models.Item{Date: "2024-05-01", Title: "My secret", Body: "secret body"})).To(Succeed())
+		local := assets.NewLocalStore(cfg.AssetPath)
+		photo := jpegMagic + strings.Repeat("secret pixels", 10)
+		Expect(local.Put(context.Background(), userID+"/photo.jpg", strings.NewReader(photo), int64(len(photo)))).To(Succeed())
+
+		oldKey := encryption.GenerateKey()
+		storage, err = open(oldKey)
+		Expect(err).ToNot(HaveOccurred())
+		expectEncrypted(storedValues(cfg.DBPath, "SELECT body FROM items"))
+		expectEncrypted(storedValues(cfg.DBPath, "SELECT body FROM item_revisions"))
+		expectEncrypted(storedValues(cfg.DBPath, "SELECT item_body FROM item_changes WHERE item_body IS NOT NULL"))
+		item, err := storage.GetItem(userID, "2024-05-01")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(item.Body).To(Equal("secret body"))
+
+		// The migration registers the legacy photo as plaintext before encrypting it
+		store := assets.NewEncryptedStore(local, storage.Keyring(), storage)
+		Expect(assets.BackfillAssets(context.Background(), logger, storage, store)).To(Succeed())
+		encrypted, err := store.EncryptBlobs(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(encrypted).To(Equal([]string{userID + "/photo.jpg"}))
+		stored, err := os.ReadFile(filepath.Join(cfg.AssetPath, userID, "photo.jpg"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(encryption.IsEncryptedStream(stored)).To(BeTrue())
+
+		newKey := encryption.GenerateKey()
+		newMasterKey, err := encryption.ParseKey(newKey)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(storage.RotateEncryptionKey(newMasterKey)).To(Equal(1))
+
+		_, err = open(oldKey)
+		Expect(err).To(MatchError(encryption.ErrDecrypt))
+		storage, err = open(newKey)
+		Expect(err).ToNot(HaveOccurred())
+		item, err = storage.GetItem(userID, "2024-05-01")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(item.Title).To(Equal("My secret"))
+
+		r, err := assets.NewEncryptedStore(local, storage.Keyring(), storage).Get(context.Background(), userID+"/photo.jpg", 0, -1)
+		Expect(err).ToNot(HaveOccurred())
+		defer r.Close()
+		Expect(io.ReadAll(r)).To(Equal([]byte(photo)))
+	})
+})
@@ -0,0 +1,119 @@
+package flows_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ya-breeze/diary.be/pkg/database"
+	"github.com/ya-breeze/diary.be/pkg/generated/goclient"
+)
+
+var _ = Describe("Full-text search flow", func() {
+	var setup *SharedTestSetup
+
+	BeforeEach(func() {
+		if !database.FullTextSearchSupported() {
+			Skip("SQLite is built without FTS5, run tests with -tags sqlite_fts5")
+		}
+
+		setup = SetupTestEnvironment()
+		setup.LoginAndGetToken()
+
+		testItems := []struct {
+			date  string
+			title string
+			body  string
+		}{
+			{"2024-03-01", "Morning jog", "Went running along the river before work."},
+			{"2024-03-02", "River trip", "We took a boat trip on the river with friends."},
+			{"2024-03-03", "Office day", "Long meetings at work, no time to run."},
+			{"2024-03-04", "Quiet evening", "Read a book about the history of the river boats."},
+		}
+		for _, item := range testItems {
+			itemsReq := *goclient.NewItemsRequest(item.date, item.title, item.body)
+			_, httpResp, err := setup.APIClient.ItemsAPI.PutItems(context.Background()).ItemsRequest(itemsReq).Execute()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(httpResp.StatusCode).To(Equal(http.StatusOK))
+		}
+	})
+
+	AfterEach(func() {
+		if setup != nil {
+			setup.TeardownTestEnvironment()
+		}
+	})
+
+	search := func(query string) []string {
+		result, httpResp, err := setup.APIClient.ItemsAPI.GetItems(context.Background()).Search(query).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(httpResp.StatusCode).To(Equal(http.StatusOK))
+		Expect(result.TotalCount).To(BeNumerically("==", len(result.Items)))
+
+		dates := make([]string, 0, len(result.Items))
+		for _, item := range result.Items {
+			dates = append(dates, item.Date)
+		}
+		return dates
+	}
+
+	It("should match whole words only", func() {
+		Expect(search("run")).To(ConsistOf("2024-03-03"))
+	})
+
+	It("should support prefix matching", func() {
+		Expect(search("run*")).To(ConsistOf("2024-03-01", "2024-03-03"))
+	})
+
+	It("should support phrase queries", func() {
+		Expect(search(`"boat trip"`)).To(ConsistOf("2024-03-02"))
+	})
+
+	It("should support boolean operators", func() {
+		Expect(search("river NOT boat*")).To(ConsistOf("2024-03-01"))
+		Expect(search("book OR meetings")).To(ConsistOf("2024-03-03", "2024-03-04"))
+		Expect(search("river AND (friends OR history)")).To(ConsistOf("2024-03-02", "2024-03-04"))
+	})
+
+	It("should return highlighted snippets", func() {
+		result, httpResp, err := setup.APIClient.ItemsAPI.GetItems(context.Background()).Search(`"boat trip"`).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(httpResp.StatusCode).To(Equal(http.StatusOK))
+		Expect(result.Items).To(HaveLen(1))
+		Expect(result.Items[0].GetSnippet()).To(Equal("We took a <mark>boat trip</mark> on the river with friends."))
+	})
+
+	It("should rank title matches above body matches", func() {
+		results := search("river")
+		Expect(results).To(HaveLen(3))
+		Expect(results[0]).To(Equal("2024-03-02"))
+	})
+
+	It("should sort search results by date when requested", func() {
+		result, httpResp, err := setup.APIClient.ItemsAPI.GetItems(context.Background()).
+			Search("river").Sort("date_asc").Limit(2).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(httpResp.StatusCode).To(Equal(http.StatusOK))
+		Expect(result.TotalCount).To(Equal(int32(3)))
+		Expect(result.Items).To(HaveLen(2))
+		Expect(result.Items[0].Date).To(Equal("2024-03-01"))
+		Expect(result.Items[1].Date).To(Equal("2024-03-02"))
+	})
+
+	It("should keep the index in sync with updates", func() {
+		itemsReq := *goclient.NewItemsRequest("2024-03-03", "Office day", "Stayed at the desk all day.")
+		_, httpResp, err := setup.APIClient.ItemsAPI.PutItems(context.Background()).ItemsRequest(itemsReq).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(httpResp.StatusCode).To(Equal(http.StatusOK))
+
+		Expect(search("meetings")).To(BeEmpty())
+		Expect(search("desk")).To(ConsistOf("2024-03-03"))
+	})
+
+	It("should tolerate malformed input", func() {
+		Expect(search("river AND (")).To(ConsistOf("2024-03-01", "2024-03-02", "2024-03-04"))
+		Expect(search("-- ::")).To(BeEmpty())
+	})
+})
@@ -0,0 +1,58 @@
+package flows_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ya-breeze/diary.be/pkg/generated/goclient"
+)
+
+var _ = Describe("Item Conflict Flow", func() {
+	var setup *SharedTestSetup
+
+	BeforeEach(func() {
+		setup = SetupTestEnvironment()
+		setup.LoginAndGetToken()
+	})
+
+	AfterEach(func() {
+		setup.TeardownTestEnvironment()
+	})
+
+	It("should reject an update based on a stale version", func() {
+		const date = "2024-07-01"
+		created, _, err := setup.APIClient.ItemsAPI.PutItems(context.Background()).
+			ItemsRequest(*goclient.NewItemsRequest(date, "Day", "Original")).IfMatch("0").Execute()
+		Expect(err).ToNot(HaveOccurred())
+		version := strconv.FormatInt(created.GetVersion(), 10)
+
+		// The first device saves its change based on the version it has seen
+		updated, _, err := setup.APIClient.ItemsAPI.PutItems(context.Background()).
+			ItemsRequest(*goclient.NewItemsRequest(date, "Day", "From phone")).IfMatch(`"` + version + `"`).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated.GetVersion()).To(Equal(created.GetVersion() + 1))
+
+		// The second device is still based on the old version
+		_, httpResp, err := setup.APIClient.ItemsAPI.PutItems(context.Background()).
+			ItemsRequest(*goclient.NewItemsRequest(date, "Day", "From laptop")).IfMatch(version).Execute()
+		Expect(err).To(HaveOccurred())
+		Expect(httpResp.StatusCode).To(Equal(http.StatusConflict))
+
+		var apiErr *goclient.GenericOpenAPIError
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		serverCopy, ok := apiErr.Model().(goclient.ItemsResponse)
+		Expect(ok).To(BeTrue())
+		Expect(serverCopy.Body).To(Equal("From phone"))
+		Expect(serverCopy.GetVersion()).To(Equal(updated.GetVersion()))
+
+		fetched, _, err := setup.APIClient.ItemsAPI.GetItems(context.Background()).Date(date).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetched.Items[0].Body).To(Equal("From phone"))
+		Expect(fetched.Items[0].GetVersion()).To(Equal(updated.GetVersion()))
+	})
+})
@@ -0,0 +1,151 @@
+package flows_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ya-breeze/diary.be/pkg/database"
+)
+
+// gpsLatitude is the GPS latitude written by exifJPEG, 50/1 degrees, minutes and seconds
+//
+//nolint:gochecknoglobals
+var gpsLatitude = bytes.Repeat([]byte{50, 0, 0, 0, 1, 0, 0, 0}, 3)
+
+// exifJPEG returns a 40x20 JPEG with an EXIF segment holding the orientation, the capture time
+// and a GPS latitude
+func exifJPEG(orientation uint16, captured string) []byte {
+	le := binary.LittleEndian
+	tiff := make([]byte, 142)
+	copy(tiff, "II")
+	le.PutUint16(tiff[2:], 42)
+	le.PutUint32(tiff[4:], 8)
+
+	entry := func(pos int, tag, typ uint16, count, value uint32) {
+		le.PutUint16(tiff[pos:], tag)
+		le.PutUint16(tiff[pos+2:], typ)
+		le.PutUint32(tiff[pos+4:], count)
+		le.PutUint32(tiff[pos+8:], value)
+	}
+	// IFD0 at 8: orientation, Exif and GPS pointers
+	le.PutUint16(tiff[8:], 3)
+	entry(10, 0x0112, 3, 1, uint32(orientation))
+	entry(22, 0x8769, 4, 1, 50)
+	entry(34, 0x8825, 4, 1, 88)
+	// Exif IFD at 50: DateTimeOriginal stored at 68
+	le.PutUint16(tiff[50:], 1)
+	entry(52, 0x9003, 2, 20, 68)
+	copy(tiff[68:], captured)
+	// GPS IFD at 88: latitude reference and latitude stored at 118
+	le.PutUint16(tiff[88:], 2)
+	entry(90, 0x0001, 2, 2, uint32('N'))
+	entry(102, 0x0002, 5, 3, 118)
+	copy(tiff[118:], gpsLatitude)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	header := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(header[2:], uint16(len(segment)+2))
+
+	var encoded bytes.Buffer
+	Expect(jpeg.Encode(&encoded, image.NewRGBA(image.Rect(0, 0, 40, 20)), nil)).To(Succeed())
+	result := append([]byte{}, encoded.Bytes()[:2]...)
+	result = append(result, header...)
+	result = append(result, segment...)
+	return append(result, encoded.Bytes()[2:]...)
+}
+
+var _ = Describe("Photo Metadata Flow", func() {
+	var setup *SharedTestSetup
+
+	BeforeEach(func() {
+		setup = SetupTestEnvironment()
+		setup.LoginAndGetToken()
+	})
+
+	AfterEach(func() {
+		setup.TeardownTestEnvironment()
+	})
+
+	upload := func(content []byte) (string, *time.Time) {
+		f, err := os.CreateTemp(setup.TempDir, "photo_*.jpg")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = f.Write(content)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = f.Seek(0, 0)
+		Expect(err).ToNot(HaveOccurred())
+
+		resp, httpResp, err := setup.APIClient.AssetsAPI.UploadAssetsBatch(context.Background()).
+			Assets([]*os.File{f}).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(httpResp.StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Files).To(HaveLen(1))
+		return resp.Files[0].GetSavedName(), resp.Files[0].CapturedAt.Get()
+	}
+
+	storedPath := func(name string) string {
+		user, err := setup.Storage.GetUserID(setup.TestEmail)
+		Expect(err).ToNot(HaveOccurred())
+		return filepath.Join(setup.Cfg.AssetPath, user, name)
+	}
+
+	It("should rotate the photo upright and record when it was taken", func() {
+		name, capturedAt := upload(exifJPEG(6, "2024:05:01 18:30:00"))
+		Expect(capturedAt).ToNot(BeNil())
+		Expect(capturedAt.Format(time.DateOnly)).To(Equal("2024-05-01"))
+
+		data, err := os.ReadFile(storedPath(name))
+		Expect(err).ToNot(HaveOccurred())
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(data))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.Width).To(Equal(20))
+		Expect(cfg.Height).To(Equal(40))
+		// The EXIF data is kept, the location as well unless stripping is enabled
+		Expect(data).To(ContainSubstring("2024:05:01 18:30:00"))
+		Expect(bytes.Contains(data, gpsLatitude)).To(BeTrue())
+
+		user, err := setup.Storage.GetUserID(setup.TestEmail)
+		Expect(err).ToNot(HaveOccurred())
+		registered, _, err := setup.Storage.GetAssets(user, database.AssetFilter{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(registered).To(HaveLen(1))
+		Expect(registered[0].CapturedAt).ToNot(BeNil())
+		Expect(registered[0].CapturedAt.Format(time.DateOnly)).To(Equal("2024-05-01"))
+	})
+
+	It("should strip the location if configured", func() {
+		setup.Cfg.StripPhotoLocation = true
+		original := exifJPEG(1, "2023:12:24 09:00:00")
+
+		name, capturedAt := upload(original)
+		Expect(capturedAt).ToNot(BeNil())
+
+		data, err := os.ReadFile(storedPath(name))
+		Expect(err).ToNot(HaveOccurred())
+		// Upright photos aren't re-encoded, only the GPS data is cleared
+		Expect(data).To(HaveLen(len(original)))
+		Expect(data).ToNot(Equal(original))
+		Expect(bytes.Contains(data, gpsLatitude)).To(BeFalse())
+		Expect(data).To(ContainSubstring("2023:12:24 09:00:00"))
+	})
+
+	It("should store photos without EXIF data unchanged", func() {
+		var encoded bytes.Buffer
+		Expect(jpeg.Encode(&encoded, image.NewRGBA(image.Rect(0, 0, 10, 10)), nil)).To(Succeed())
+
+		name, capturedAt := upload(encoded.Bytes())
+		Expect(capturedAt).To(BeNil())
+		data, err := os.ReadFile(storedPath(name))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(Equal(encoded.Bytes()))
+	})
+})
@@ -0,0 +1,221 @@
+package flows_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ya-breeze/diary.be/pkg/server/assets"
+)
+
+var _ = Describe("Resumable Upload Flow", func() {
+	var setup *SharedTestSetup
+	var token string
+	var content []byte
+
+	BeforeEach(func() {
+		setup = SetupTestEnvironment()
+		token = setup.LoginAndGetToken()
+		content = append([]byte(mp4Magic), bytes.Repeat([]byte("video frame "), 1000)...)
+	})
+
+	AfterEach(func() {
+		setup.TeardownTestEnvironment()
+	})
+
+	request := func(method, path string, header map[string]string, body io.Reader) (*http.Response, []byte) {
+		req, err := http.NewRequestWithContext(context.Background(), method, setup.ServerAddr+path, body)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set("Authorization", "Bearer "+token)
+		for key, value := range header {
+			req.Header.Set(key, value)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		return resp, data
+	}
+
+	create := func(filename string, size int) assets.Upload {
+		body, err := json.Marshal(map[string]any{"filename": filename, "size": size})
+		Expect(err).ToNot(HaveOccurred())
+		resp, data := request(http.MethodPost, "/v1/assets/uploads", nil, bytes.NewReader(body))
+		Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+		var upload assets.Upload
+		Expect(json.Unmarshal(data, &upload)).To(Succeed())
+		return upload
+	}
+
+	appendChunk := func(id string, offset int, chunk []byte) *http.Response {
+		resp, _ := request(http.MethodPatch, "/v1/assets/uploads/"+id,
+			map[string]string{"Upload-Offset": strconv.Itoa(offset), "Content-Type": "application/offset+octet-stream"},
+			bytes.NewReader(chunk))
+		return resp
+	}
+
+	complete := func(id, checksum string) (*http.Response, []byte) {
+		body, err := json.Marshal(map[string]string{"sha256": checksum})
+		Expect(err).ToNot(HaveOccurred())
+		return request(http.MethodPost, "/v1/assets/uploads/"+id+"/complete", nil, bytes.NewReader(body))
+	}
+
+	uploadBlobs := func() []assets.BlobInfo {
+		userID, err := setup.Storage.GetUserID(setup.TestEmail)
+		Expect(err).ToNot(HaveOccurred())
+		blobs, err := assets.NewLocalStore(setup.TempDir).List(context.Background(), userID+"/"+assets.UploadsDirName+"/")
+		Expect(err).ToNot(HaveOccurred())
+		return blobs
+	}
+
+	It("should resume an interrupted upload and commit it as an asset", func() {
+		upload := create("clip.mp4", len(content))
+		Expect(upload.Offset).To(BeZero())
+		half := len(content) / 2
+
+		resp := appendChunk(upload.ID, 0, content[:half])
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Header.Get("Upload-Offset")).To(Equal(strconv.Itoa(half)))
+
+		// A chunk sent at a stale offset is rejected with the offset to continue from
+		resp = appendChunk(upload.ID, 0, content[:half])
+		Expect(resp.StatusCode).To(Equal(http.StatusConflict))
+		Expect(resp.Header.Get("Upload-Offset")).To(Equal(strconv.Itoa(half)))
+
+		resp, data := request(http.MethodGet, "/v1/assets/uploads/"+upload.ID, nil, nil)
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		var current assets.Upload
+		Expect(json.Unmarshal(data, &current)).To(Succeed())
+		Expect(current.Offset).To(Equal(int64(half)))
+
+		// Completing early fails without losing the received content
+		hash := sha256.Sum256(content)
+		resp, _ = complete(upload.ID, hex.EncodeToString(hash[:]))
+		Expect(resp.StatusCode).To(Equal(http.StatusConflict))
+
+		Expect(appendChunk(upload.ID, half, content[half:]).StatusCode).To(Equal(http.StatusOK))
+		resp, data = complete(upload.ID, hex.EncodeToString(hash[:]))
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		var saved map[string]any
+		Expect(json.Unmarshal(data, &saved)).To(Succeed())
+		Expect(saved["savedName"]).To(Equal(hex.EncodeToString(hash[:]) + ".mp4"))
+		Expect(saved["originalName"]).To(Equal("clip.mp4"))
+		Expect(saved["contentType"]).To(Equal("video/mp4"))
+
+		rd, _, err := setup.APIClient.AssetsAPI.GetAsset(context.Background()).Path(saved["savedName"].(string)).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		stored, err := io.ReadAll(rd)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stored).To(Equal(content))
+
+		list, _, err := setup.APIClient.AssetsAPI.ListAssets(context.Background()).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list.Assets).To(HaveLen(1))
+		Expect(uploadBlobs()).To(BeEmpty())
+	})
+
+	It("should reject content that doesn't match the checksum", func() {
+		upload := create("clip.mp4", len(content))
+		Expect(appendChunk(upload.ID, 0, content).StatusCode).To(Equal(http.StatusOK))
+
+		resp, _ := complete(upload.ID, hex.EncodeToString(make([]byte, sha256.Size)))
+		Expect(resp.StatusCode).To(Equal(http.StatusUnprocessableEntity))
+		list, _, err := setup.APIClient.AssetsAPI.ListAssets(context.Background()).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list.Assets).To(BeEmpty())
+
+		// The upload is kept, so it can be completed with the right checksum
+		resp, _ = request(http.MethodGet, "/v1/assets/uploads/"+upload.ID, nil, nil)
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		hash := sha256.Sum256(content)
+		resp, _ = complete(upload.ID, hex.EncodeToString(hash[:]))
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		resp, _ = request(http.MethodGet, "/v1/assets/uploads/"+upload.ID, nil, nil)
+		Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+	})
+
+	It("should remove uploads with rejected content", func() {
+		upload := create("clip.mp4", len(content))
+		Expect(appendChunk(upload.ID, 0, bytes.Repeat([]byte("x"), len(content))).StatusCode).To(Equal(http.StatusOK))
+
+		hash := sha256.Sum256(bytes.Repeat([]byte("x"), len(content)))
+		resp, _ := complete(upload.ID, hex.EncodeToString(hash[:]))
+		Expect(resp.StatusCode).To(Equal(http.StatusUnprocessableEntity))
+		resp, _ = request(http.MethodGet, "/v1/assets/uploads/"+upload.ID, nil, nil)
+		Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+	})
+
+	It("should count uploads in progress toward the quota", func() {
+		setup.Cfg.UserQuotaFiles = 1
+		upload := create("clip.mp4", len(content))
+
+		body := bytes.NewReader([]byte(`{"filename": "other.mp4", "size": 10}`))
+		resp, _ := request(http.MethodPost, "/v1/assets/uploads", nil, body)
+		Expect(resp.StatusCode).To(Equal(http.StatusInsufficientStorage))
+		f, err := os.Create(filepath.Join(GinkgoT().TempDir(), "other.mp4"))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = f.WriteString(mp4Magic + "other")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = f.Seek(0, 0)
+		Expect(err).ToNot(HaveOccurred())
+		_, httpResp, _ := setup.APIClient.AssetsAPI.UploadAssetsBatch(context.Background()).Assets([]*os.File{f}).Execute()
+		Expect(httpResp.StatusCode).To(Equal(http.StatusInsufficientStorage))
+
+		// The upload itself can be completed
+		Expect(appendChunk(upload.ID, 0, content).StatusCode).To(Equal(http.StatusOK))
+		hash := sha256.Sum256(content)
+		resp, _ = complete(upload.ID, hex.EncodeToString(hash[:]))
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	It("should reject content beyond the announced size", func() {
+		upload := create("clip.mp4", 10)
+
+		resp := appendChunk(upload.ID, 0, content[:11])
+		Expect(resp.StatusCode).To(Equal(http.StatusRequestEntityTooLarge))
+		Expect(resp.Header.Get("Upload-Offset")).To(Equal("0"))
+	})
+
+	It("should reject invalid uploads", func() {
+		body := bytes.NewReader([]byte(`{"filename": "tool.exe", "size": 10}`))
+		resp, _ := request(http.MethodPost, "/v1/assets/uploads", nil, body)
+		Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+
+		resp, _ = request(http.MethodGet, "/v1/assets/uploads/../../secret", nil, nil)
+		Expect(resp.StatusCode).To(BeNumerically(">=", 400))
+		resp, _ = request(http.MethodGet, "/v1/assets/uploads/not-an-id", nil, nil)
+		Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+	})
+
+	It("should abort uploads and collect abandoned ones", func() {
+		aborted := create("clip.mp4", len(content))
+		resp, _ := request(http.MethodDelete, "/v1/assets/uploads/"+aborted.ID, nil, nil)
+		Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+		resp, _ = request(http.MethodGet, "/v1/assets/uploads/"+aborted.ID, nil, nil)
+		Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+
+		abandoned := create("clip.mp4", len(content))
+		Expect(appendChunk(abandoned.ID, 0, content[:100]).StatusCode).To(Equal(http.StatusOK))
+
+		collector := assets.NewGarbageCollector(setup.Logger, setup.Cfg, setup.Storage, assets.NewLocalStore(setup.TempDir))
+		report, err := collector.Collect(context.Background(), time.Now().Add(time.Minute), false)
+		Expect(err).ToNot(HaveOccurred())
+		// The description and the received chunk of the abandoned upload
+		Expect(report.TempFiles).To(HaveLen(2))
+		Expect(uploadBlobs()).To(BeEmpty())
+		resp, _ = request(http.MethodGet, "/v1/assets/uploads/"+abandoned.ID, nil, nil)
+		Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+	})
+})
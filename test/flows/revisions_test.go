@@ -0,0 +1,62 @@
+package flows_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ya-breeze/diary.be/pkg/generated/goclient"
+)
+
+var _ = Describe("Revision History Flow", func() {
+	var setup *SharedTestSetup
+
+	BeforeEach(func() {
+		setup = SetupTestEnvironment()
+		setup.LoginAndGetToken()
+	})
+
+	AfterEach(func() {
+		setup.TeardownTestEnvironment()
+	})
+
+	It("should list, compare and restore revisions", func() {
+		const date = "2024-06-01"
+		for _, body := range []string{"Morning\nEvening", "Morning\nNight"} {
+			itemsReq := *goclient.NewItemsRequest(date, "Day", body)
+			_, httpResp, err := setup.APIClient.ItemsAPI.PutItems(context.Background()).ItemsRequest(itemsReq).Execute()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(httpResp.StatusCode).To(Equal(http.StatusOK))
+		}
+
+		revisions, _, err := setup.APIClient.ItemsAPI.GetItemRevisions(context.Background(), date).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(revisions).To(HaveLen(2))
+		Expect(revisions[0].Body).To(Equal("Morning\nNight"))
+
+		diff, _, err := setup.APIClient.ItemsAPI.GetItemRevisionsDiff(context.Background(), date).
+			From(revisions[1].Id).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diff.ToId).To(Equal(revisions[0].Id))
+		Expect(diff.Body).To(ContainElement(*goclient.NewDiffLine("delete", "Evening")))
+		Expect(diff.Body).To(ContainElement(*goclient.NewDiffLine("insert", "Night")))
+
+		restored, _, err := setup.APIClient.ItemsAPI.RestoreItemRevision(context.Background(), date, revisions[1].Id).
+			Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(restored.Body).To(Equal("Morning\nEvening"))
+
+		fetched, _, err := setup.APIClient.ItemsAPI.GetItems(context.Background()).Date(date).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetched.Items).To(HaveLen(1))
+		Expect(fetched.Items[0].Body).To(Equal("Morning\nEvening"))
+	})
+
+	It("should return 404 for an unknown revision", func() {
+		_, httpResp, err := setup.APIClient.ItemsAPI.RestoreItemRevision(context.Background(), "2024-06-02", 12345).Execute()
+		Expect(err).To(HaveOccurred())
+		Expect(httpResp.StatusCode).To(Equal(http.StatusNotFound))
+	})
+})
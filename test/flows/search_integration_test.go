@@ -245,5 +245,81 @@ var _ = Describe("Search Integration Flow", func() {
 				Expect(searchResult.Items[3].Date).To(Equal("2024-01-10"))
 			})
 		})
+
+		Context("when combining tag conditions", func() {
+			It("should support all-of and none-of tags", func() {
+				searchResult, httpResp, err := setup.APIClient.ItemsAPI.GetItems(context.Background()).
+					TagsAll("work,project").TagsNone("review").Execute()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(httpResp.StatusCode).To(Equal(http.StatusOK))
+
+				Expect(searchResult.Items).To(HaveLen(1))
+				Expect(searchResult.Items[0].Date).To(Equal("2024-01-11"))
+			})
+		})
+
+		Context("when filtering by date range", func() {
+			It("should return items within the range", func() {
+				searchResult, httpResp, err := setup.APIClient.ItemsAPI.GetItems(context.Background()).
+					DateFrom("2024-01-11").DateTo("2024-01-12").Execute()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(httpResp.StatusCode).To(Equal(http.StatusOK))
+
+				Expect(searchResult.TotalCount).To(Equal(int32(2)))
+				Expect(searchResult.Items).To(HaveLen(2))
+				Expect(searchResult.Items[0].Date).To(Equal("2024-01-12"))
+				Expect(searchResult.Items[1].Date).To(Equal("2024-01-11"))
+			})
+
+			It("should combine the range with other filters", func() {
+				searchResult, httpResp, err := setup.APIClient.ItemsAPI.GetItems(context.Background()).
+					DateFrom("2024-01-12").Tags("project").Execute()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(httpResp.StatusCode).To(Equal(http.StatusOK))
+
+				Expect(searchResult.Items).To(HaveLen(1))
+				Expect(searchResult.Items[0].Date).To(Equal("2024-01-13"))
+			})
+		})
+
+		Context("when paginating", func() {
+			It("should return pages in the requested order", func() {
+				searchResult, httpResp, err := setup.APIClient.ItemsAPI.GetItems(context.Background()).
+					Sort("date_asc").Limit(3).Offset(2).Execute()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(httpResp.StatusCode).To(Equal(http.StatusOK))
+
+				Expect(searchResult.TotalCount).To(Equal(int32(4)))
+				Expect(searchResult.Items).To(HaveLen(2))
+				Expect(searchResult.Items[0].Date).To(Equal("2024-01-12"))
+				Expect(searchResult.Items[1].Date).To(Equal("2024-01-13"))
+			})
+
+			It("should omit fields that are not requested", func() {
+				searchResult, httpResp, err := setup.APIClient.ItemsAPI.GetItems(context.Background()).
+					Tags("work").Fields("title").Execute()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(httpResp.StatusCode).To(Equal(http.StatusOK))
+
+				Expect(searchResult.Items).To(HaveLen(2))
+				for _, item := range searchResult.Items {
+					Expect(item.Title).ToNot(BeEmpty())
+					Expect(item.Body).To(BeEmpty())
+					Expect(item.Tags).To(BeEmpty())
+					Expect(item.HasPreviousDate()).To(BeFalse())
+					Expect(item.HasNextDate()).To(BeFalse())
+				}
+			})
+
+			It("should reject invalid parameters", func() {
+				_, httpResp, err := setup.APIClient.ItemsAPI.GetItems(context.Background()).Limit(0).Execute()
+				Expect(err).To(HaveOccurred())
+				Expect(httpResp.StatusCode).To(Equal(http.StatusBadRequest))
+
+				_, httpResp, err = setup.APIClient.ItemsAPI.GetItems(context.Background()).Sort("title").Execute()
+				Expect(err).To(HaveOccurred())
+				Expect(httpResp.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
 	})
 })
@@ -18,6 +18,13 @@ import (
 	"github.com/ya-breeze/diary.be/pkg/server"
 )
 
+// Magic bytes that make uploaded test content detected as the type of its extension
+const (
+	jpegMagic = "\xff\xd8\xff\xe0"
+	pngMagic  = "\x89PNG\r\n\x1a\n"
+	mp4Magic  = "\x00\x00\x00\x18ftypmp42"
+)
+
 // SharedTestSetup contains all the shared test infrastructure
 //
 //nolint:containedctx
@@ -41,6 +48,12 @@ func newCancellableContext() (context.Context, context.CancelFunc) {
 
 // SetupTestEnvironment creates and configures the shared test environment
 func SetupTestEnvironment() *SharedTestSetup {
+	return SetupTestEnvironmentWith(nil)
+}
+
+// SetupTestEnvironmentWith creates the shared test environment, configure can change the config
+// before the storage is opened
+func SetupTestEnvironmentWith(configure func(cfg *config.Config)) *SharedTestSetup {
 	setup := &SharedTestSetup{}
 
 	setup.Logger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
@@ -57,6 +70,9 @@ func SetupTestEnvironment() *SharedTestSetup {
 		Issuer:    "test-issuer",
 		JWTSecret: "test-secret-key-for-jwt-tokens",
 	}
+	if configure != nil {
+		configure(setup.Cfg)
+	}
 
 	setup.Storage = database.NewStorage(setup.Logger, setup.Cfg)
 	Expect(setup.Storage.Open()).To(Succeed())
@@ -0,0 +1,71 @@
+package flows_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ya-breeze/diary.be/pkg/generated/goclient"
+)
+
+var _ = Describe("Sync Assets Flow", func() {
+	var setup *SharedTestSetup
+
+	BeforeEach(func() {
+		setup = SetupTestEnvironment()
+		setup.LoginAndGetToken()
+	})
+
+	AfterEach(func() {
+		setup.TeardownTestEnvironment()
+	})
+
+	It("should report uploaded assets in the change feed and the snapshot", func() {
+		_, _, err := setup.APIClient.ItemsAPI.PutItems(context.Background()).
+			ItemsRequest(*goclient.NewItemsRequest("2024-11-01", "Entry", "Body")).Execute()
+		Expect(err).ToNot(HaveOccurred())
+
+		f, err := os.CreateTemp("", "sync_asset_*.png")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.Remove(f.Name())
+		defer f.Close()
+		_, err = f.WriteString(pngMagic + "png content")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = f.Seek(0, 0)
+		Expect(err).ToNot(HaveOccurred())
+
+		uploaded, _, err := setup.APIClient.AssetsAPI.UploadAssetsBatch(context.Background()).
+			Assets([]*os.File{f}).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(uploaded.Files).To(HaveLen(1))
+		name := uploaded.Files[0].GetSavedName()
+		hash := sha256.Sum256([]byte(pngMagic + "png content"))
+
+		changes, httpResp, err := setup.APIClient.SyncAPI.GetChanges(context.Background()).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(httpResp.StatusCode).To(Equal(http.StatusOK))
+		Expect(changes.Changes).To(HaveLen(2))
+		Expect(changes.Changes[0].EntityType).To(Equal("item"))
+		Expect(changes.Changes[0].AssetSnapshot.IsSet()).To(BeFalse())
+
+		change := changes.Changes[1]
+		Expect(change.EntityType).To(Equal("asset"))
+		Expect(change.OperationType).To(Equal("created"))
+		Expect(change.Date).To(BeEmpty())
+		Expect(change.ItemSnapshot.IsSet()).To(BeFalse())
+		Expect(change.AssetSnapshot.Get()).To(Equal(goclient.NewSyncAssetSnapshot(
+			name, int64(len(pngMagic+"png content")), "image/png", hex.EncodeToString(hash[:]))))
+
+		snapshot, _, err := setup.APIClient.SyncAPI.GetSnapshot(context.Background()).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(snapshot.Items).To(HaveLen(1))
+		Expect(snapshot.Assets).To(HaveLen(1))
+		Expect(snapshot.Assets[0].Name).To(Equal(name))
+		Expect(snapshot.ChangeId).To(Equal(change.Id))
+	})
+})
@@ -180,7 +180,7 @@ var _ = Describe("Sync Integration Flow", func() {
 			Expect(page3.HasMore).To(BeFalse())
 
 			// Verify no duplicate changes across pages
-			allIDs := make(map[int32]bool)
+			allIDs := make(map[int64]bool)
 			for _, change := range page1.Changes {
 				allIDs[change.Id] = true
 			}
@@ -223,5 +223,14 @@ var _ = Describe("Sync Integration Flow", func() {
 				Expect(*syncResponse.NextId).To(BeNumerically("==", 0))
 			}
 		})
+
+		It("should accept change IDs beyond int32", func() {
+			syncResponse, httpResponse, err := setup.APIClient.SyncAPI.GetChanges(context.Background()).
+				Since(1 << 40).Execute()
+			Expect(err).NotTo(HaveOccurred())
+			defer httpResponse.Body.Close()
+			Expect(httpResponse.StatusCode).To(Equal(http.StatusOK))
+			Expect(syncResponse.Changes).To(BeEmpty())
+		})
 	})
 })
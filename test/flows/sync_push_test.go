@@ -0,0 +1,63 @@
+package flows_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ya-breeze/diary.be/pkg/generated/goclient"
+)
+
+var _ = Describe("Sync Push Flow", func() {
+	var setup *SharedTestSetup
+
+	BeforeEach(func() {
+		setup = SetupTestEnvironment()
+		setup.LoginAndGetToken()
+	})
+
+	AfterEach(func() {
+		setup.TeardownTestEnvironment()
+	})
+
+	It("should push offline changes and report conflicts", func() {
+		// The server copy is changed after the client pulled
+		pulled, _, err := setup.APIClient.SyncAPI.GetChanges(context.Background()).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		baseID := int64(0)
+		if len(pulled.Changes) > 0 {
+			baseID = pulled.Changes[len(pulled.Changes)-1].Id
+		}
+		_, _, err = setup.APIClient.ItemsAPI.PutItems(context.Background()).
+			ItemsRequest(*goclient.NewItemsRequest("2024-08-01", "Server", "Edited on the web")).Execute()
+		Expect(err).ToNot(HaveOccurred())
+
+		conflicting := goclient.NewSyncPushChange("upsert", "2024-08-01")
+		conflicting.SetClientId("a")
+		conflicting.SetBody("Edited offline")
+		created := goclient.NewSyncPushChange("upsert", "2024-08-02")
+		created.SetClientId("b")
+		created.SetTitle("Offline entry")
+		request := goclient.NewSyncPushRequest([]goclient.SyncPushChange{*conflicting, *created})
+		request.SetBaseChangeId(baseID)
+
+		pushed, httpResp, err := setup.APIClient.SyncAPI.PushChanges(context.Background()).
+			SyncPushRequest(*request).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(httpResp.StatusCode).To(Equal(http.StatusOK))
+		Expect(pushed.Results).To(HaveLen(2))
+		Expect(pushed.Results[0].GetClientId()).To(Equal("a"))
+		Expect(pushed.Results[0].Status).To(Equal("conflict"))
+		Expect(pushed.Results[0].GetServerSnapshot().Body).To(Equal("Edited on the web"))
+		Expect(pushed.Results[1].Status).To(Equal("applied"))
+
+		// The latest change ID returned by the push is the change of the applied entry
+		changes, _, err := setup.APIClient.SyncAPI.GetChanges(context.Background()).Since(baseID).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		last := changes.Changes[len(changes.Changes)-1]
+		Expect(last.Id).To(Equal(pushed.LatestChangeId))
+		Expect(last.Date).To(Equal("2024-08-02"))
+	})
+})
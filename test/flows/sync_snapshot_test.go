@@ -0,0 +1,51 @@
+package flows_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ya-breeze/diary.be/pkg/generated/goclient"
+)
+
+var _ = Describe("Sync Snapshot Flow", func() {
+	var setup *SharedTestSetup
+
+	BeforeEach(func() {
+		setup = SetupTestEnvironment()
+		setup.LoginAndGetToken()
+	})
+
+	AfterEach(func() {
+		setup.TeardownTestEnvironment()
+	})
+
+	It("should bootstrap from the snapshot and continue with changes", func() {
+		for _, date := range []string{"2024-09-01", "2024-09-02"} {
+			_, _, err := setup.APIClient.ItemsAPI.PutItems(context.Background()).
+				ItemsRequest(*goclient.NewItemsRequest(date, "Entry", "Body")).Execute()
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		snapshot, httpResp, err := setup.APIClient.SyncAPI.GetSnapshot(context.Background()).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(httpResp.StatusCode).To(Equal(http.StatusOK))
+		Expect(snapshot.Items).To(HaveLen(2))
+		Expect(snapshot.Items[0].Date).To(Equal("2024-09-01"))
+
+		changes, _, err := setup.APIClient.SyncAPI.GetChanges(context.Background()).Since(snapshot.ChangeId).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes.Changes).To(BeEmpty())
+
+		_, _, err = setup.APIClient.ItemsAPI.PutItems(context.Background()).
+			ItemsRequest(*goclient.NewItemsRequest("2024-09-03", "Later", "Body")).Execute()
+		Expect(err).ToNot(HaveOccurred())
+
+		changes, _, err = setup.APIClient.SyncAPI.GetChanges(context.Background()).Since(snapshot.ChangeId).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes.Changes).To(HaveLen(1))
+		Expect(changes.Changes[0].Date).To(Equal("2024-09-03"))
+	})
+})
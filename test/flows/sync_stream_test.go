@@ -0,0 +1,127 @@
+package flows_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ya-breeze/diary.be/pkg/generated/goclient"
+)
+
+type streamEvent struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// readStreamEvent reads the next event of a server-sent event stream, skipping comments
+func readStreamEvent(reader *bufio.Reader) streamEvent {
+	var event streamEvent
+	for {
+		line, err := reader.ReadString('\n')
+		Expect(err).ToNot(HaveOccurred())
+		line = strings.TrimSuffix(line, "\n")
+
+		switch {
+		case line == "":
+			if event.Data != "" {
+				return event
+			}
+		case strings.HasPrefix(line, "id: "):
+			event.ID = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			event.Event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			event.Data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+var _ = Describe("Sync Stream Flow", func() {
+	var setup *SharedTestSetup
+	var token string
+	var ctx context.Context
+	var cancel context.CancelFunc
+
+	BeforeEach(func() {
+		setup = SetupTestEnvironment()
+		token = setup.LoginAndGetToken()
+		ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	})
+
+	AfterEach(func() {
+		cancel()
+		setup.TeardownTestEnvironment()
+	})
+
+	openStream := func(lastEventID string) *http.Response {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, setup.ServerAddr+"/v1/sync/stream", nil)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set("Authorization", "Bearer "+token)
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(resp.Body.Close)
+		return resp
+	}
+
+	putItem := func(date, title string) {
+		_, _, err := setup.APIClient.ItemsAPI.PutItems(context.Background()).
+			ItemsRequest(*goclient.NewItemsRequest(date, title, "Body")).Execute()
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	It("should push committed changes and resume from Last-Event-ID", func() {
+		putItem("2024-10-01", "Before")
+
+		resp := openStream("")
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Header.Get("Content-Type")).To(Equal("text/event-stream"))
+		reader := bufio.NewReader(resp.Body)
+
+		// The stream starts after the latest change, so only the new change is sent
+		putItem("2024-10-02", "Live")
+		event := readStreamEvent(reader)
+		Expect(event.Event).To(Equal("change"))
+		var change goclient.SyncChangeResponse
+		Expect(json.Unmarshal([]byte(event.Data), &change)).To(Succeed())
+		Expect(change.Date).To(Equal("2024-10-02"))
+		Expect(change.OperationType).To(Equal("created"))
+		Expect(event.ID).To(Equal(strconv.Itoa(int(change.Id))))
+		Expect(resp.Body.Close()).To(Succeed())
+
+		// Changes made while the client was disconnected are sent on reconnect
+		putItem("2024-10-03", "Offline")
+		_, err := setup.APIClient.ItemsAPI.DeleteItems(context.Background()).Date("2024-10-02").Execute()
+		Expect(err).ToNot(HaveOccurred())
+
+		reader = bufio.NewReader(openStream(event.ID).Body)
+		event = readStreamEvent(reader)
+		Expect(json.Unmarshal([]byte(event.Data), &change)).To(Succeed())
+		Expect(change.Date).To(Equal("2024-10-03"))
+		event = readStreamEvent(reader)
+		Expect(json.Unmarshal([]byte(event.Data), &change)).To(Succeed())
+		Expect(change.Date).To(Equal("2024-10-02"))
+		Expect(change.OperationType).To(Equal("deleted"))
+	})
+
+	It("should reject an invalid Last-Event-ID", func() {
+		resp := openStream("abc")
+		Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should require authentication", func() {
+		token = "invalid"
+		resp := openStream("")
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+	})
+})
@@ -0,0 +1,79 @@
+package flows_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ya-breeze/diary.be/pkg/generated/goclient"
+)
+
+var _ = Describe("Tag catalogue flow", func() {
+	var setup *SharedTestSetup
+
+	BeforeEach(func() {
+		setup = SetupTestEnvironment()
+		setup.LoginAndGetToken()
+
+		for date, tags := range map[string][]string{
+			"2024-03-01": {"trip", "family"},
+			"2024-03-02": {"vacation"},
+			"2024-03-03": {"work"},
+		} {
+			req := *goclient.NewItemsRequest(date, "Entry "+date, "Body")
+			req.SetTags(tags)
+			_, httpResp, err := setup.APIClient.ItemsAPI.PutItems(context.Background()).ItemsRequest(req).Execute()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(httpResp.StatusCode).To(Equal(http.StatusOK))
+		}
+	})
+
+	AfterEach(func() {
+		setup.TeardownTestEnvironment()
+	})
+
+	It("should list, merge and rename tags and expose the changes to sync clients", func() {
+		tags, httpResp, err := setup.APIClient.TagsAPI.GetTags(context.Background()).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(httpResp.StatusCode).To(Equal(http.StatusOK))
+		Expect(tags).To(HaveLen(4))
+
+		changes, _, err := setup.APIClient.SyncAPI.GetChanges(context.Background()).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		since := changes.Changes[len(changes.Changes)-1].Id
+
+		merged, _, err := setup.APIClient.TagsAPI.MergeTags(context.Background()).
+			TagMergeRequest(*goclient.NewTagMergeRequest([]string{"trip", "vacation"}, "travel")).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(merged.UpdatedItems).To(Equal(int32(2)))
+
+		renamed, _, err := setup.APIClient.TagsAPI.RenameTag(context.Background()).
+			TagRenameRequest(*goclient.NewTagRenameRequest("work", "job")).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(renamed.UpdatedItems).To(Equal(int32(1)))
+
+		tags, _, err = setup.APIClient.TagsAPI.GetTags(context.Background()).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tags).To(ConsistOf(
+			goclient.TagResponse{Tag: "travel", Count: 2, FirstUsed: "2024-03-01", LastUsed: "2024-03-02"},
+			goclient.TagResponse{Tag: "family", Count: 1, FirstUsed: "2024-03-01", LastUsed: "2024-03-01"},
+			goclient.TagResponse{Tag: "job", Count: 1, FirstUsed: "2024-03-03", LastUsed: "2024-03-03"},
+		))
+
+		changes, _, err = setup.APIClient.SyncAPI.GetChanges(context.Background()).Since(since).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes.Changes).To(HaveLen(3))
+		for _, change := range changes.Changes {
+			Expect(change.OperationType).To(Equal("updated"))
+		}
+	})
+
+	It("should reject a rename to a blank tag", func() {
+		_, httpResp, err := setup.APIClient.TagsAPI.RenameTag(context.Background()).
+			TagRenameRequest(*goclient.NewTagRenameRequest("work", "  ")).Execute()
+		Expect(err).To(HaveOccurred())
+		Expect(httpResp.StatusCode).To(Equal(http.StatusBadRequest))
+	})
+})
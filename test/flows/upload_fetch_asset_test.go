@@ -29,7 +29,7 @@ var _ = Describe("Upload and Fetch Asset Flow", func() {
 				setup.LoginAndGetToken()
 
 				// Step 2: Create a test asset file to upload
-				testAssetContent := []byte("test image content for upload")
+				testAssetContent := []byte(jpegMagic + "test image content for upload")
 
 				// Create a temporary file for upload
 				tempFile, err := os.CreateTemp("", "test_upload_*.jpg")
@@ -75,7 +75,7 @@ var _ = Describe("Upload and Fetch Asset Flow", func() {
 		Context("when user tries to upload without authentication", func() {
 			It("should receive 401 unauthorized", func() {
 				// Create a test asset file to upload
-				testAssetContent := []byte("test image content for upload")
+				testAssetContent := []byte(jpegMagic + "test image content for upload")
 
 				// Create a temporary file for upload
 				tempFile, err := os.CreateTemp("", "test_upload_*.jpg")
@@ -105,7 +105,7 @@ var _ = Describe("Upload and Fetch Asset Flow", func() {
 				setup.LoginAndGetToken()
 
 				// Step 2: Upload first asset
-				firstAssetContent := []byte("first test image content")
+				firstAssetContent := []byte(jpegMagic + "first test image content")
 				firstTempFile, err := os.CreateTemp("", "test_upload_1_*.jpg")
 				Expect(err).ToNot(HaveOccurred())
 				defer os.Remove(firstTempFile.Name())
@@ -122,7 +122,7 @@ var _ = Describe("Upload and Fetch Asset Flow", func() {
 				firstFilename := strings.TrimSpace(firstUploadResponse)
 
 				// Step 3: Upload second asset
-				secondAssetContent := []byte("second test image content with different data")
+				secondAssetContent := []byte(jpegMagic + "second test image content with different data")
 				secondTempFile, err := os.CreateTemp("", "test_upload_2_*.jpg")
 				Expect(err).ToNot(HaveOccurred())
 				defer os.Remove(secondTempFile.Name())
@@ -0,0 +1,113 @@
+package flows_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ya-breeze/diary.be/pkg/generated/goclient"
+)
+
+var _ = Describe("User Quota Flow", func() {
+	var setup *SharedTestSetup
+
+	BeforeEach(func() {
+		setup = SetupTestEnvironment()
+		setup.LoginAndGetToken()
+	})
+
+	AfterEach(func() {
+		setup.TeardownTestEnvironment()
+	})
+
+	createFile := func(name string, size int) *os.File {
+		content := append([]byte(jpegMagic), bytes.Repeat([]byte(name), size)[:size-len(jpegMagic)]...)
+		f, err := os.Create(filepath.Join(setup.TempDir, name))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = f.Write(content)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = f.Seek(0, 0)
+		Expect(err).ToNot(HaveOccurred())
+		return f
+	}
+
+	uploadBatch := func(files ...*os.File) int {
+		_, httpResp, _ := setup.APIClient.AssetsAPI.UploadAssetsBatch(context.Background()).Assets(files).Execute()
+		return httpResp.StatusCode
+	}
+
+	getUsage := func() *goclient.UserUsage {
+		usage, httpResp, err := setup.APIClient.UserAPI.GetUserUsage(context.Background()).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(httpResp.StatusCode).To(Equal(http.StatusOK))
+		return usage
+	}
+
+	It("should report the storage used by the user", func() {
+		Expect(uploadBatch(createFile("a.jpg", 1000), createFile("b.jpg", 2000))).To(Equal(http.StatusOK))
+		itemsReq := *goclient.NewItemsRequest("2024-01-15", "Title", "Twelve bytes")
+		_, _, err := setup.APIClient.ItemsAPI.PutItems(context.Background()).ItemsRequest(itemsReq).Execute()
+		Expect(err).ToNot(HaveOccurred())
+
+		usage := getUsage()
+		Expect(usage.GetAssetBytes()).To(Equal(int64(3000)))
+		Expect(usage.GetAssetFiles()).To(Equal(int32(2)))
+		Expect(usage.GetTrashedBytes()).To(BeZero())
+		Expect(usage.GetItems()).To(Equal(int32(1)))
+		Expect(usage.GetBodyBytes()).To(Equal(int64(12)))
+		Expect(usage.QuotaBytes.IsSet()).To(BeFalse())
+		Expect(usage.RemainingBytes.IsSet()).To(BeFalse())
+	})
+
+	It("should reject uploads exceeding the size quota", func() {
+		setup.Cfg.UserQuotaMB = 1
+		const half = 512 * 1024
+
+		Expect(uploadBatch(createFile("a.jpg", half))).To(Equal(http.StatusOK))
+		usage := getUsage()
+		Expect(usage.GetQuotaBytes()).To(Equal(int64(1024 * 1024)))
+		Expect(usage.GetRemainingBytes()).To(Equal(int64(half)))
+
+		Expect(uploadBatch(createFile("b.jpg", half+1))).To(Equal(http.StatusInsufficientStorage))
+
+		_, httpResp, _ := setup.APIClient.AssetsAPI.UploadAsset(context.Background()).
+			Asset(createFile("c.jpg", half+1)).Execute()
+		Expect(httpResp.StatusCode).To(Equal(http.StatusInsufficientStorage))
+
+		// The remaining space can still be used
+		Expect(uploadBatch(createFile("d.jpg", half))).To(Equal(http.StatusOK))
+		usage = getUsage()
+		Expect(usage.GetAssetFiles()).To(Equal(int32(2)))
+		Expect(usage.GetRemainingBytes()).To(BeZero())
+	})
+
+	It("should reject uploads exceeding the file quota", func() {
+		setup.Cfg.UserQuotaFiles = 2
+
+		Expect(uploadBatch(createFile("a.jpg", 100), createFile("b.jpg", 100), createFile("c.jpg", 100))).
+			To(Equal(http.StatusInsufficientStorage))
+		Expect(uploadBatch(createFile("a.jpg", 100), createFile("b.jpg", 100))).To(Equal(http.StatusOK))
+
+		usage := getUsage()
+		Expect(usage.GetQuotaFiles()).To(Equal(int32(2)))
+		Expect(usage.GetRemainingFiles()).To(BeZero())
+		Expect(uploadBatch(createFile("c.jpg", 100))).To(Equal(http.StatusInsufficientStorage))
+	})
+
+	It("should reject items exceeding the item quota", func() {
+		setup.Cfg.UserQuotaItems = 1
+
+		itemsReq := *goclient.NewItemsRequest("2024-01-15", "Title", "Body")
+		_, _, err := setup.APIClient.ItemsAPI.PutItems(context.Background()).ItemsRequest(itemsReq).Execute()
+		Expect(err).ToNot(HaveOccurred())
+		itemsReq.Date = "2024-01-16"
+		_, httpResp, _ := setup.APIClient.ItemsAPI.PutItems(context.Background()).ItemsRequest(itemsReq).Execute()
+		Expect(httpResp.StatusCode).To(Equal(http.StatusInsufficientStorage))
+		Expect(getUsage().GetItems()).To(Equal(int32(1)))
+	})
+})
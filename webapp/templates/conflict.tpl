@@ -0,0 +1,48 @@
+{{ template "header.tpl" . }}
+
+<main>
+    <div class="alert alert-warning" role="alert">
+        {{ if .server }}
+        The entry for {{ .mine.Date }} was changed on another device after you started editing it. Your changes were not saved.
+        {{ else }}
+        The entry for {{ .mine.Date }} was deleted on another device after you started editing it. Your changes were not saved.
+        {{ end }}
+    </div>
+
+    <div class="row">
+        <div class="col-6">
+            <h5>Saved version</h5>
+            {{ with .server }}
+            <p class="fw-bold">{{ .Title }}</p>
+            <pre class="border rounded p-2">{{ .Body }}</pre>
+            <p>{{ range .Tags }}<span class="badge bg-secondary me-1">{{ . }}</span>{{ end }}</p>
+            <a href="/web/edit?date={{ .Date }}" class="btn btn-secondary">Discard my changes</a>
+            {{ else }}
+            <p class="text-muted">The entry no longer exists.</p>
+            <a href="/?date={{ .mine.Date }}" class="btn btn-secondary">Discard my changes</a>
+            {{ end }}
+        </div>
+        <div class="col-6">
+            <h5>Your version</h5>
+            <form action="/web/edit" method="POST">
+                <input type="hidden" name="date" value="{{ .mine.Date }}"/>
+                <input type="hidden" name="version" value="{{ .version }}"/>
+                <div class="mb-3">
+                    <label for="title" class="form-label">Title:</label>
+                    <input type="text" class="form-control" name="title" id="title" value="{{ .mine.Title }}"/>
+                </div>
+                <div class="mb-3">
+                    <label for="body" class="form-label">Body:</label>
+                    <textarea class="form-control" name="body" id="body" rows="10">{{ .mine.Body }}</textarea>
+                </div>
+                <div class="mb-3">
+                    <label for="tags" class="form-label">Tags</label>
+                    <input type="text" class="form-control" name="tags" id="tags" value="{{ .mineTags }}"/>
+                </div>
+                <button type="submit" class="btn btn-primary">Save my version</button>
+            </form>
+        </div>
+    </div>
+</main>
+
+{{ template "footer.tpl" . }}
@@ -0,0 +1,25 @@
+{{ template "header.tpl" . }}
+
+<main>
+    <div class="row justify-content-center">
+        <div class="col-md-6">
+            <div class="card border-danger">
+                <div class="card-body">
+                    <h5 class="card-title">Delete entry for {{ .item.Date }}?</h5>
+                    {{ with .item.Title }}
+                    <p class="card-text fw-bold">{{ . }}</p>
+                    {{ end }}
+                    <p class="card-text text-muted">The entry will be removed from all synchronized devices. It can be restored later from its history.</p>
+
+                    <form action="/web/delete" method="POST" class="d-inline">
+                        <input type="hidden" name="date" value="{{ .item.Date }}"/>
+                        <button type="submit" class="btn btn-danger">Delete</button>
+                    </form>
+                    <a href="/web/edit?date={{ .item.Date }}" class="btn btn-secondary">Cancel</a>
+                </div>
+            </div>
+        </div>
+    </div>
+</main>
+
+{{ template "footer.tpl" . }}
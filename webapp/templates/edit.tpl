@@ -26,9 +26,18 @@ $(document).ready(function () {
         $('#assets').append('<div class="card" style="width: 18rem;"><img src="' + src + '" id="' + imgId + '" class="card-img-top"></div>');
     }
 
+    // Suggest the entry of the day an uploaded photo was taken, if it isn't this one
+    function suggestCapturedDate(date) {
+        if (!date || date === '{{ .item.Date }}') { return; }
+        $('#captureSuggestion').empty().removeClass('d-none')
+            .append(document.createTextNode('Photo taken on ' + date + '. '))
+            .append($('<a class="alert-link"></a>').attr('href', '/web/edit?date=' + encodeURIComponent(date)).text('Open that entry'));
+    }
+
     // Upload immediately when files are selected (supports single or multiple)
     $('#imageUpload').on('change', function () {
         $('#uploadError').addClass('d-none').text('');
+        $('#captureSuggestion').addClass('d-none').empty();
         var fileInput = this;
         if (fileInput.files.length === 0) { return; }
 
@@ -54,9 +63,10 @@ $(document).ready(function () {
                     }
                     return xhr;
                 },
-                success: function (response) {
+                success: function (response, status, xhr) {
                     addImage(response);
                     insertAssetMarkdownFromId('dynamicImg_' + response);
+                    suggestCapturedDate(xhr.getResponseHeader('X-Captured-Date'));
                     $('#imageUpload').val('');
                     $('#uploadProgress').addClass('d-none');
                 },
@@ -98,6 +108,9 @@ $(document).ready(function () {
                             addImage(name);
                             insertAssetMarkdownFromId('dynamicImg_' + name);
                         });
+                        // The latest capture date of the batch is suggested
+                        var dates = Object.values(data.capturedDates || {}).sort();
+                        suggestCapturedDate(dates[dates.length - 1]);
                     } catch (e) { console.error('Invalid JSON', e); }
                     $('#imageUpload').val('');
                     $('#uploadProgress').addClass('d-none');
@@ -112,6 +125,34 @@ $(document).ready(function () {
         }
     });
 
+    // Tag autocomplete: suggest known tags matching the tag being typed
+    var knownTags = {{ .knownTags }};
+
+    function currentTags() {
+        return $('#tags').val().split(',').map(function (t) { return t.trim(); });
+    }
+
+    function updateTagSuggestions() {
+        var tags = currentTags();
+        var typed = tags[tags.length - 1].toLowerCase();
+        $('#tagSuggestions').empty();
+        knownTags.filter(function (tag) {
+            return tags.indexOf(tag) === -1 && tag.toLowerCase().indexOf(typed) === 0;
+        }).slice(0, 20).forEach(function (tag) {
+            $('<button type="button" class="btn btn-sm btn-outline-secondary me-1 mb-1"></button>')
+                .text(tag).attr('data-tag', tag).appendTo('#tagSuggestions');
+        });
+    }
+
+    $('#tags').on('input focus', updateTagSuggestions);
+
+    $('#tagSuggestions').on('click', 'button', function () {
+        var tags = currentTags();
+        tags[tags.length - 1] = $(this).attr('data-tag');
+        $('#tags').val(tags.filter(function (t) { return t !== ''; }).join(', ') + ', ').focus();
+        updateTagSuggestions();
+    });
+
     // Keep upload button as a shortcut to open file picker
     $('#uploadBtn').on('click', function () {
         $('#imageUpload').click();
@@ -131,6 +172,7 @@ $(document).ready(function () {
         <div class="col">
             <form action="/web/edit" method="POST">
                 <input type="hidden" name="date" value="{{ .item.Date }}"/>
+                <input type="hidden" name="version" value="{{ if .exists }}{{ .item.Version }}{{ else }}0{{ end }}"/>
                 <input type="hidden" id="user_id" value="{{ .UserID }}"/>
 
                 <h5>{{ .item.Date }}</h5>
@@ -147,10 +189,15 @@ $(document).ready(function () {
 
                 <div class="mb-3">
                     <label for="tags" class="form-label">Tags</label>
-                    <input type="text" class="form-control" name="tags" value="{{ range .item.Tags }}{{.}}, {{ end }}"/>
+                    <input type="text" class="form-control" name="tags" id="tags" autocomplete="off" value="{{ range .item.Tags }}{{.}}, {{ end }}"/>
+                    <div id="tagSuggestions" class="mt-2"></div>
                 </div>
 
                 <button type="submit" class="btn btn-primary">Save</button>
+                {{ if .exists }}
+                <a href="/web/delete?date={{ .item.Date }}" class="btn btn-outline-danger">Delete</a>
+                {{ end }}
+                <a href="/web/history?date={{ .item.Date }}" class="btn btn-outline-secondary">History</a>
             </form>
         </div>
         <div class="col-3 text-center">`
@@ -158,6 +205,7 @@ $(document).ready(function () {
             <button id="uploadBtn" class="btn btn-secondary">Upload asset(s)</button>
 
             <div id="uploadError" class="alert alert-danger d-none mt-2" role="alert"></div>
+            <div id="captureSuggestion" class="alert alert-info d-none mt-2" role="alert"></div>
             <div id="uploadProgress" class="progress d-none mt-2">
               <div class="progress-bar" role="progressbar" style="width: 0%;" aria-valuenow="0" aria-valuemin="0" aria-valuemax="100">0%</div>
             </div>
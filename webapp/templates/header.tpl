@@ -65,6 +65,18 @@
                         </button>
                     </form>
                     <ul class="navbar-nav ms-3">
+                        {{ with .Usage }}
+                        <li class="nav-item">
+                            <span class="navbar-text me-2" id="storageUsage"
+                                  title="{{ .Files }}{{ with .QuotaFiles }} of {{ . }}{{ end }} files">
+                                <i class="bi bi-hdd" aria-hidden="true"></i>
+                                {{ formatBytes .Bytes }}{{ with .QuotaBytes }} of {{ formatBytes . }}{{ end }}
+                                {{ with .Percent }}
+                                <span class="badge {{ if ge . 90 }}bg-danger{{ else }}bg-secondary{{ end }}">{{ . }}%</span>
+                                {{ end }}
+                            </span>
+                        </li>
+                        {{ end }}
                         <li class="nav-item">
                             <a class="nav-link" href="/web/logout">Logout</a>
                         </li>
@@ -0,0 +1,57 @@
+{{ template "header.tpl" . }}
+
+<main>
+    <div class="row">
+        <div class="col-3">
+            <h5>History of {{ .date }}</h5>
+            <div class="list-group">
+                {{ range .revisions }}
+                <a href="/web/history?date={{ $.date }}&rev={{ .Id }}"
+                   class="list-group-item list-group-item-action{{ if eq .Id $.selected.Id }} active{{ end }}">
+                    {{ .Timestamp.Format "2006-01-02 15:04:05" }}
+                    <div class="small text-truncate">{{ .Title }}</div>
+                </a>
+                {{ else }}
+                <div class="list-group-item text-muted">No saved versions</div>
+                {{ end }}
+            </div>
+            <a href="/web/edit?date={{ .date }}" class="btn btn-secondary mt-3">Back</a>
+        </div>
+        <div class="col-9">
+            {{ with .selected }}
+            <div class="d-flex justify-content-between align-items-center mb-2">
+                <h5 class="mb-0">Version of {{ .Timestamp.Format "2006-01-02 15:04:05" }}</h5>
+                <form action="/web/history/restore" method="POST">
+                    <input type="hidden" name="date" value="{{ .Date }}"/>
+                    <input type="hidden" name="id" value="{{ .Id }}"/>
+                    <button type="submit" class="btn btn-primary">Restore this version</button>
+                </form>
+            </div>
+
+            {{ with $.diff }}
+            <p class="text-muted">Changes compared to the previous version:</p>
+            <h6>Title</h6>
+            <pre class="border rounded p-2">{{ range .Title }}<div class="{{ if eq .Op "insert" }}bg-success-subtle{{ else if eq .Op "delete" }}bg-danger-subtle text-decoration-line-through{{ end }}">{{ if eq .Op "insert" }}+ {{ else if eq .Op "delete" }}- {{ else }}  {{ end }}{{ .Text }}</div>{{ end }}</pre>
+            <h6>Body</h6>
+            <pre class="border rounded p-2">{{ range .Body }}<div class="{{ if eq .Op "insert" }}bg-success-subtle{{ else if eq .Op "delete" }}bg-danger-subtle text-decoration-line-through{{ end }}">{{ if eq .Op "insert" }}+ {{ else if eq .Op "delete" }}- {{ else }}  {{ end }}{{ .Text }}</div>{{ end }}</pre>
+            {{ if or .TagsAdded .TagsRemoved }}
+            <h6>Tags</h6>
+            <p>
+                {{ range .TagsAdded }}<span class="badge bg-success me-1">+ {{ . }}</span>{{ end }}
+                {{ range .TagsRemoved }}<span class="badge bg-danger me-1">- {{ . }}</span>{{ end }}
+            </p>
+            {{ end }}
+            {{ else }}
+            <p class="text-muted">First saved version.</p>
+            <h6>Title</h6>
+            <pre class="border rounded p-2">{{ .Title }}</pre>
+            <h6>Body</h6>
+            <pre class="border rounded p-2">{{ .Body }}</pre>
+            <p>{{ range .Tags }}<span class="badge bg-secondary me-1">{{ . }}</span>{{ end }}</p>
+            {{ end }}
+            {{ end }}
+        </div>
+    </div>
+</main>
+
+{{ template "footer.tpl" . }}
@@ -5,7 +5,9 @@
         <div class="d-flex justify-content-between align-items-center mb-2 mb-md-3">
             <div class="search-header">
                 <h1 class="h3 mb-0">Search Results</h1>
-                {{ if .searchQuery }}
+                {{ if .searchError }}
+                    <p class="text-danger mb-0">{{ .searchError }}: "{{ .searchQuery }}"</p>
+                {{ else if .searchQuery }}
                     <p class="text-muted mb-0">
                         {{ if .totalCount }}
                             Found {{ .totalCount }} result{{ if ne .totalCount 1 }}s{{ end }} for "{{ .searchQuery }}"
@@ -13,10 +15,13 @@
                             No results found for "{{ .searchQuery }}"
                         {{ end }}
                     </p>
-                {{ else if .searchTags }}
+                {{ else if .hasSearchTags }}
                     <p class="text-muted mb-0">
                         {{ if .totalCount }}
-                            Found {{ .totalCount }} result{{ if ne .totalCount 1 }}s{{ end }} with tags: {{ range $i, $tag := .searchTags }}{{ if $i }}, {{ end }}<span class="badge bg-secondary">{{ $tag }}</span>{{ end }}
+                            Found {{ .totalCount }} result{{ if ne .totalCount 1 }}s{{ end }}
+                            {{- if .searchTags }} with any of tags: {{ range $i, $tag := .searchTags }}{{ if $i }}, {{ end }}<span class="badge bg-secondary">{{ $tag }}</span>{{ end }}{{ end }}
+                            {{- if .searchTagsAll }} with all of tags: {{ range $i, $tag := .searchTagsAll }}{{ if $i }}, {{ end }}<span class="badge bg-primary">{{ $tag }}</span>{{ end }}{{ end }}
+                            {{- if .searchTagsNone }} without tags: {{ range $i, $tag := .searchTagsNone }}{{ if $i }}, {{ end }}<span class="badge bg-light text-dark text-decoration-line-through">{{ $tag }}</span>{{ end }}{{ end }}
                         {{ else }}
                             No results found with the specified tags
                         {{ end }}
@@ -32,8 +37,49 @@
                 {{ end }}
             </div>
 
-            {{ template "layout-toggle" . }}
+            <div class="d-flex align-items-center gap-2">
+                <form class="search-sort-form d-flex align-items-center gap-1" action="/web/search" method="GET" aria-label="Sort results">
+                    {{ if .searchQuery }}<input type="hidden" name="search" value="{{ .searchQuery }}">{{ end }}
+                    {{ if .searchTagsParam }}<input type="hidden" name="tags" value="{{ .searchTagsParam }}">{{ end }}
+                    {{ if .searchTagsAllParam }}<input type="hidden" name="tagsAll" value="{{ .searchTagsAllParam }}">{{ end }}
+                    {{ if .searchTagsNoneParam }}<input type="hidden" name="tagsNone" value="{{ .searchTagsNoneParam }}">{{ end }}
+                    {{ if .searchDate }}<input type="hidden" name="date" value="{{ .searchDate }}">{{ end }}
+                    {{ if .dateFrom }}<input type="hidden" name="dateFrom" value="{{ .dateFrom }}">{{ end }}
+                    {{ if .dateTo }}<input type="hidden" name="dateTo" value="{{ .dateTo }}">{{ end }}
+                    <label for="sortSelect" class="visually-hidden">Sort by</label>
+                    <select id="sortSelect" name="sort" class="form-select form-select-sm" onchange="this.form.submit()">
+                        <option value="" {{ if not .sort }}selected{{ end }}>Default order</option>
+                        {{ if .searchQuery }}<option value="relevance" {{ if eq .sort "relevance" }}selected{{ end }}>Most relevant</option>{{ end }}
+                        <option value="date_desc" {{ if eq .sort "date_desc" }}selected{{ end }}>Newest first</option>
+                        <option value="date_asc" {{ if eq .sort "date_asc" }}selected{{ end }}>Oldest first</option>
+                    </select>
+                    <noscript><button type="submit" class="btn btn-outline-secondary btn-sm">Sort</button></noscript>
+                </form>
+                {{ template "layout-toggle" . }}
+            </div>
         </div>
+
+        <form class="search-date-range d-flex flex-wrap align-items-center gap-2 mb-2 mb-md-3" action="/web/search" method="GET" aria-label="Filter by tags and date">
+            {{ if .searchQuery }}<input type="hidden" name="search" value="{{ .searchQuery }}">{{ end }}
+            {{ if .sort }}<input type="hidden" name="sort" value="{{ .sort }}">{{ end }}
+            <label for="tagsInput" class="visually-hidden">Any of tags</label>
+            <input id="tagsInput" type="text" name="tags" value="{{ .searchTagsParam }}" placeholder="Any of tags" class="form-control form-control-sm w-auto">
+            <label for="tagsAllInput" class="visually-hidden">All of tags</label>
+            <input id="tagsAllInput" type="text" name="tagsAll" value="{{ .searchTagsAllParam }}" placeholder="All of tags" class="form-control form-control-sm w-auto">
+            <label for="tagsNoneInput" class="visually-hidden">Without tags</label>
+            <input id="tagsNoneInput" type="text" name="tagsNone" value="{{ .searchTagsNoneParam }}" placeholder="Without tags" class="form-control form-control-sm w-auto">
+            <label for="dateFromInput" class="small text-muted">From</label>
+            <input id="dateFromInput" type="date" name="dateFrom" value="{{ .dateFrom }}" class="form-control form-control-sm w-auto">
+            <label for="dateToInput" class="small text-muted">To</label>
+            <input id="dateToInput" type="date" name="dateTo" value="{{ .dateTo }}" class="form-control form-control-sm w-auto">
+            <button type="submit" class="btn btn-outline-secondary btn-sm">Apply</button>
+            {{ range .dateShortcuts }}
+                <a href="{{ .URL }}" class="btn btn-sm {{ if .Active }}btn-secondary{{ else }}btn-outline-secondary{{ end }}">{{ .Label }}</a>
+            {{ end }}
+            {{ if .hasDateRange }}
+                <a href="{{ .clearDateRangeURL }}" class="btn btn-link btn-sm">Any date</a>
+            {{ end }}
+        </form>
     </header>
 
     <div class="diary-content-wrapper">
@@ -65,7 +111,9 @@
                             </header>
                             
                             <div class="diary-entry-preview mt-3">
-                                {{ if .Body }}
+                                {{ if .Snippet }}
+                                    <div class="diary-entry-body diary-entry-snippet">{{- .Snippet }} <a href="/?date={{ .Date }}" class="text-primary ms-2" aria-label="Read full entry for {{ .Date }}">Read more...</a></div>
+                                {{ else if .Body }}
                                     <div class="diary-entry-body">{{- if gt (len .Body) 300 -}}{{- snippet .Body 300 -}}... <a href="/?date={{ .Date }}" class="text-primary ms-2" aria-label="Read full entry for {{ .Date }}">Read more...</a>{{- else -}}{{- .Body -}}{{- end -}}</div>
                                 {{ else }}
                                     <p class="text-muted fst-italic">No content</p>
@@ -83,11 +131,30 @@
                         </article>
                     {{ end }}
                 </div>
+                {{ if gt .totalPages 1 }}
+                    <nav class="search-pagination d-flex justify-content-between align-items-center mb-4" aria-label="Search results pages">
+                        {{ if .prevPageURL }}
+                            <a href="{{ .prevPageURL }}" class="btn btn-outline-secondary btn-sm" rel="prev">
+                                <i class="bi bi-chevron-left" aria-hidden="true"></i> Previous
+                            </a>
+                        {{ else }}
+                            <span></span>
+                        {{ end }}
+                        <span class="text-muted small">Page {{ .page }} of {{ .totalPages }}</span>
+                        {{ if .nextPageURL }}
+                            <a href="{{ .nextPageURL }}" class="btn btn-outline-secondary btn-sm" rel="next">
+                                Next <i class="bi bi-chevron-right" aria-hidden="true"></i>
+                            </a>
+                        {{ else }}
+                            <span></span>
+                        {{ end }}
+                    </nav>
+                {{ end }}
             {{ else }}
                 <div class="diary-empty-state text-center py-5">
                     <i class="bi bi-search display-1 text-muted mb-3" aria-hidden="true"></i>
                     <h2 class="h4 text-muted mb-3">No entries found</h2>
-                    {{ if or .searchQuery .searchTags }}
+                    {{ if or .searchQuery .hasSearchTags }}
                         <p class="text-muted mb-4">Try adjusting your search terms or browse all entries.</p>
                         <div class="d-flex gap-2 justify-content-center">
                             <a href="/web/search" class="btn btn-outline-primary">